@@ -1,33 +1,132 @@
 package database
 
 import (
+	"context"
+	"fmt"
+	"go-api-rest/internal/config"
 	"go-api-rest/pkg/logger"
+	"strings"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	gormLogger "gorm.io/gorm/logger"
+)
+
+// Drivers de armazenamento suportados por config.DatabaseConfig.Driver
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory" // sem banco: usa repository.NewMemoryPersonalityRepository
 )
 
 // Database representa a conexão com o banco de dados
 type Database struct {
-	DB *gorm.DB
+	DB       *gorm.DB
+	Metrics  *QueryMetrics
+	replicas *replicaSet
 }
 
-// NewDatabase cria uma nova conexão com o banco de dados
-func NewDatabase(dsn string) (*Database, error) {
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: gormLogger.Default.LogMode(gormLogger.Silent),
+// NewDatabase cria uma nova conexão com o banco de dados, tentando novamente
+// com backoff exponencial até cfg.ConnectTimeout. Os observers adicionais
+// recebem a duração de cada query, junto com as métricas internas.
+func NewDatabase(cfg config.DatabaseConfig, observers ...QueryObserver) (*Database, error) {
+	dialector, err := newDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := NewQueryMetrics()
+	gormConfig := &gorm.Config{
+		// Converte violações de unicidade em gorm.ErrDuplicatedKey em todos os drivers
+		TranslateError: true,
+		Logger: NewGormLogger(GormLoggerConfig{
+			LogLevel:      ParseLogLevel(cfg.LogLevel),
+			SlowThreshold: cfg.SlowQueryThreshold,
+			SampleRate:    cfg.QuerySampleRate,
+			RedactParams:  cfg.RedactQueryParams,
+			Observer:      multiObserver(append([]QueryObserver{metrics}, observers...)),
+		}),
+	}
+
+	policy := RetryPolicy{
+		Initial:  cfg.ConnectRetryInitial,
+		Max:      cfg.ConnectRetryMax,
+		Deadline: cfg.ConnectTimeout,
+	}
+
+	var db *gorm.DB
+	err = retry(context.Background(), policy, "Conexão com banco de dados", func() error {
+		var err error
+		db, err = gorm.Open(dialector, gormConfig)
+		if err != nil && db != nil {
+			// O GORM devolve a conexão mesmo quando o ping falha
+			if sqlDB, dbErr := db.DB(); dbErr == nil {
+				sqlDB.Close()
+			}
+		}
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Info("Conexão com banco de dados estabelecida com sucesso")
-	return &Database{DB: db}, nil
+	if err := configurePool(db, cfg); err != nil {
+		return nil, err
+	}
+
+	database := &Database{DB: db, Metrics: metrics}
+	if len(cfg.ReplicaDSNs) > 0 && cfg.Driver == DriverPostgres {
+		if database.replicas, err = registerReplicas(db, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Info("Conexão com banco de dados estabelecida com sucesso",
+		"driver", cfg.Driver,
+		"max_open_conns", cfg.MaxOpenConns,
+		"max_idle_conns", cfg.MaxIdleConns,
+		"replicas", len(cfg.ReplicaDSNs),
+	)
+	return database, nil
+}
+
+// newDialector cria o dialector do GORM para o driver configurado
+func newDialector(cfg config.DatabaseConfig) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case "", DriverPostgres:
+		return postgres.Open(cfg.DSN()), nil
+	case DriverSQLite:
+		// Driver SQLite em Go puro, sem CGO; aceita caminhos e URIs "file:"
+		sep := "?"
+		if strings.Contains(cfg.SQLitePath, "?") {
+			sep = "&"
+		}
+		return sqlite.Open(cfg.SQLitePath + sep + "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"), nil
+	default:
+		return nil, fmt.Errorf("driver de banco de dados não suportado: %s", cfg.Driver)
+	}
 }
 
-// Close fecha a conexão com o banco de dados
+// configurePool aplica os limites do pool de conexões
+func configurePool(db *gorm.DB, cfg config.DatabaseConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return nil
+}
+
+// Close fecha a conexão com o banco de dados e com as réplicas
 func (d *Database) Close() error {
+	if d.replicas != nil {
+		if err := d.replicas.close(); err != nil {
+			return err
+		}
+	}
 	sqlDB, err := d.DB.DB()
 	if err != nil {
 		return err
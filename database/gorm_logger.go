@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api-rest/pkg/logger"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// QueryObserver recebe a duração de cada query executada pelo GORM
+type QueryObserver interface {
+	ObserveQuery(operation string, duration time.Duration, err error)
+}
+
+// multiObserver repassa cada query a vários observers
+type multiObserver []QueryObserver
+
+func (m multiObserver) ObserveQuery(operation string, duration time.Duration, err error) {
+	for _, observer := range m {
+		observer.ObserveQuery(operation, duration, err)
+	}
+}
+
+// GormLoggerConfig configura o adaptador de logs do GORM
+type GormLoggerConfig struct {
+	LogLevel      gormLogger.LogLevel
+	SlowThreshold time.Duration // queries acima deste tempo são registradas como alerta
+	SampleRate    float64       // fração das queries comuns registradas no nível info (0 a 1)
+	RedactParams  bool          // omite os valores dos parâmetros no SQL registrado
+	Observer      QueryObserver
+}
+
+// gormLogAdapter encaminha os logs do GORM para o pkg/logger
+type gormLogAdapter struct {
+	cfg GormLoggerConfig
+}
+
+// NewGormLogger cria um gormLogger.Interface que usa o pkg/logger com os campos da requisição
+func NewGormLogger(cfg GormLoggerConfig) gormLogger.Interface {
+	return &gormLogAdapter{cfg: cfg}
+}
+
+func (l *gormLogAdapter) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
+	cfg := l.cfg
+	cfg.LogLevel = level
+	return &gormLogAdapter{cfg: cfg}
+}
+
+func (l *gormLogAdapter) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.cfg.LogLevel >= gormLogger.Info {
+		logger.InfoContext(ctx, fmt.Sprintf(msg, data...), "component", "gorm")
+	}
+}
+
+func (l *gormLogAdapter) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.cfg.LogLevel >= gormLogger.Warn {
+		logger.WarnContext(ctx, fmt.Sprintf(msg, data...), "component", "gorm")
+	}
+}
+
+func (l *gormLogAdapter) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.cfg.LogLevel >= gormLogger.Error {
+		logger.ErrorContext(ctx, fmt.Sprintf(msg, data...), "component", "gorm")
+	}
+}
+
+// Trace é chamado pelo GORM ao final de cada query
+func (l *gormLogAdapter) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	operation := queryOperation(sql)
+
+	if l.cfg.Observer != nil {
+		l.cfg.Observer.ObserveQuery(operation, elapsed, err)
+	}
+
+	if l.cfg.LogLevel <= gormLogger.Silent {
+		return
+	}
+
+	attrs := []any{
+		"component", "gorm",
+		"operation", operation,
+		"sql", sql,
+		"rows", rows,
+		"duration", elapsed,
+	}
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.cfg.LogLevel >= gormLogger.Error:
+		logger.ErrorContext(ctx, "Erro ao executar query", append(attrs, "error", err)...)
+	case l.cfg.SlowThreshold > 0 && elapsed > l.cfg.SlowThreshold && l.cfg.LogLevel >= gormLogger.Warn:
+		logger.WarnContext(ctx, "Query lenta", append(attrs, "threshold", l.cfg.SlowThreshold)...)
+	case l.cfg.LogLevel >= gormLogger.Info && l.sampled():
+		// No nível escolhido em DB_LOG_LEVEL; em debug o nível padrão do
+		// logger descartaria as queries amostradas
+		logger.InfoContext(ctx, "Query executada", attrs...)
+	}
+}
+
+// ParamsFilter remove os parâmetros do SQL registrado quando a redação está ativa
+func (l *gormLogAdapter) ParamsFilter(_ context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.cfg.RedactParams {
+		return sql, nil
+	}
+	return sql, params
+}
+
+// sampled decide se uma query comum deve ser registrada
+func (l *gormLogAdapter) sampled() bool {
+	return l.cfg.SampleRate >= 1 || (l.cfg.SampleRate > 0 && rand.Float64() < l.cfg.SampleRate)
+}
+
+// queryOperation extrai o comando SQL (SELECT, INSERT...) usado como rótulo das métricas
+func queryOperation(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if i := strings.IndexAny(sql, " \n\t("); i > 0 {
+		sql = sql[:i]
+	}
+	switch operation := strings.ToUpper(sql); operation {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "BEGIN", "COMMIT", "ROLLBACK":
+		return operation
+	default:
+		return "OTHER"
+	}
+}
+
+// ParseLogLevel converte o nome do nível ("silent", "error", "warn", "info") para o GORM
+func ParseLogLevel(name string) gormLogger.LogLevel {
+	switch strings.ToLower(name) {
+	case "silent":
+		return gormLogger.Silent
+	case "error":
+		return gormLogger.Error
+	case "info":
+		return gormLogger.Info
+	default:
+		return gormLogger.Warn
+	}
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go-api-rest/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// testRecord é a tabela usada nos testes do adaptador de logs
+type testRecord struct {
+	ID   uint
+	Name string
+}
+
+// openTestDB abre um sqlite em memória com o adaptador configurado; a tabela
+// é criada antes, sem passar pelo adaptador
+func openTestDB(t *testing.T, cfg GormLoggerConfig) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormLogger.Discard})
+	if err != nil {
+		t.Fatalf("Erro ao abrir o sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	if err := db.AutoMigrate(&testRecord{}); err != nil {
+		t.Fatalf("Erro ao criar a tabela: %v", err)
+	}
+	return db.Session(&gorm.Session{Logger: NewGormLogger(cfg)})
+}
+
+// captureLogs direciona o logger padrão, no nível info, para o buffer
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	previousLevel := logger.Level()
+	t.Cleanup(func() { logger.Setup(logger.Options{Level: previousLevel.String()}) })
+
+	var buf bytes.Buffer
+	if err := logger.Setup(logger.Options{Level: "info", Format: "json", Output: &buf}); err != nil {
+		t.Fatalf("Erro ao configurar logger: %v", err)
+	}
+	return &buf
+}
+
+// queryLogs devolve as linhas registradas para a operação
+func queryLogs(t *testing.T, buf *bytes.Buffer, operation string) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Log não é JSON válido: %q", line)
+		}
+		if entry["operation"] == operation {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func TestGormLogger_Trace(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        GormLoggerConfig
+		level      string // nível esperado do log; vazio quando nada é registrado
+		message    string
+		wantParams bool
+	}{
+		{"query lenta", GormLoggerConfig{LogLevel: gormLogger.Warn, SlowThreshold: time.Nanosecond, RedactParams: true}, "WARN", "Query lenta", false},
+		{"abaixo do limite", GormLoggerConfig{LogLevel: gormLogger.Warn, SlowThreshold: time.Hour, SampleRate: 1}, "", "", false},
+		{"lenta em silent", GormLoggerConfig{LogLevel: gormLogger.Silent, SlowThreshold: time.Nanosecond}, "", "", false},
+		{"amostrada em info", GormLoggerConfig{LogLevel: gormLogger.Info, SampleRate: 1, RedactParams: true}, "INFO", "Query executada", false},
+		{"fora da amostra", GormLoggerConfig{LogLevel: gormLogger.Info, SampleRate: 0}, "", "", false},
+		{"parâmetros visíveis", GormLoggerConfig{LogLevel: gormLogger.Info, SampleRate: 1}, "INFO", "Query executada", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := captureLogs(t)
+			db := openTestDB(t, tt.cfg)
+
+			if err := db.Create(&testRecord{Name: "Ada Lovelace"}).Error; err != nil {
+				t.Fatalf("Erro inesperado: %v", err)
+			}
+
+			entries := queryLogs(t, buf, "INSERT")
+			if tt.level == "" {
+				if len(entries) != 0 {
+					t.Fatalf("Esperava nenhum log, obteve %v", entries)
+				}
+				return
+			}
+			if len(entries) != 1 {
+				t.Fatalf("Esperava um log do INSERT, obteve %v", entries)
+			}
+			entry := entries[0]
+			if entry["level"] != tt.level || entry["msg"] != tt.message || entry["component"] != "gorm" {
+				t.Errorf("Esperava %s %q, obteve %v", tt.level, tt.message, entry)
+			}
+			sql, _ := entry["sql"].(string)
+			if got := strings.Contains(sql, "Ada Lovelace"); got != tt.wantParams {
+				t.Errorf("Parâmetros no SQL registrado: esperava %v, obteve %q", tt.wantParams, sql)
+			}
+		})
+	}
+}
+
+func TestGormLogger_Errors(t *testing.T) {
+	buf := captureLogs(t)
+	db := openTestDB(t, GormLoggerConfig{LogLevel: gormLogger.Error})
+
+	// Registro ausente não é um erro da query
+	var record testRecord
+	if err := db.First(&record, 1).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Esperava gorm.ErrRecordNotFound, obteve %v", err)
+	}
+	if entries := queryLogs(t, buf, "SELECT"); len(entries) != 0 {
+		t.Errorf("Registro ausente não deveria ser registrado, obteve %v", entries)
+	}
+
+	if err := db.Exec("SELECT * FROM tabela_inexistente").Error; err == nil {
+		t.Fatal("Esperava erro na consulta")
+	}
+	entries := queryLogs(t, buf, "SELECT")
+	if len(entries) != 1 || entries[0]["level"] != "ERROR" || entries[0]["error"] == nil {
+		t.Errorf("Esperava um log de erro com a causa, obteve %v", entries)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := map[string]gormLogger.LogLevel{
+		"silent":       gormLogger.Silent,
+		"ERROR":        gormLogger.Error,
+		"warn":         gormLogger.Warn,
+		"info":         gormLogger.Info,
+		"desconhecido": gormLogger.Warn,
+	}
+	for name, want := range tests {
+		if got := ParseLogLevel(name); got != want {
+			t.Errorf("ParseLogLevel(%q): esperava %v, obteve %v", name, want, got)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Ping verifica se o banco de dados está acessível
+func (d *Database) Ping(ctx context.Context) error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// MigrationsCheck retorna um check que falha quando existem tabelas ou
+// colunas dos modelos informados ainda não criadas no banco
+func (d *Database) MigrationsCheck(models ...interface{}) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		pending, err := d.PendingMigrations(ctx, models...)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("migrations pendentes: %s", strings.Join(pending, ", "))
+		}
+		return nil
+	}
+}
+
+// PendingMigrations lista as tabelas e colunas dos modelos que não existem no banco
+func (d *Database) PendingMigrations(ctx context.Context, models ...interface{}) ([]string, error) {
+	db := d.DB.WithContext(ctx)
+	migrator := db.Migrator()
+
+	var pending []string
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(model) {
+			pending = append(pending, table)
+			continue
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" && !migrator.HasColumn(model, field.DBName) {
+				pending = append(pending, table+"."+field.DBName)
+			}
+		}
+	}
+	return pending, nil
+}
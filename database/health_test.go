@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"go-api-rest/internal/health"
+	"go-api-rest/models"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+func TestPendingMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(db *gorm.DB) error
+		pending []string
+	}{
+		{"tabela ausente", func(*gorm.DB) error { return nil }, []string{"personalities"}},
+		{"coluna ausente", func(db *gorm.DB) error {
+			// Esquema anterior à coluna version
+			return db.Exec("CREATE TABLE personalities (id integer PRIMARY KEY, name text NOT NULL UNIQUE, history text NOT NULL, created_at datetime, updated_at datetime)").Error
+		}, []string{"personalities.version"}},
+		{"esquema migrado", func(db *gorm.DB) error { return db.AutoMigrate(&models.Personality{}) }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormLogger.Discard})
+			if err != nil {
+				t.Fatalf("Erro ao abrir o sqlite: %v", err)
+			}
+			t.Cleanup(func() {
+				if sqlDB, err := db.DB(); err == nil {
+					sqlDB.Close()
+				}
+			})
+			if err := tt.setup(db); err != nil {
+				t.Fatalf("Erro ao preparar o esquema: %v", err)
+			}
+			d := &Database{DB: db}
+
+			pending, err := d.PendingMigrations(context.Background(), &models.Personality{})
+			if err != nil {
+				t.Fatalf("Erro inesperado: %v", err)
+			}
+			if !reflect.DeepEqual(pending, tt.pending) {
+				t.Errorf("Esperava pendências %v, obteve %v", tt.pending, pending)
+			}
+
+			// Com pendências o check crítico derruba o /readyz
+			registry := health.NewRegistry(time.Second)
+			registry.Register(health.Check{Name: "migrations", Critical: true, Check: d.MigrationsCheck(&models.Personality{})})
+			rec := httptest.NewRecorder()
+			registry.ReadinessHandler()(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			want := http.StatusOK
+			if len(tt.pending) > 0 {
+				want = http.StatusServiceUnavailable
+			}
+			if rec.Code != want {
+				t.Errorf("Esperava /readyz com status %d, obteve %d: %s", want, rec.Code, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// QueryStats acumula as métricas de uma operação SQL
+type QueryStats struct {
+	Operation string
+	Count     int64
+	Errors    int64
+	Total     time.Duration
+	Max       time.Duration
+}
+
+// Average retorna a duração média das queries
+func (s QueryStats) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// QueryMetrics agrega a duração das queries por operação
+type QueryMetrics struct {
+	mu    sync.Mutex
+	stats map[string]*QueryStats
+}
+
+// NewQueryMetrics cria um agregador de métricas vazio
+func NewQueryMetrics() *QueryMetrics {
+	return &QueryMetrics{stats: make(map[string]*QueryStats)}
+}
+
+// ObserveQuery registra a execução de uma query
+func (m *QueryMetrics) ObserveQuery(operation string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, exists := m.stats[operation]
+	if !exists {
+		stats = &QueryStats{Operation: operation}
+		m.stats[operation] = stats
+	}
+	stats.Count++
+	stats.Total += duration
+	if duration > stats.Max {
+		stats.Max = duration
+	}
+	if err != nil {
+		stats.Errors++
+	}
+}
+
+// Snapshot retorna uma cópia das métricas ordenada por operação
+func (m *QueryMetrics) Snapshot() []QueryStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make([]QueryStats, 0, len(m.stats))
+	for _, stats := range m.stats {
+		snapshot = append(snapshot, *stats)
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		return snapshot[i].Operation < snapshot[j].Operation
+	})
+	return snapshot
+}
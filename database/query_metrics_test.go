@@ -0,0 +1,83 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	gormLogger "gorm.io/gorm/logger"
+)
+
+func TestQueryMetrics_AggregatesByOperation(t *testing.T) {
+	metrics := NewQueryMetrics()
+	db := openTestDB(t, GormLoggerConfig{LogLevel: gormLogger.Silent, Observer: metrics})
+
+	for _, name := range []string{"Ada Lovelace", "Alan Turing"} {
+		if err := db.Create(&testRecord{Name: name}).Error; err != nil {
+			t.Fatalf("Erro inesperado: %v", err)
+		}
+	}
+	var records []testRecord
+	if err := db.Find(&records).Error; err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if err := db.Exec("SELECT * FROM tabela_inexistente").Error; err == nil {
+		t.Fatal("Esperava erro na consulta")
+	}
+
+	// Com o log em silent as métricas continuam sendo registradas
+	want := map[string]struct{ count, errors int64 }{
+		"INSERT": {2, 0},
+		"SELECT": {2, 1},
+	}
+	snapshot := metrics.Snapshot()
+	if len(snapshot) != len(want) {
+		t.Fatalf("Esperava as operações INSERT e SELECT, obteve %+v", snapshot)
+	}
+	for i, stats := range snapshot {
+		if i > 0 && snapshot[i-1].Operation >= stats.Operation {
+			t.Errorf("Esperava operações em ordem, obteve %q antes de %q", snapshot[i-1].Operation, stats.Operation)
+		}
+		expected, ok := want[stats.Operation]
+		if !ok || stats.Count != expected.count || stats.Errors != expected.errors {
+			t.Errorf("%s: esperava %d queries e %d erros, obteve %+v", stats.Operation, expected.count, expected.errors, stats)
+		}
+		if stats.Max <= 0 || stats.Total < stats.Max || stats.Average() > stats.Max {
+			t.Errorf("%s: durações inconsistentes: %+v", stats.Operation, stats)
+		}
+	}
+}
+
+func TestQueryStats_Average(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats QueryStats
+		want  time.Duration
+	}{
+		{"sem queries", QueryStats{}, 0},
+		{"média", QueryStats{Count: 4, Total: 10 * time.Millisecond}, 2500 * time.Microsecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.Average(); got != tt.want {
+				t.Errorf("Esperava %v, obteve %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQueryOperation(t *testing.T) {
+	tests := map[string]string{
+		"SELECT * FROM personalities":         "SELECT",
+		"  insert INTO personalities (name)":  "INSERT",
+		"UPDATE\n personalities SET name = ?": "UPDATE",
+		"DELETE FROM personalities":           "DELETE",
+		"BEGIN":                               "BEGIN",
+		"WITH recentes AS (SELECT 1)":         "OTHER",
+		"":                                    "OTHER",
+	}
+	for sql, want := range tests {
+		if got := queryOperation(sql); got != want {
+			t.Errorf("queryOperation(%q): esperava %s, obteve %s", sql, want, got)
+		}
+	}
+}
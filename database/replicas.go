@@ -0,0 +1,176 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-api-rest/internal/config"
+	"go-api-rest/pkg/logger"
+	"go-api-rest/pkg/reqctx"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// Políticas de balanceamento entre réplicas
+const (
+	PolicyRoundRobin       = "round_robin"
+	PolicyLeastConnections = "least_connections"
+)
+
+// replica representa uma réplica de leitura e seu estado de saúde
+type replica struct {
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// replicaSet escolhe a réplica de cada leitura e monitora sua saúde.
+// Implementa dbresolver.Policy; quando nenhuma réplica está saudável a
+// leitura é redirecionada ao primário.
+type replicaSet struct {
+	primary  *sql.DB
+	replicas map[gorm.ConnPool]*replica
+	policy   string
+	next     atomic.Uint64
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// Resolve escolhe a conexão para uma leitura
+func (s *replicaSet) Resolve(pools []gorm.ConnPool) gorm.ConnPool {
+	healthy := make([]*replica, 0, len(pools))
+	for _, pool := range pools {
+		if r, ok := s.replicas[pool]; ok && r.healthy.Load() {
+			healthy = append(healthy, r)
+		}
+	}
+	if len(healthy) == 0 {
+		return s.primary
+	}
+
+	if s.policy == PolicyLeastConnections {
+		chosen := healthy[0]
+		for _, r := range healthy[1:] {
+			if r.db.Stats().InUse < chosen.db.Stats().InUse {
+				chosen = r
+			}
+		}
+		return chosen.db
+	}
+	return healthy[int(s.next.Add(1)%uint64(len(healthy)))].db
+}
+
+// monitor verifica periodicamente cada réplica com um ping
+func (s *replicaSet) monitor(interval, timeout time.Duration) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			s.probe(timeout)
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// probe atualiza o estado de saúde das réplicas, registrando as mudanças
+func (s *replicaSet) probe(timeout time.Duration) {
+	for _, r := range s.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := r.db.PingContext(ctx)
+		cancel()
+
+		healthy := err == nil
+		if r.healthy.Swap(healthy) != healthy {
+			if healthy {
+				logger.Info("Réplica de leitura disponível novamente")
+			} else {
+				logger.Warn("Réplica de leitura indisponível, leituras redirecionadas", "error", err)
+			}
+		}
+	}
+}
+
+// close interrompe o monitoramento e fecha as conexões das réplicas
+func (s *replicaSet) close() error {
+	close(s.stop)
+	s.wg.Wait()
+	var firstErr error
+	for _, r := range s.replicas {
+		if err := r.db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// registerReplicas habilita o roteamento de leituras para as réplicas configuradas
+func registerReplicas(db *gorm.DB, cfg config.DatabaseConfig) (*replicaSet, error) {
+	primary, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	set := &replicaSet{
+		primary:  primary,
+		replicas: make(map[gorm.ConnPool]*replica, len(cfg.ReplicaDSNs)),
+		policy:   cfg.ReplicaPolicy,
+		stop:     make(chan struct{}),
+	}
+
+	// O primário entra na lista de réplicas para que a política seja sempre
+	// consultada, mesmo com uma única réplica, e possa fazer o fallback
+	dialectors := []gorm.Dialector{postgres.New(postgres.Config{Conn: primary})}
+	for _, dsn := range cfg.ReplicaDSNs {
+		replicaDB, err := sql.Open("pgx", dsn)
+		if err != nil {
+			set.close()
+			return nil, fmt.Errorf("erro ao abrir réplica: %w", err)
+		}
+		replicaDB.SetMaxOpenConns(cfg.MaxOpenConns)
+		replicaDB.SetMaxIdleConns(cfg.MaxIdleConns)
+		replicaDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+		replicaDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+		set.replicas[replicaDB] = &replica{db: replicaDB}
+		dialectors = append(dialectors, postgres.New(postgres.Config{Conn: replicaDB}))
+	}
+
+	// Réplicas indisponíveis na inicialização não devem impedir o registro
+	db.Config.DisableAutomaticPing = true
+	if err := db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   set,
+	})); err != nil {
+		set.close()
+		return nil, err
+	}
+
+	set.monitor(cfg.ReplicaHealthInterval, cfg.ReplicaHealthTimeout)
+	return set, nil
+}
+
+// ReadDB retorna a conexão para leituras. Quando o contexto pede leitura no
+// primário (read-your-writes), a réplica é ignorada. O retorno é uma nova
+// sessão, que pode iniciar várias consultas sem acumular condições.
+func ReadDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if reqctx.PrimaryRead(ctx) {
+		return PrimaryDB(ctx, db)
+	}
+	return db.WithContext(ctx)
+}
+
+// PrimaryDB retorna a conexão no primário, usada por leituras que protegem
+// escritas; como em ReadDB, o retorno é uma nova sessão
+func PrimaryDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return db.WithContext(ctx).Clauses(dbresolver.Write).Session(&gorm.Session{})
+}
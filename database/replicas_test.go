@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"go-api-rest/pkg/reqctx"
+	"testing"
+
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+func newTestReplicaSet(t *testing.T, policy string, replicas int) (*replicaSet, []gorm.ConnPool) {
+	t.Helper()
+	open := func() *sql.DB {
+		// sql.Open não conecta; os pools servem apenas como identidade
+		db, err := sql.Open("pgx", "host=localhost")
+		if err != nil {
+			t.Fatalf("Erro ao abrir pool: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return db
+	}
+
+	set := &replicaSet{primary: open(), replicas: make(map[gorm.ConnPool]*replica), policy: policy}
+	pools := []gorm.ConnPool{set.primary}
+	for i := 0; i < replicas; i++ {
+		db := open()
+		set.replicas[db] = &replica{db: db}
+		pools = append(pools, db)
+	}
+	return set, pools
+}
+
+func TestReplicaSet_RoundRobinOverHealthyReplicas(t *testing.T) {
+	set, pools := newTestReplicaSet(t, PolicyRoundRobin, 3)
+	for _, pool := range pools[1:] {
+		set.replicas[pool].healthy.Store(true)
+	}
+	set.replicas[pools[2]].healthy.Store(false)
+
+	seen := make(map[gorm.ConnPool]int)
+	for i := 0; i < 10; i++ {
+		seen[set.Resolve(pools)]++
+	}
+
+	if seen[pools[0]] != 0 || seen[pools[2]] != 0 {
+		t.Errorf("Leituras não deveriam ir ao primário nem à réplica indisponível: %v", seen)
+	}
+	if seen[pools[1]] != 5 || seen[pools[3]] != 5 {
+		t.Errorf("Esperava distribuição igual entre réplicas saudáveis: %v", seen)
+	}
+}
+
+func TestReplicaSet_FallsBackToPrimary(t *testing.T) {
+	set, pools := newTestReplicaSet(t, PolicyLeastConnections, 2)
+
+	if pool := set.Resolve(pools); pool != gorm.ConnPool(set.primary) {
+		t.Error("Sem réplicas saudáveis a leitura deveria ir ao primário")
+	}
+}
+
+func TestReadDB_ReturnsReusableHandle(t *testing.T) {
+	db := openTestDB(t, GormLoggerConfig{LogLevel: gormLogger.Silent}).Session(&gorm.Session{DryRun: true})
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		db      *gorm.DB
+		primary bool
+	}{
+		{"réplica", ReadDB(ctx, db), false},
+		{"leitura no primário", ReadDB(reqctx.WithPrimaryRead(ctx), db), true},
+		{"primário", PrimaryDB(ctx, db), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Como em FindPage: a contagem e a busca partem do mesmo handle
+			var count int64
+			tt.db.Model(&testRecord{}).Where("name = ?", "Ada").Count(&count)
+
+			var records []testRecord
+			stmt := tt.db.Where("name = ?", "Ada").Find(&records).Statement
+			// Marca do dbresolver.Write, que direciona a consulta ao primário
+			if _, primary := stmt.Settings.Load("gorm:db_resolver:write"); primary != tt.primary {
+				t.Errorf("Consulta no primário = %v, esperava %v", primary, tt.primary)
+			}
+			sql := stmt.SQL.String()
+			if want := "SELECT * FROM `test_records` WHERE name = ?"; sql != want {
+				t.Errorf("Esperava a busca %q sem resíduos da contagem, obteve %q", want, sql)
+			}
+		})
+	}
+}
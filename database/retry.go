@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"go-api-rest/pkg/logger"
+	"math/rand/v2"
+	"time"
+)
+
+// minRetryBackoff é a menor espera entre tentativas; evita que um Initial
+// zero repita a operação sem pausa
+const minRetryBackoff = 100 * time.Millisecond
+
+// RetryPolicy define o backoff exponencial com jitter usado ao conectar
+type RetryPolicy struct {
+	Initial  time.Duration // espera antes da segunda tentativa, no mínimo minRetryBackoff
+	Max      time.Duration // limite superior da espera entre tentativas, nunca menor que Initial
+	Deadline time.Duration // prazo total para as tentativas
+}
+
+// retry executa fn até obter sucesso ou até o prazo da política expirar
+func retry(ctx context.Context, policy RetryPolicy, operation string, fn func() error) error {
+	ctx, cancel := context.WithTimeout(ctx, policy.Deadline)
+	defer cancel()
+
+	backoff := max(policy.Initial, minRetryBackoff)
+	maxBackoff := max(policy.Max, backoff)
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			if attempt > 1 {
+				logger.Info(operation+" concluída", "attempt", attempt)
+			}
+			return nil
+		}
+
+		wait := withJitter(backoff)
+		deadline, _ := ctx.Deadline()
+		if time.Now().Add(wait).After(deadline) {
+			return fmt.Errorf("%s falhou após %d tentativas: %w", operation, attempt, err)
+		}
+
+		logger.Warn(operation+" falhou, nova tentativa agendada",
+			"attempt", attempt,
+			"retry_in", wait,
+			"error", err,
+		)
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return fmt.Errorf("%s falhou após %d tentativas: %w", operation, attempt, err)
+		}
+
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// withJitter sorteia uma espera entre metade e o total do backoff para
+// evitar que várias instâncias tentem conectar ao mesmo tempo
+func withJitter(backoff time.Duration) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+	half := backoff / 2
+	return half + rand.N(backoff-half+1)
+}
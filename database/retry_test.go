@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetry_SucceedsAfterFailures(t *testing.T) {
+	policy := RetryPolicy{Initial: time.Millisecond, Max: 4 * time.Millisecond, Deadline: time.Second}
+
+	attempts := 0
+	err := retry(context.Background(), policy, "Teste", func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("conexão recusada")
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Esperava sucesso, mas obteve erro: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Esperava 3 tentativas, mas obteve %d", attempts)
+	}
+}
+
+func TestRetry_StopsAtDeadline(t *testing.T) {
+	policy := RetryPolicy{Initial: 100 * time.Millisecond, Max: 200 * time.Millisecond, Deadline: 300 * time.Millisecond}
+	failure := errors.New("conexão recusada")
+
+	start := time.Now()
+	err := retry(context.Background(), policy, "Teste", func() error { return failure })
+
+	if !errors.Is(err, failure) {
+		t.Errorf("Esperava o último erro encapsulado, mas obteve: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Tentativas deveriam respeitar o prazo, duraram %v", elapsed)
+	}
+}
+
+func TestRetry_ZeroInitialStillWaits(t *testing.T) {
+	// Sem o mínimo, Initial e Max zero repetiriam a operação sem pausa até o prazo
+	policy := RetryPolicy{Deadline: 300 * time.Millisecond}
+
+	attempts := 0
+	err := retry(context.Background(), policy, "Teste", func() error {
+		attempts++
+		return errors.New("conexão recusada")
+	})
+
+	if err == nil {
+		t.Fatal("Esperava erro após o prazo")
+	}
+	if attempts > 6 {
+		t.Errorf("Esperava no máximo 6 tentativas com espera mínima de %v, obteve %d", minRetryBackoff/2, attempts)
+	}
+}
+
+func TestWithJitter_StaysWithinBounds(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		wait := withJitter(100 * time.Millisecond)
+		if wait < 50*time.Millisecond || wait > 100*time.Millisecond {
+			t.Fatalf("Espera fora do intervalo esperado: %v", wait)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// spanAttribute devolve o valor do atributo registrado no span
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, attr := range span.Attributes() {
+		if attr.Key == key {
+			return attr.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestRegisterTracing_CreatesSpanPerCommand(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormLogger.Discard})
+	if err != nil {
+		t.Fatalf("Erro ao abrir o sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	if err := db.AutoMigrate(&testRecord{}); err != nil {
+		t.Fatalf("Erro ao criar a tabela: %v", err)
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	if err := RegisterTracing(db, provider); err != nil {
+		t.Fatalf("Erro ao registrar o tracing: %v", err)
+	}
+
+	ctx, parent := provider.Tracer("teste").Start(context.Background(), "handler")
+	if err := db.WithContext(ctx).Create(&testRecord{Name: "Ada Lovelace"}).Error; err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if err := db.WithContext(ctx).Exec("SELECT * FROM tabela_inexistente").Error; err == nil {
+		t.Fatal("Esperava erro na consulta")
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Esperava os spans do INSERT, do SELECT e do handler, obteve %d", len(spans))
+	}
+	insert, raw := spans[0], spans[1]
+
+	if insert.Name() != "gorm.create" || raw.Name() != "gorm.raw" {
+		t.Errorf("Esperava gorm.create e gorm.raw, obteve %s e %s", insert.Name(), raw.Name())
+	}
+	for _, span := range []sdktrace.ReadOnlySpan{insert, raw} {
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("Span %s deveria ser filho do span da requisição", span.Name())
+		}
+		if system := spanAttribute(span, "db.system"); system != "sqlite" {
+			t.Errorf("%s: esperava db.system sqlite, obteve %q", span.Name(), system)
+		}
+	}
+
+	// O SQL registrado usa placeholders, sem os valores dos parâmetros
+	statement := spanAttribute(insert, "db.statement")
+	if !strings.HasPrefix(statement, "INSERT INTO `test_records`") || strings.Contains(statement, "Ada Lovelace") {
+		t.Errorf("db.statement inesperado: %q", statement)
+	}
+	if insert.Status().Code != codes.Unset {
+		t.Errorf("Esperava status sem erro no INSERT, obteve %v", insert.Status())
+	}
+
+	if raw.Status().Code != codes.Error || !strings.Contains(raw.Status().Description, "tabela_inexistente") {
+		t.Errorf("Esperava status de erro na consulta, obteve %v", raw.Status())
+	}
+	if statement := spanAttribute(raw, "db.statement"); statement != "SELECT * FROM tabela_inexistente" {
+		t.Errorf("db.statement inesperado: %q", statement)
+	}
+}
@@ -0,0 +1,42 @@
+// Package cache define os armazenamentos usados pelo cache de leitura da API.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"go-api-rest/internal/config"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache armazena valores serializados com prazo de validade
+type Cache interface {
+	// Get retorna o valor e se ele foi encontrado
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set grava o valor por ttl; ttl não positivo apenas remove a chave, em
+	// todos os armazenamentos
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Observer recebe o resultado de cada consulta ao cache (hit, miss, negative_hit ou error)
+type Observer interface {
+	ObserveCache(name, result string)
+}
+
+// New cria o armazenamento configurado em cfg.Store
+func New(cfg config.CacheConfig) (Cache, error) {
+	switch cfg.Store {
+	case "", "memory":
+		return NewLRU(cfg.Size), nil
+	case "redis":
+		opts, err := redis.ParseURL(cfg.RedisURL)
+		if err != nil {
+			return nil, fmt.Errorf("URL do Redis inválida: %w", err)
+		}
+		return NewRedis(redis.NewClient(opts), "cache:"), nil
+	default:
+		return nil, fmt.Errorf("store de cache desconhecido: %s", cfg.Store)
+	}
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2)
+
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	c.Set(ctx, "b", []byte("2"), time.Minute)
+	c.Get(ctx, "a") // "b" passa a ser a menos usada
+	c.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, found, _ := c.Get(ctx, "b"); found {
+		t.Error("Esperava que \"b\" fosse descartada")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, found, _ := c.Get(ctx, key); !found {
+			t.Errorf("Esperava %q no cache", key)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("Esperava 2 entradas, obteve %d", c.Len())
+	}
+}
+
+func TestLRU_ExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	c := NewLRU(10)
+	c.now = func() time.Time { return now }
+
+	c.Set(ctx, "a", []byte("1"), time.Second)
+	if value, found, _ := c.Get(ctx, "a"); !found || string(value) != "1" {
+		t.Fatalf("Esperava \"1\", obteve %q (encontrado: %v)", value, found)
+	}
+
+	now = now.Add(time.Second)
+	if _, found, _ := c.Get(ctx, "a"); found {
+		t.Error("Esperava entrada expirada")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Esperava entrada expirada removida, obteve %d entradas", c.Len())
+	}
+}
+
+func TestRedis_GetSetDelete(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	c := NewRedis(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "cache:")
+
+	if _, found, err := c.Get(ctx, "a"); found || err != nil {
+		t.Fatalf("Esperava miss sem erro, obteve encontrado=%v erro=%v", found, err)
+	}
+
+	if err := c.Set(ctx, "a", []byte("1"), time.Minute); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if !mr.Exists("cache:a") {
+		t.Error("Esperava chave com prefixo no Redis")
+	}
+	if ttl := mr.TTL("cache:a"); ttl != time.Minute {
+		t.Errorf("Esperava TTL de 1m, obteve %v", ttl)
+	}
+
+	if value, found, _ := c.Get(ctx, "a"); !found || string(value) != "1" {
+		t.Errorf("Esperava \"1\", obteve %q (encontrado: %v)", value, found)
+	}
+
+	if err := c.Delete(ctx, "a"); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if _, found, _ := c.Get(ctx, "a"); found {
+		t.Error("Esperava chave removida")
+	}
+}
+
+// TestCache_NonPositiveTTL garante o mesmo significado de ttl zero nos dois
+// armazenamentos: no Redis ele seria uma chave sem expiração
+func TestCache_NonPositiveTTL(t *testing.T) {
+	mr := miniredis.RunT(t)
+	stores := map[string]Cache{
+		"lru":   NewLRU(10),
+		"redis": NewRedis(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "cache:"),
+	}
+
+	for name, c := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if err := c.Set(ctx, "a", []byte("1"), 0); err != nil {
+				t.Fatalf("Erro inesperado: %v", err)
+			}
+			if _, found, _ := c.Get(ctx, "a"); found {
+				t.Error("Esperava que ttl zero não gravasse a chave")
+			}
+
+			c.Set(ctx, "b", []byte("1"), time.Minute)
+			if err := c.Set(ctx, "b", []byte("2"), 0); err != nil {
+				t.Fatalf("Erro inesperado: %v", err)
+			}
+			if value, found, _ := c.Get(ctx, "b"); found {
+				t.Errorf("Esperava que ttl zero removesse a chave, obteve %q", value)
+			}
+		})
+	}
+}
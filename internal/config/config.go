@@ -5,59 +5,342 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Config armazena as configurações da aplicação
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	RateLimit   RateLimitConfig
+	Cache       CacheConfig
+	HTTPCache   HTTPCacheConfig
+	Compression CompressionConfig
+	CORS        CORSConfig
+	Log         LogConfig
+	Tracing     TracingConfig
+	Health      HealthConfig
+	Contract    ContractConfig
+	Versioning  VersioningConfig
+	GraphQL     GraphQLConfig
+	GRPC        GRPCConfig
+	Events      EventsConfig
 }
 
 // ServerConfig contém configurações do servidor
 type ServerConfig struct {
-	Port int
-	Env  string
+	Port           int
+	Env            string
+	TrustedProxies []string // IPs ou CIDRs cujos X-Forwarded-For são confiáveis
+	AdminToken     string   // habilita as rotas /admin quando definido
+	MaxBodyBytes   int64    // limite do corpo das requisições de escrita
 }
 
 // DatabaseConfig contém configurações do banco de dados
 type DatabaseConfig struct {
+	Driver     string // postgres, sqlite ou memory
+	SQLitePath string // arquivo do banco quando Driver é sqlite
+
 	Host     string
 	Port     int
 	User     string
 	Password string
 	DBName   string
 	SSLMode  string
+
+	LogLevel           string        // silent, error, warn ou info
+	SlowQueryThreshold time.Duration // limite para registrar uma query como lenta
+	QuerySampleRate    float64       // fração das queries comuns registradas com DB_LOG_LEVEL=info
+	RedactQueryParams  bool          // omite os parâmetros do SQL nos logs
+
+	MaxOpenConns     int
+	MaxIdleConns     int
+	ConnMaxLifetime  time.Duration
+	ConnMaxIdleTime  time.Duration
+	StatementTimeout time.Duration // statement_timeout da sessão; zero desativa
+	ApplicationName  string        // identifica a aplicação em pg_stat_activity
+
+	ConnectTimeout      time.Duration // prazo total para conectar na inicialização
+	ConnectRetryInitial time.Duration // espera inicial entre tentativas
+	ConnectRetryMax     time.Duration // espera máxima entre tentativas
+
+	ReplicaDSNs           []string      // réplicas de leitura
+	ReplicaPolicy         string        // round_robin ou least_connections
+	ReplicaHealthInterval time.Duration // intervalo entre os pings nas réplicas
+	ReplicaHealthTimeout  time.Duration
+	ReadYourWritesWindow  time.Duration // tempo em que a sessão lê do primário após uma escrita
+}
+
+// RateLimitConfig contém configurações de limitação de requisições
+type RateLimitConfig struct {
+	Enabled      bool
+	ReadRate     float64 // requisições por segundo em rotas de leitura
+	ReadBurst    int
+	WriteRate    float64 // requisições por segundo em rotas de escrita
+	WriteBurst   int
+	Store        string // "memory" ou "redis"
+	RedisURL     string
+	APIKeyHeader string
+	APIKeys      []string // API keys válidas; só elas recebem um bucket próprio
+}
+
+// CacheConfig contém configurações do cache de leitura do repositório
+type CacheConfig struct {
+	Enabled     bool
+	Store       string // "memory" ou "redis"
+	RedisURL    string
+	Size        int           // máximo de entradas no LRU em memória
+	TTL         time.Duration // validade das entradas encontradas; zero desativa
+	NegativeTTL time.Duration // validade das entradas de registros inexistentes; zero desativa
+}
+
+// HTTPCacheConfig contém as políticas de Cache-Control das rotas da API
+type HTTPCacheConfig struct {
+	ResourcePolicy   string // GET de uma personalidade
+	CollectionPolicy string // GET da lista de personalidades
+}
+
+// CompressionConfig contém configurações de compressão HTTP
+type CompressionConfig struct {
+	Enabled             bool
+	Encodings           []string // br, zstd e gzip, em ordem de preferência
+	MinSize             int      // respostas menores seguem sem compressão
+	MaxDecompressedSize int64    // limite do corpo de requisição descomprimido
+}
+
+// CORSConfig contém a política de CORS da API
+type CORSConfig struct {
+	AllowedOrigins   []string // aceita curingas de subdomínio, ex: https://*.example.com
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           int // tempo de cache do preflight em segundos
+}
+
+// LogConfig contém configurações de log
+type LogConfig struct {
+	Level     string // debug, info, warn ou error
+	Format    string // json ou text
+	AddSource bool
+}
+
+// TracingConfig contém configurações do OpenTelemetry
+type TracingConfig struct {
+	Exporter     string // otlp, stdout ou none
+	OTLPEndpoint string // host:porta do coletor OTLP/HTTP
+	OTLPInsecure bool
+	ServiceName  string
+	SampleRatio  float64 // fração de traces amostrados na raiz (0 a 1)
+}
+
+// HealthConfig contém configurações dos health checks
+type HealthConfig struct {
+	CheckTimeout time.Duration // timeout padrão de cada check
+}
+
+// ContractConfig contém a validação das requisições e respostas contra o
+// documento OpenAPI publicado em /openapi.json
+type ContractConfig struct {
+	Mode      string // off, log ou reject
+	Responses bool   // também valida as respostas dos handlers
+}
+
+// VersioningConfig contém a política de versões da API
+type VersioningConfig struct {
+	DefaultVersion int       // versão das rotas sem versão na URL nem no Accept
+	V1DeprecatedAt time.Time // data do cabeçalho Deprecation da v1; zero omite os avisos
+	V1Sunset       time.Time // data de remoção da v1 no cabeçalho Sunset; zero omite
+}
+
+// GraphQLConfig contém os limites do endpoint /graphql
+type GraphQLConfig struct {
+	MaxDepth      int  // aninhamento máximo de campos por operação
+	MaxComplexity int  // custo máximo estimado por operação
+	Playground    bool // serve o GraphiQL em GET /graphql para navegadores
+}
+
+// GRPCConfig contém configurações do servidor gRPC
+type GRPCConfig struct {
+	Port       int
+	Reflection bool // habilita o serviço de reflection, usado por grpcurl e afins
+}
+
+// EventsConfig contém configurações da distribuição de alterações
+type EventsConfig struct {
+	SubscriberBuffer  int           // eventos não lidos por assinante antes de desconectá-lo
+	ReplaySize        int           // eventos recentes guardados para retomada pelo Last-Event-ID
+	HeartbeatInterval time.Duration // intervalo dos comentários que mantêm o stream SSE aberto
+	WriteTimeout      time.Duration // prazo de escrita de cada evento SSE antes de desconectar o cliente
 }
 
 // Load carrega as configurações das variáveis de ambiente
 func Load() *Config {
+	env := getEnv("ENV", "development")
 	return &Config{
 		Server: ServerConfig{
-			Port: getEnvAsInt("SERVER_PORT", 8000),
-			Env:  getEnv("ENV", "development"),
+			Port:           getEnvAsInt("SERVER_PORT", 8000),
+			Env:            env,
+			TrustedProxies: getEnvAsSlice("TRUSTED_PROXIES", nil),
+			AdminToken:     getEnv("ADMIN_TOKEN", ""),
+			MaxBodyBytes:   int64(getEnvAsInt("MAX_BODY_BYTES", 1<<20)),
 		},
 		Database: DatabaseConfig{
+			Driver:     getEnv("DB_DRIVER", "postgres"),
+			SQLitePath: getEnv("DB_SQLITE_PATH", "go-api-rest.db"),
+
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnvAsInt("DB_PORT", 5432),
 			User:     getEnv("DB_USER", "vilar"),
 			Password: getEnv("DB_PASSWORD", "vilar123"),
 			DBName:   getEnv("DB_NAME", "postgres"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			LogLevel:           getEnv("DB_LOG_LEVEL", "warn"),
+			SlowQueryThreshold: getEnvAsDuration("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+			QuerySampleRate:    getEnvAsFloat("DB_QUERY_SAMPLE_RATE", 0.1),
+			RedactQueryParams:  getEnvAsBool("DB_REDACT_QUERY_PARAMS", true),
+
+			MaxOpenConns:     getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:     getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetime:  getEnvAsDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+			ConnMaxIdleTime:  getEnvAsDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+			StatementTimeout: getEnvAsDuration("DB_STATEMENT_TIMEOUT", 30*time.Second),
+			ApplicationName:  getEnv("DB_APPLICATION_NAME", "go-api-rest"),
+
+			ConnectTimeout:      getEnvAsDuration("DB_CONNECT_TIMEOUT", time.Minute),
+			ConnectRetryInitial: getEnvAsDuration("DB_CONNECT_RETRY_INITIAL", 500*time.Millisecond),
+			ConnectRetryMax:     getEnvAsDuration("DB_CONNECT_RETRY_MAX", 10*time.Second),
+
+			ReplicaDSNs:           getEnvAsSlice("DB_REPLICA_DSNS", nil),
+			ReplicaPolicy:         getEnv("DB_REPLICA_POLICY", "round_robin"),
+			ReplicaHealthInterval: getEnvAsDuration("DB_REPLICA_HEALTH_INTERVAL", 5*time.Second),
+			ReplicaHealthTimeout:  getEnvAsDuration("DB_REPLICA_HEALTH_TIMEOUT", time.Second),
+			ReadYourWritesWindow:  getEnvAsDuration("DB_READ_YOUR_WRITES_WINDOW", 5*time.Second),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:      getEnvAsBool("RATE_LIMIT_ENABLED", true),
+			ReadRate:     getEnvAsFloat("RATE_LIMIT_READ_RATE", 20),
+			ReadBurst:    getEnvAsInt("RATE_LIMIT_READ_BURST", 40),
+			WriteRate:    getEnvAsFloat("RATE_LIMIT_WRITE_RATE", 5),
+			WriteBurst:   getEnvAsInt("RATE_LIMIT_WRITE_BURST", 10),
+			Store:        getEnv("RATE_LIMIT_STORE", "memory"),
+			RedisURL:     getEnv("RATE_LIMIT_REDIS_URL", "redis://localhost:6379/0"),
+			APIKeyHeader: getEnv("RATE_LIMIT_API_KEY_HEADER", "X-API-Key"),
+			APIKeys:      getEnvAsSlice("RATE_LIMIT_API_KEYS", nil),
+		},
+		Cache: CacheConfig{
+			Enabled:     getEnvAsBool("CACHE_ENABLED", false),
+			Store:       getEnv("CACHE_STORE", "memory"),
+			RedisURL:    getEnv("CACHE_REDIS_URL", "redis://localhost:6379/0"),
+			Size:        getEnvAsInt("CACHE_SIZE", 1000),
+			TTL:         getEnvAsDuration("CACHE_TTL", time.Minute),
+			NegativeTTL: getEnvAsDuration("CACHE_NEGATIVE_TTL", 10*time.Second),
+		},
+		HTTPCache: HTTPCacheConfig{
+			ResourcePolicy:   getEnv("CACHE_CONTROL_RESOURCE", "private, no-cache"),
+			CollectionPolicy: getEnv("CACHE_CONTROL_COLLECTION", "private, no-cache"),
+		},
+		Compression: CompressionConfig{
+			Enabled:             getEnvAsBool("COMPRESSION_ENABLED", true),
+			Encodings:           getEnvAsSlice("COMPRESSION_ENCODINGS", []string{"br", "zstd", "gzip"}),
+			MinSize:             getEnvAsInt("COMPRESSION_MIN_SIZE", 1024),
+			MaxDecompressedSize: int64(getEnvAsInt("COMPRESSION_MAX_DECOMPRESSED_SIZE", 10<<20)),
 		},
+		CORS: CORSConfig{
+			AllowedOrigins:   getEnvAsSlice("CORS_ALLOWED_ORIGINS", nil),
+			AllowedMethods:   getEnvAsSlice("CORS_ALLOWED_METHODS", []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}),
+			AllowedHeaders:   getEnvAsSlice("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Authorization", "X-API-Key", "X-Request-ID", "If-None-Match", "If-Modified-Since"}),
+			ExposedHeaders:   getEnvAsSlice("CORS_EXPOSED_HEADERS", []string{"ETag", "Last-Modified", "Link", "Location", "X-Request-ID", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "API-Version", "Deprecation", "Sunset"}),
+			AllowCredentials: getEnvAsBool("CORS_ALLOW_CREDENTIALS", false),
+			MaxAge:           getEnvAsInt("CORS_MAX_AGE", 600),
+		},
+		Log: LogConfig{
+			Level:     getEnv("LOG_LEVEL", "info"),
+			Format:    getEnv("LOG_FORMAT", "text"),
+			AddSource: getEnvAsBool("LOG_ADD_SOURCE", false),
+		},
+		Tracing: TracingConfig{
+			Exporter:     getEnv("TRACING_EXPORTER", "none"),
+			OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4318"),
+			OTLPInsecure: getEnvAsBool("OTEL_EXPORTER_OTLP_INSECURE", true),
+			ServiceName:  getEnv("OTEL_SERVICE_NAME", "go-api-rest"),
+			SampleRatio:  getEnvAsFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+		Health: HealthConfig{
+			CheckTimeout: getEnvAsDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		},
+		Contract: ContractConfig{
+			Mode:      getEnv("CONTRACT_VALIDATION", defaultContractMode(env)),
+			Responses: getEnvAsBool("CONTRACT_VALIDATE_RESPONSES", true),
+		},
+		Versioning: VersioningConfig{
+			DefaultVersion: getEnvAsInt("API_DEFAULT_VERSION", 1),
+			V1DeprecatedAt: getEnvAsDate("API_V1_DEPRECATED_AT", time.Time{}),
+			V1Sunset:       getEnvAsDate("API_V1_SUNSET", time.Time{}),
+		},
+		GraphQL: GraphQLConfig{
+			MaxDepth:      getEnvAsInt("GRAPHQL_MAX_DEPTH", 6),
+			MaxComplexity: getEnvAsInt("GRAPHQL_MAX_COMPLEXITY", 1000),
+			Playground:    getEnvAsBool("GRAPHQL_PLAYGROUND", env == "development"),
+		},
+		GRPC: GRPCConfig{
+			Port:       getEnvAsInt("GRPC_PORT", 9090),
+			Reflection: getEnvAsBool("GRPC_REFLECTION", env == "development"),
+		},
+		Events: EventsConfig{
+			SubscriberBuffer:  getEnvAsInt("EVENTS_SUBSCRIBER_BUFFER", 64),
+			ReplaySize:        getEnvAsInt("EVENTS_REPLAY_SIZE", 1024),
+			HeartbeatInterval: getEnvAsDuration("EVENTS_HEARTBEAT_INTERVAL", 15*time.Second),
+			WriteTimeout:      getEnvAsDuration("EVENTS_WRITE_TIMEOUT", 10*time.Second),
+		},
+	}
+}
+
+// defaultContractMode registra as divergências do contrato em development e
+// test; em produção a validação fica desligada por padrão
+func defaultContractMode(env string) string {
+	switch env {
+	case "development", "test":
+		return "log"
 	}
+	return "off"
 }
 
 // GetDSN retorna a string de conexão do banco de dados
 func (c *Config) GetDSN() string {
-	return fmt.Sprintf(
+	return c.Database.DSN()
+}
+
+// DSN retorna a string de conexão do banco de dados
+func (d DatabaseConfig) DSN() string {
+	dsn := fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
-		c.Database.Host,
-		c.Database.User,
-		c.Database.Password,
-		c.Database.DBName,
-		c.Database.Port,
-		c.Database.SSLMode,
+		d.Host,
+		d.User,
+		quoteDSNValue(d.Password),
+		d.DBName,
+		d.Port,
+		d.SSLMode,
 	)
+	if d.ApplicationName != "" {
+		dsn += " application_name=" + quoteDSNValue(d.ApplicationName)
+	}
+	if d.StatementTimeout > 0 {
+		dsn += fmt.Sprintf(" statement_timeout=%d", d.StatementTimeout.Milliseconds())
+	}
+	return dsn
+}
+
+// quoteDSNValue aplica aspas simples quando o valor contém espaços ou caracteres especiais
+func quoteDSNValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " '\\") {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
 }
 
 // getEnv obtém uma variável de ambiente ou retorna um valor padrão
@@ -81,3 +364,78 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return value
 }
+
+// getEnvAsFloat obtém uma variável de ambiente como float64 ou retorna um valor padrão
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		log.Printf("Erro ao converter %s para float, usando valor padrão: %g", key, defaultValue)
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvAsDuration obtém uma variável de ambiente como time.Duration ou retorna um valor padrão
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := time.ParseDuration(valueStr)
+	if err != nil {
+		log.Printf("Erro ao converter %s para duração, usando valor padrão: %s", key, defaultValue)
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvAsDate obtém uma variável de ambiente no formato AAAA-MM-DD ou retorna
+// um valor padrão; "none" retorna a data zero
+func getEnvAsDate(key string, defaultValue time.Time) time.Time {
+	valueStr := os.Getenv(key)
+	switch valueStr {
+	case "":
+		return defaultValue
+	case "none":
+		return time.Time{}
+	}
+	value, err := time.Parse(time.DateOnly, valueStr)
+	if err != nil {
+		log.Printf("Erro ao converter %s para data, usando valor padrão: %s", key, defaultValue.Format(time.DateOnly))
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvAsBool obtém uma variável de ambiente como bool ou retorna um valor padrão
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		log.Printf("Erro ao converter %s para bool, usando valor padrão: %t", key, defaultValue)
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvAsSlice obtém uma variável de ambiente separada por vírgulas ou retorna um valor padrão
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	var values []string
+	for _, item := range strings.Split(valueStr, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
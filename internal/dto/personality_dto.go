@@ -1,29 +1,43 @@
 package dto
 
+import (
+	customValidator "go-api-rest/pkg/validator"
+	"time"
+)
+
+func init() {
+	customValidator.RegisterStructRule(updateHasChanges, UpdatePersonalityRequest{})
+}
+
 // CreatePersonalityRequest representa os dados para criar uma personalidade
 type CreatePersonalityRequest struct {
-	Name    string `json:"name" validate:"required,min=3,max=100"`
-	History string `json:"history" validate:"required,min=10,max=5000"`
+	Name    string `json:"name" xml:"name" yaml:"name" validate:"required,trimmed,personname,min=3,max=100"`
+	History string `json:"history" xml:"history" yaml:"history" validate:"required,nocontrol,min=10,max=5000"`
 }
 
 // UpdatePersonalityRequest representa os dados para atualizar uma personalidade
 type UpdatePersonalityRequest struct {
-	Name    string `json:"name" validate:"omitempty,min=3,max=100"`
-	History string `json:"history" validate:"omitempty,min=10,max=5000"`
+	Name    string `json:"name" xml:"name" yaml:"name" validate:"omitempty,trimmed,personname,min=3,max=100"`
+	History string `json:"history" xml:"history" yaml:"history" validate:"omitempty,nocontrol,min=10,max=5000"`
 }
 
-// PersonalityResponse representa a resposta da API
-type PersonalityResponse struct {
-	ID      uint   `json:"id"`
-	Name    string `json:"name"`
-	History string `json:"history"`
+// updateHasChanges exige ao menos um campo na atualização, já que campos
+// vazios mantêm o valor atual
+func updateHasChanges(sl customValidator.StructLevel) {
+	req := sl.Current().Interface().(UpdatePersonalityRequest)
+	if req.Name == "" && req.History == "" {
+		sl.ReportError(req.Name, "name", "Name", "atleastone", "history")
+	}
 }
 
-// ErrorResponse representa uma resposta de erro
-type ErrorResponse struct {
-	Error   string            `json:"error"`
-	Message string            `json:"message,omitempty"`
-	Details map[string]string `json:"details,omitempty"`
+// PersonalityResponse representa a resposta da API
+type PersonalityResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	History   string    `json:"history"`
+	Version   uint      `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // SuccessResponse representa uma resposta de sucesso genérica
@@ -31,3 +45,65 @@ type SuccessResponse struct {
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
 }
+
+// PageRequest seleciona uma página das listagens, a partir dos parâmetros
+// page e per_page. O limite de page impede que o offset transborde.
+type PageRequest struct {
+	Page    int `json:"page" validate:"gte=1,lte=1000000"`
+	PerPage int `json:"per_page" validate:"gte=1,lte=100"`
+}
+
+// Offset retorna quantos registros antecedem a página
+func (p PageRequest) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+// PersonalityFilter restringe as listagens; campos vazios não filtram
+type PersonalityFilter struct {
+	Name         string    `json:"name" validate:"max=100"` // trecho do nome
+	Text         string    `json:"text" validate:"max=100"` // trecho do nome ou da história
+	UpdatedSince time.Time `json:"updated_since"`           // alteradas a partir desta data
+}
+
+// PersonalityPage é uma página da listagem de personalidades com o total de registros
+type PersonalityPage struct {
+	Items []PersonalityResponse
+	Total int64
+}
+
+// PersonalityEnvelope é a resposta da v2 para uma personalidade
+type PersonalityEnvelope struct {
+	Data PersonalityResponse `json:"data"`
+}
+
+// PersonalityListEnvelope é a resposta da v2 para a listagem paginada
+type PersonalityListEnvelope struct {
+	Data  []PersonalityResponse `json:"data"`
+	Meta  PageMeta              `json:"meta"`
+	Links PageLinks             `json:"links"`
+}
+
+// PageMeta descreve a página retornada
+type PageMeta struct {
+	Page       int   `json:"page"`
+	PerPage    int   `json:"per_page"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// PageLinks aponta para as páginas vizinhas; prev e next são omitidos nas pontas
+type PageLinks struct {
+	Self  string `json:"self"`
+	First string `json:"first"`
+	Last  string `json:"last"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+}
+
+// PersonalityEvent é o dado de cada evento do feed de alterações
+type PersonalityEvent struct {
+	Type          string               `json:"type" validate:"oneof=created updated deleted"`
+	PersonalityID uint                 `json:"personality_id"`
+	Personality   *PersonalityResponse `json:"personality,omitempty"`
+	Time          time.Time            `json:"time"`
+}
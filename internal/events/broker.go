@@ -0,0 +1,177 @@
+// Package events distribui as alterações no cadastro de personalidades para
+// os assinantes do processo, como os streams Watch do gRPC e o feed SSE.
+package events
+
+import (
+	"errors"
+	"go-api-rest/internal/dto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrSlowConsumer encerra a assinatura cujo buffer encheu; o assinante perdeu
+// eventos e precisa reconsultar o estado antes de assinar de novo
+var ErrSlowConsumer = errors.New("assinante não acompanhou os eventos")
+
+// ErrReplayUnavailable indica que eventos posteriores ao ID pedido já saíram
+// do histórico, ou que o ID é de outra execução do processo
+var ErrReplayUnavailable = errors.New("eventos anteriores não estão mais disponíveis")
+
+// Type é o tipo da alteração
+type Type string
+
+const (
+	Created Type = "created"
+	Updated Type = "updated"
+	Deleted Type = "deleted"
+)
+
+// Event é uma alteração em uma personalidade
+type Event struct {
+	ID            uint64 // sequência crescente atribuída pelo Broker, reiniciada a cada execução
+	Type          Type
+	PersonalityID uint
+	Personality   *dto.PersonalityResponse // estado após a alteração; nil em Deleted
+	Time          time.Time
+}
+
+// Broker entrega cada evento publicado a todas as assinaturas abertas, na
+// ordem de publicação. A entrega não bloqueia quem publica: uma assinatura com
+// o buffer cheio é encerrada com ErrSlowConsumer. Os eventos mais recentes
+// ficam guardados para quem retoma a assinatura com SubscribeSince.
+type Broker struct {
+	mu          sync.Mutex
+	epoch       string // distingue os IDs desta execução dos de execuções anteriores
+	lastID      uint64
+	buffer      int
+	replay      int
+	history     []Event // últimos eventos, do mais antigo ao mais recente
+	subscribers map[*Subscription]struct{}
+}
+
+// NewBroker cria um broker cujas assinaturas guardam até buffer eventos não
+// lidos e que mantém os últimos replay eventos publicados
+func NewBroker(buffer, replay int) *Broker {
+	return &Broker{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		buffer:      max(buffer, 1),
+		replay:      max(replay, 0),
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// EventID identifica fora do processo o evento de sequência seq, no formato
+// "<época>-<seq>". A sequência recomeça a cada execução; a época, não, e
+// impede que um ID anterior ao reinício seja tomado por um evento atual.
+func (b *Broker) EventID(seq uint64) string {
+	return b.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// Publish numera o evento, preenche a data quando vazia, o guarda no histórico
+// e o entrega às assinaturas
+func (b *Broker) Publish(e Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	e.ID = b.lastID
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if b.replay > 0 {
+		if len(b.history) == b.replay {
+			b.history = b.history[1:]
+		}
+		b.history = append(b.history, e)
+	}
+	for s := range b.subscribers {
+		select {
+		case s.events <- e:
+		default:
+			b.remove(s, ErrSlowConsumer)
+		}
+	}
+	return e
+}
+
+// Subscribe abre uma assinatura com os eventos publicados a partir de agora
+func (b *Broker) Subscribe() *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.subscribe()
+}
+
+// SubscribeSince abre uma assinatura como Subscribe e devolve os eventos do
+// histórico posteriores a lastEventID, um ID de EventID, para quem retoma de
+// onde parou. Quando algum deles já saiu do histórico, ou o ID é inválido ou
+// de outra execução, a assinatura é aberta mesmo assim, sem eventos anteriores
+// e com ErrReplayUnavailable: o assinante deve reconsultar o estado.
+func (b *Broker) SubscribeSince(lastEventID string) (*Subscription, []Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := b.subscribe()
+
+	epoch, seq, _ := strings.Cut(lastEventID, "-")
+	lastID, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil || epoch != b.epoch {
+		return s, nil, ErrReplayUnavailable
+	}
+	oldest := b.lastID + 1 - uint64(len(b.history))
+	if lastID > b.lastID || lastID+1 < oldest {
+		return s, nil, ErrReplayUnavailable
+	}
+	return s, append([]Event(nil), b.history[lastID+1-oldest:]...), nil
+}
+
+// subscribe registra uma nova assinatura; exige o lock
+func (b *Broker) subscribe() *Subscription {
+	s := &Subscription{broker: b, events: make(chan Event, b.buffer), start: b.lastID}
+	b.subscribers[s] = struct{}{}
+	return s
+}
+
+// remove encerra a assinatura; exige o lock
+func (b *Broker) remove(s *Subscription, err error) {
+	if _, ok := b.subscribers[s]; !ok {
+		return
+	}
+	delete(b.subscribers, s)
+	s.err = err
+	close(s.events)
+}
+
+// Subscription recebe os eventos de um Broker
+type Subscription struct {
+	broker *Broker
+	events chan Event
+	start  uint64
+	err    error
+}
+
+// Start é o ID do último evento publicado antes da assinatura; os eventos
+// recebidos em Events têm IDs maiores
+func (s *Subscription) Start() uint64 {
+	return s.start
+}
+
+// Events entrega os eventos em ordem; o canal é fechado quando a assinatura
+// termina, por Close ou por ErrSlowConsumer
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Err informa por que a assinatura terminou; nil enquanto aberta ou após Close
+func (s *Subscription) Err() error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.err
+}
+
+// Close encerra a assinatura; pode ser chamado mais de uma vez
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s, nil)
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBroker_DeliversInOrder(t *testing.T) {
+	b := NewBroker(10, 0)
+	first, second := b.Subscribe(), b.Subscribe()
+	defer first.Close()
+
+	b.Publish(Event{Type: Created, PersonalityID: 1})
+	b.Publish(Event{Type: Updated, PersonalityID: 1})
+	second.Close()
+	b.Publish(Event{Type: Deleted, PersonalityID: 1})
+
+	for i, want := range []Type{Created, Updated, Deleted} {
+		e := <-first.Events()
+		if e.Type != want || e.ID != uint64(i+1) || e.Time.IsZero() {
+			t.Errorf("Evento %d inesperado: %+v", i, e)
+		}
+	}
+
+	var received []Type
+	for e := range second.Events() {
+		received = append(received, e.Type)
+	}
+	if len(received) != 2 || second.Err() != nil {
+		t.Errorf("Esperava 2 eventos antes do Close, obteve %v (%v)", received, second.Err())
+	}
+}
+
+func TestBroker_DropsSlowConsumer(t *testing.T) {
+	b := NewBroker(2, 0)
+	slow, fast := b.Subscribe(), b.Subscribe()
+	defer fast.Close()
+
+	for i := 0; i < 3; i++ {
+		b.Publish(Event{Type: Updated, PersonalityID: 1})
+		<-fast.Events()
+	}
+
+	count := 0
+	for range slow.Events() {
+		count++
+	}
+	if count != 2 || !errors.Is(slow.Err(), ErrSlowConsumer) {
+		t.Errorf("Esperava 2 eventos e ErrSlowConsumer, obteve %d e %v", count, slow.Err())
+	}
+	if fast.Err() != nil {
+		t.Errorf("A assinatura em dia não deveria ser encerrada: %v", fast.Err())
+	}
+	slow.Close()
+}
+
+func TestBroker_SubscribeSinceReplaysHistory(t *testing.T) {
+	b := NewBroker(10, 3)
+	for i := 0; i < 5; i++ {
+		b.Publish(Event{Type: Updated, PersonalityID: 1})
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		replay      []uint64
+		wantErr     error
+	}{
+		{"em dia", b.EventID(5), nil, nil},
+		{"dentro do histórico", b.EventID(2), []uint64{3, 4, 5}, nil},
+		{"fora do histórico", b.EventID(1), nil, ErrReplayUnavailable},
+		{"posterior ao último", b.EventID(9), nil, ErrReplayUnavailable},
+		// Após um reinício a sequência recomeça; a época difere mesmo que a
+		// sequência ainda esteja no histórico
+		{"de outra execução", NewBroker(10, 3).EventID(4), nil, ErrReplayUnavailable},
+		{"sem época", "4", nil, ErrReplayUnavailable},
+		{"inválido", b.EventID(4) + "x", nil, ErrReplayUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, replay, err := b.SubscribeSince(tt.lastEventID)
+			defer s.Close()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Esperava erro %v, obteve %v", tt.wantErr, err)
+			}
+			var ids []uint64
+			for _, e := range replay {
+				ids = append(ids, e.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.replay) {
+				t.Errorf("Esperava replay %v, obteve %v", tt.replay, ids)
+			}
+			if s.Start() != 5 {
+				t.Errorf("Esperava início em 5, obteve %d", s.Start())
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"go-api-rest/internal/config"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/repository"
+	"go-api-rest/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// countingService conta as chamadas a GetByIDs para verificar o agrupamento
+type countingService struct {
+	service.PersonalityService
+	batches [][]uint
+}
+
+func (s *countingService) GetByIDs(ctx context.Context, ids []uint) ([]dto.PersonalityResponse, error) {
+	s.batches = append(s.batches, ids)
+	return s.PersonalityService.GetByIDs(ctx, ids)
+}
+
+// newTestHandler cria o endpoint sobre um repositório em memória com três
+// personalidades, de IDs 1 a 3
+func newTestHandler(t *testing.T, cfg config.GraphQLConfig) (*Handler, *countingService) {
+	t.Helper()
+	svc := &countingService{PersonalityService: service.NewPersonalityService(repository.NewMemoryPersonalityRepository())}
+	for _, p := range []dto.CreatePersonalityRequest{
+		{Name: "Ada Lovelace", History: "Matemática inglesa, primeira programadora"},
+		{Name: "Alan Turing", History: "Matemático inglês, pai da computação"},
+		{Name: "Grace Hopper", History: "Cientista da computação americana"},
+	} {
+		if _, err := svc.Create(context.Background(), &p); err != nil {
+			t.Fatalf("Erro ao criar personalidade: %v", err)
+		}
+	}
+	h, err := NewHandler(svc, cfg)
+	if err != nil {
+		t.Fatalf("Erro ao criar o handler: %v", err)
+	}
+	return h, svc
+}
+
+// post envia a consulta pelo POST e decodifica a resposta
+func post(t *testing.T, h http.Handler, query string, variables map[string]interface{}) (int, map[string]interface{}) {
+	t.Helper()
+	body, _ := json.Marshal(Request{Query: query, Variables: variables})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("Resposta não é JSON: %v: %s", err, rec.Body.String())
+	}
+	return rec.Code, out
+}
+
+// errorCodes retorna extensions.code de cada erro da resposta
+func errorCodes(out map[string]interface{}) []string {
+	errs, _ := out["errors"].([]interface{})
+	codes := make([]string, len(errs))
+	for i, e := range errs {
+		extensions, _ := e.(map[string]interface{})["extensions"].(map[string]interface{})
+		codes[i], _ = extensions["code"].(string)
+	}
+	return codes
+}
+
+func TestHandler_BatchesLookupsByID(t *testing.T) {
+	h, svc := newTestHandler(t, config.GraphQLConfig{})
+
+	_, out := post(t, h, `{
+		a: personality(id: "1") { name }
+		b: personality(id: "3") { name }
+		c: personality(id: "99") { name }
+		d: personality(id: "1") { history }
+	}`, nil)
+
+	if _, ok := out["errors"]; ok {
+		t.Fatalf("Não esperava erros: %v", out["errors"])
+	}
+	if len(svc.batches) != 1 || len(svc.batches[0]) != 3 {
+		t.Fatalf("Esperava uma busca com 3 IDs, obteve %v", svc.batches)
+	}
+	data := out["data"].(map[string]interface{})
+	if name := data["b"].(map[string]interface{})["name"]; name != "Grace Hopper" {
+		t.Errorf("Esperava Grace Hopper, obteve %v", name)
+	}
+	if data["c"] != nil {
+		t.Errorf("Esperava null para ID inexistente, obteve %v", data["c"])
+	}
+}
+
+func TestHandler_ListPrimesLoader(t *testing.T) {
+	h, svc := newTestHandler(t, config.GraphQLConfig{})
+
+	_, out := post(t, h, `query($perPage: Int) {
+		personalities(perPage: $perPage) {
+			items { id name }
+			pageInfo { page total totalPages hasNextPage hasPreviousPage }
+		}
+		search(text: "INGL") { items { name } pageInfo { total } }
+	}`, map[string]interface{}{"perPage": 2})
+
+	if _, ok := out["errors"]; ok {
+		t.Fatalf("Não esperava erros: %v", out["errors"])
+	}
+	data := out["data"].(map[string]interface{})
+	list := data["personalities"].(map[string]interface{})
+	if items := list["items"].([]interface{}); len(items) != 2 || items[0].(map[string]interface{})["id"] != "1" {
+		t.Errorf("Esperava os IDs 1 e 2, obteve %v", items)
+	}
+	pageInfo := list["pageInfo"].(map[string]interface{})
+	if pageInfo["total"] != 3.0 || pageInfo["totalPages"] != 2.0 || pageInfo["hasNextPage"] != true || pageInfo["hasPreviousPage"] != false {
+		t.Errorf("pageInfo inesperado: %v", pageInfo)
+	}
+	if total := data["search"].(map[string]interface{})["pageInfo"].(map[string]interface{})["total"]; total != 2.0 {
+		t.Errorf("Esperava 2 resultados na busca, obteve %v", total)
+	}
+	if len(svc.batches) != 0 {
+		t.Errorf("Listagens não deveriam buscar por ID, obteve %v", svc.batches)
+	}
+}
+
+func TestHandler_Mutations(t *testing.T) {
+	h, _ := newTestHandler(t, config.GraphQLConfig{})
+
+	_, out := post(t, h, `mutation($input: CreatePersonalityInput!) {
+		createPersonality(input: $input) { id name version }
+	}`, map[string]interface{}{"input": map[string]interface{}{"name": "Marie Curie", "history": "Física e química polonesa"}})
+	created := out["data"].(map[string]interface{})["createPersonality"].(map[string]interface{})
+	if created["id"] != "4" || created["version"] != 1.0 {
+		t.Errorf("Personalidade criada inesperada: %v", created)
+	}
+
+	_, out = post(t, h, `mutation { updatePersonality(id: "4", input: {history: "Duas vezes premiada com o Nobel"}) { history version } }`, nil)
+	updated := out["data"].(map[string]interface{})["updatePersonality"].(map[string]interface{})
+	if updated["version"] != 2.0 {
+		t.Errorf("Esperava a versão 2, obteve %v", updated)
+	}
+
+	_, out = post(t, h, `mutation { deletePersonality(id: "4") }`, nil)
+	if deleted := out["data"].(map[string]interface{})["deletePersonality"]; deleted != true {
+		t.Errorf("Esperava true, obteve %v", deleted)
+	}
+}
+
+func TestHandler_ServiceErrors(t *testing.T) {
+	h, _ := newTestHandler(t, config.GraphQLConfig{})
+
+	tests := []struct {
+		name  string
+		query string
+		code  string
+	}{
+		{"validação", `mutation { createPersonality(input: {name: "A", history: "curta"}) { id } }`, "VALIDATION_FAILED"},
+		{"inexistente", `mutation { deletePersonality(id: "99") }`, "PERSONALITY_NOT_FOUND"},
+		{"nome duplicado", `mutation { createPersonality(input: {name: "Ada Lovelace", history: "Matemática inglesa"}) { id } }`, "NAME_CONFLICT"},
+		{"ID inválido", `{ personality(id: "abc") { name } }`, "INVALID_ID"},
+		{"página inválida", `{ personalities(perPage: 500) { items { id } } }`, "VALIDATION_FAILED"},
+		{"sintaxe", `{ personality(id: "1") { name }`, codeParseFailed},
+		{"campo inexistente", `{ personality(id: "1") { age } }`, codeValidationFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, out := post(t, h, tt.query, nil)
+			if status != http.StatusOK {
+				t.Errorf("Esperava status 200, obteve %d", status)
+			}
+			if codes := errorCodes(out); len(codes) != 1 || codes[0] != tt.code {
+				t.Errorf("Esperava o código %s, obteve %v", tt.code, out["errors"])
+			}
+		})
+	}
+
+	// Erros de validação trazem as mensagens por campo
+	_, out := post(t, h, `mutation { createPersonality(input: {name: "A", history: "Matemática inglesa"}) { id } }`, nil)
+	extensions := out["errors"].([]interface{})[0].(map[string]interface{})["extensions"].(map[string]interface{})
+	fields, _ := extensions["errors"].([]interface{})
+	if len(fields) != 1 || fields[0].(map[string]interface{})["field"] != "name" {
+		t.Errorf("Esperava erro no campo name, obteve %v", extensions)
+	}
+}
+
+func TestHandler_Limits(t *testing.T) {
+	depth := config.GraphQLConfig{MaxDepth: 2}
+	complexity := config.GraphQLConfig{MaxComplexity: 50}
+
+	tests := []struct {
+		name  string
+		cfg   config.GraphQLConfig
+		query string
+		code  string
+	}{
+		{"dentro da profundidade", depth, `{ personality(id: "1") { name } }`, ""},
+		{"acima da profundidade", depth, `{ personalities(perPage: 1) { items { id } } }`, codeTooDeep},
+		{"profundidade em fragmento", depth, `{ ...F } fragment F on Query { search(text: "a") { items { id } } }`, codeTooDeep},
+		{"introspecção não conta", depth, `{ __schema { types { fields { name } } } }`, ""},
+		{"dentro da complexidade", complexity, `{ personalities(perPage: 10) { items { id name history } } }`, ""},
+		{"acima da complexidade", complexity, `{ personalities(perPage: 20) { items { id name history } } }`, codeTooComplex},
+		{"perPage padrão", complexity, `{ personalities { items { id name history } } }`, codeTooComplex},
+		{"perPage por variável", complexity, `query($n: Int) { search(text: "a", perPage: $n) { items { id name history } } }`, codeTooComplex},
+		{"sem limites", config.GraphQLConfig{}, `{ personalities(perPage: 100) { items { id name history } } }`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newTestHandler(t, tt.cfg)
+			_, out := post(t, h, tt.query, map[string]interface{}{"n": 30})
+			codes := errorCodes(out)
+			if tt.code == "" {
+				if len(codes) != 0 {
+					t.Errorf("Não esperava erros, obteve %v", out["errors"])
+				}
+				return
+			}
+			if len(codes) != 1 || codes[0] != tt.code {
+				t.Errorf("Esperava o código %s, obteve %v", tt.code, out["errors"])
+			}
+			if _, ok := out["data"]; ok {
+				t.Errorf("Consultas rejeitadas não deveriam ser executadas: %v", out["data"])
+			}
+		})
+	}
+}
+
+func TestHandler_Get(t *testing.T) {
+	h, _ := newTestHandler(t, config.GraphQLConfig{Playground: true})
+
+	get := func(target, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/graphql?query="+url.QueryEscape(`query($id: ID!) { personality(id: $id) { name } }`)+
+		"&variables="+url.QueryEscape(`{"id":"2"}`), "application/json")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Alan Turing") {
+		t.Errorf("Esperava Alan Turing, obteve %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = get("/graphql?query="+url.QueryEscape(`mutation { deletePersonality(id: "1") }`), "application/json")
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("Esperava 405 com Allow: POST, obteve %d %v", rec.Code, rec.Header())
+	}
+	if _, out := post(t, h, `{ personality(id: "1") { name } }`, nil); out["data"].(map[string]interface{})["personality"] == nil {
+		t.Error("A mutation pelo GET não deveria ter sido executada")
+	}
+
+	rec = get("/graphql?query={&variables=[", "application/json")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Esperava 400 para variáveis inválidas, obteve %d", rec.Code)
+	}
+
+	rec = get("/graphql", "text/html,application/xhtml+xml")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "graphiql") {
+		t.Errorf("Esperava o GraphiQL, obteve %d: %s", rec.Code, rec.Body.String())
+	}
+
+	h.cfg.Playground = false
+	if rec = get("/graphql", "text/html"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Sem playground esperava 400, obteve %d", rec.Code)
+	}
+}
@@ -0,0 +1,258 @@
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"go-api-rest/internal/config"
+	"go-api-rest/internal/handler"
+	"go-api-rest/internal/service"
+	"go-api-rest/pkg/i18n"
+	"go-api-rest/pkg/logger"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/reqctx"
+	"go-api-rest/pkg/request"
+	"go-api-rest/pkg/response"
+	"net/http"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Códigos das extensões de erros que não vêm do serviço
+const (
+	codeParseFailed      = "GRAPHQL_PARSE_FAILED"
+	codeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	codeTooDeep          = "QUERY_TOO_DEEP"
+	codeTooComplex       = "QUERY_TOO_COMPLEX"
+)
+
+// Request é uma requisição GraphQL, no corpo do POST ou na query string do GET
+type Request struct {
+	Query         string                 `json:"query" validate:"required"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	Extensions    map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Response é o resultado de uma operação; data fica ausente quando a
+// operação não chegou a ser executada
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []Error     `json:"errors,omitempty"`
+}
+
+// Error é um erro GraphQL; extensions.code identifica o tipo do erro e, em
+// erros de validação, extensions.errors traz as mensagens por campo
+type Error struct {
+	Message    string                 `json:"message"`
+	Locations  []Location             `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Location aponta a linha e a coluna do erro na consulta
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Handler atende o endpoint /graphql
+type Handler struct {
+	schema graphql.Schema
+	svc    service.PersonalityService
+	cfg    config.GraphQLConfig
+}
+
+// NewHandler monta o schema sobre o serviço. Com cfg.Playground, GETs de
+// navegadores sem consulta recebem o GraphiQL, que envia as consultas para a
+// própria rota.
+func NewHandler(svc service.PersonalityService, cfg config.GraphQLConfig) (*Handler, error) {
+	schema, err := NewSchema(svc)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{schema: schema, svc: svc, cfg: cfg}, nil
+}
+
+// Playground informa se o GraphiQL está habilitado
+func (h *Handler) Playground() bool {
+	return h.cfg.Playground
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		query := r.URL.Query()
+		if h.cfg.Playground && !query.Has("query") && strings.Contains(r.Header.Get("Accept"), "text/html") {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			if r.Method != http.MethodHead {
+				w.Write(playgroundPage)
+			}
+			return
+		}
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if raw := query.Get("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				problem.Write(w, r, problem.Validation(map[string]string{
+					"variables": i18n.T(r.Context(), "graphql.invalid_variables"),
+				}))
+				return
+			}
+		}
+	default:
+		if err := request.Decode(r, &req); err != nil {
+			problem.Write(w, r, handler.MapError(err))
+			return
+		}
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		problem.Write(w, r, problem.Validation(map[string]string{
+			"query": i18n.T(r.Context(), "graphql.missing_query"),
+		}))
+		return
+	}
+
+	result, mutation := h.execute(r.Context(), req, r.Method == http.MethodPost)
+	if mutation {
+		// Mutations pelo GET ficariam expostas a links e prefetch
+		w.Header().Set("Allow", http.MethodPost)
+		problem.Write(w, r, problem.MethodNotAllowed.Localized("graphql.mutation_requires_post"))
+		return
+	}
+	response.JSON(w, http.StatusOK, result)
+}
+
+// execute valida a consulta, aplica os limites e executa a operação. Sem
+// allowMutation, uma mutation válida não é executada e mutation volta true.
+func (h *Handler) execute(ctx context.Context, req Request, allowMutation bool) (result *Response, mutation bool) {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &Response{Errors: requestErrors(codeParseFailed, gqlerrors.FormatErrors(err))}, false
+	}
+	if validation := graphql.ValidateDocument(&h.schema, doc, nil); !validation.IsValid {
+		return &Response{Errors: requestErrors(codeValidationFailed, validation.Errors)}, false
+	}
+
+	op, fragments := operation(doc, req.OperationName)
+	if op == nil {
+		// O executor descreve a operação ausente ou ambígua
+		executed := graphql.Execute(graphql.ExecuteParams{Schema: h.schema, AST: doc, OperationName: req.OperationName, Context: ctx})
+		return &Response{Errors: requestErrors(codeValidationFailed, executed.Errors)}, false
+	}
+	if op.Operation != ast.OperationTypeQuery && !allowMutation {
+		return nil, true
+	}
+	if errs := h.checkLimits(ctx, op, fragments, req.Variables); len(errs) > 0 {
+		return &Response{Errors: errs}, false
+	}
+
+	executed := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoader(ctx, newLoader(h.svc)),
+	})
+	return &Response{Data: executed.Data, Errors: fieldErrors(ctx, executed.Errors)}, false
+}
+
+func (h *Handler) checkLimits(ctx context.Context, op *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition, variables map[string]interface{}) []Error {
+	c := measure(op, fragments, variables)
+	var errs []Error
+	if h.cfg.MaxDepth > 0 && c.depth > h.cfg.MaxDepth {
+		errs = append(errs, Error{
+			Message:    i18n.T(ctx, "graphql.too_deep", c.depth, h.cfg.MaxDepth),
+			Extensions: map[string]interface{}{"code": codeTooDeep, "depth": c.depth, "max_depth": h.cfg.MaxDepth},
+		})
+	}
+	if h.cfg.MaxComplexity > 0 && c.complexity > h.cfg.MaxComplexity {
+		errs = append(errs, Error{
+			Message:    i18n.T(ctx, "graphql.too_complex", c.complexity, h.cfg.MaxComplexity),
+			Extensions: map[string]interface{}{"code": codeTooComplex, "complexity": c.complexity, "max_complexity": h.cfg.MaxComplexity},
+		})
+	}
+	return errs
+}
+
+// requestErrors converte os erros de sintaxe e de validação da consulta
+func requestErrors(code string, errs []gqlerrors.FormattedError) []Error {
+	out := make([]Error, len(errs))
+	for i, err := range errs {
+		out[i] = newError(err)
+		out[i].Extensions = map[string]interface{}{"code": code}
+	}
+	return out
+}
+
+// fieldErrors converte os erros da execução. Os dos resolvers passam pelo
+// mesmo mapeamento das respostas REST e são traduzidos para o idioma da
+// requisição; erros internos são registrados e não expõem detalhes.
+func fieldErrors(ctx context.Context, errs []gqlerrors.FormattedError) []Error {
+	if len(errs) == 0 {
+		return nil
+	}
+	out := make([]Error, len(errs))
+	for i, err := range errs {
+		out[i] = newError(err)
+		original := originalError(err)
+		if original == nil {
+			// Erros do executor, como variáveis com tipo errado
+			out[i].Extensions = map[string]interface{}{"code": codeValidationFailed}
+			continue
+		}
+		p := handler.MapError(original)
+		if p.Status >= http.StatusInternalServerError {
+			logger.ErrorContext(ctx, "Erro ao resolver campo GraphQL", "path", err.Path, "error", original)
+		}
+		localized := p.In(reqctx.Locale(ctx))
+		out[i].Message = localized.Detail
+		if out[i].Message == "" {
+			out[i].Message = localized.Title
+		}
+		out[i].Extensions = map[string]interface{}{"code": p.Code}
+		if len(p.Errors) > 0 {
+			out[i].Extensions["errors"] = p.Errors
+		}
+	}
+	return out
+}
+
+func newError(err gqlerrors.FormattedError) Error {
+	e := Error{Message: err.Message, Path: err.Path}
+	for _, location := range err.Locations {
+		e.Locations = append(e.Locations, Location{Line: location.Line, Column: location.Column})
+	}
+	return e
+}
+
+// originalError recupera o erro devolvido pelo resolver, que a biblioteca
+// envolve em *gqlerrors.Error e FormattedError
+func originalError(err error) error {
+	for {
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			err = e.OriginalError
+		default:
+			return err
+		}
+	}
+}
+
+// playgroundPage é o GraphiQL, carregado de CDN; só é servido com o
+// playground habilitado, o padrão em desenvolvimento
+//
+//go:embed graphiql.html
+var playgroundPage []byte
@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// cost é a profundidade e a complexidade estimada de uma seleção
+type cost struct {
+	depth      int
+	complexity int
+}
+
+// measure calcula a profundidade e a complexidade da operação, com os
+// fragmentos expandidos. Cada campo custa 1 mais o custo da sua seleção;
+// campos paginados multiplicam o custo da seleção por perPage, ou pelo padrão
+// quando o argumento não é informado. Campos de introspecção (__schema,
+// __type e __typename) não contam. O documento já deve ter sido validado,
+// o que garante fragmentos conhecidos e sem ciclos.
+func measure(op *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition, variables map[string]interface{}) cost {
+	m := measurer{fragments: fragments, variables: variables}
+	return m.selectionSet(op.SelectionSet)
+}
+
+type measurer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+func (m measurer) selectionSet(set *ast.SelectionSet) cost {
+	var total cost
+	if set == nil {
+		return total
+	}
+	for _, selection := range set.Selections {
+		var c cost
+		switch selection := selection.(type) {
+		case *ast.Field:
+			c = m.field(selection)
+		case *ast.InlineFragment:
+			c = m.selectionSet(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if fragment, ok := m.fragments[selection.Name.Value]; ok {
+				c = m.selectionSet(fragment.SelectionSet)
+			}
+		}
+		total.depth = max(total.depth, c.depth)
+		total.complexity += c.complexity
+	}
+	return total
+}
+
+func (m measurer) field(field *ast.Field) cost {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return cost{}
+	}
+	children := m.selectionSet(field.SelectionSet)
+	return cost{
+		depth:      children.depth + 1,
+		complexity: 1 + children.complexity*m.multiplier(field),
+	}
+}
+
+// paginatedFields são os campos cuja seleção se repete para cada item da página
+var paginatedFields = map[string]bool{"personalities": true, "search": true}
+
+// multiplier é o número de itens que o campo pode devolver
+func (m measurer) multiplier(field *ast.Field) int {
+	if !paginatedFields[field.Name.Value] {
+		return 1
+	}
+	for _, arg := range field.Arguments {
+		if arg.Name.Value == "perPage" {
+			if n := m.intValue(arg.Value); n > 0 {
+				return n
+			}
+		}
+	}
+	return defaultPerPage
+}
+
+func (m measurer) intValue(value ast.Value) int {
+	switch value := value.(type) {
+	case *ast.IntValue:
+		n, _ := strconv.Atoi(value.Value)
+		return n
+	case *ast.Variable:
+		switch v := m.variables[value.Name.Value].(type) {
+		case float64:
+			return int(v)
+		case int:
+			return v
+		case int64:
+			return int(v)
+		}
+	}
+	return 0
+}
+
+// operation encontra a operação a executar e os fragmentos do documento;
+// sem nome, o documento deve ter uma única operação
+func operation(doc *ast.Document, name string) (*ast.OperationDefinition, map[string]*ast.FragmentDefinition) {
+	var op *ast.OperationDefinition
+	operations := 0
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			operations++
+			if name == "" || (definition.Name != nil && definition.Name.Value == name) {
+				op = definition
+			}
+		case *ast.FragmentDefinition:
+			fragments[definition.Name.Value] = definition
+		}
+	}
+	if name == "" && operations > 1 {
+		return nil, fragments
+	}
+	return op, fragments
+}
@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"context"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/service"
+	"slices"
+	"sync"
+)
+
+// loader agrupa as buscas por ID de uma requisição em uma única chamada a
+// GetByIDs. Cada load devolve um thunk que o executor avalia depois de
+// resolver os demais campos do mesmo nível; o primeiro thunk avaliado busca
+// todos os IDs pendentes. Os resultados ficam guardados até o fim da requisição.
+type loader struct {
+	svc     service.PersonalityService
+	mu      sync.Mutex
+	pending []uint
+	results map[uint]loaded
+	batches int
+}
+
+// loaded é o resultado de um ID; personality nil sem erro indica inexistente
+type loaded struct {
+	personality *dto.PersonalityResponse
+	err         error
+}
+
+func newLoader(svc service.PersonalityService) *loader {
+	return &loader{svc: svc, results: make(map[uint]loaded)}
+}
+
+// load agenda a busca do ID e retorna o thunk com o resultado
+func (l *loader) load(ctx context.Context, id uint) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, done := l.results[id]; !done && !slices.Contains(l.pending, id) {
+		l.pending = append(l.pending, id)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, done := l.results[id]; !done {
+			l.dispatch(ctx)
+		}
+		result := l.results[id]
+		if result.err != nil || result.personality == nil {
+			// Nil sem tipo, para o executor responder null
+			return nil, result.err
+		}
+		return result.personality, nil
+	}
+}
+
+// dispatch busca os IDs pendentes; exige o lock
+func (l *loader) dispatch(ctx context.Context) {
+	ids := l.pending
+	l.pending = nil
+	l.batches++
+
+	found, err := l.svc.GetByIDs(ctx, ids)
+	for _, id := range ids {
+		l.results[id] = loaded{err: err}
+	}
+	for i := range found {
+		l.results[found[i].ID] = loaded{personality: &found[i]}
+	}
+}
+
+// prime guarda personalidades já obtidas por outra consulta
+func (l *loader) prime(items []dto.PersonalityResponse) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i := range items {
+		if _, done := l.results[items[i].ID]; !done {
+			l.results[items[i].ID] = loaded{personality: &items[i]}
+		}
+	}
+}
+
+type loaderKey struct{}
+
+func withLoader(ctx context.Context, l *loader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// loaderFrom retorna o loader da requisição
+func loaderFrom(ctx context.Context) *loader {
+	return ctx.Value(loaderKey{}).(*loader)
+}
@@ -0,0 +1,276 @@
+// Package graph expõe o serviço de personalidades como uma API GraphQL, com
+// agrupamento das buscas por ID e limites de profundidade e complexidade.
+package graph
+
+import (
+	"context"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/service"
+	"go-api-rest/pkg/problem"
+	customValidator "go-api-rest/pkg/validator"
+	"strconv"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Página padrão das listagens quando page e perPage não são informados
+const (
+	defaultPage    = 1
+	defaultPerPage = 20
+)
+
+// NewSchema monta o schema GraphQL sobre o serviço de personalidades. As
+// buscas por ID usam o loader da requisição, criado pelo Handler.
+func NewSchema(svc service.PersonalityService) (graphql.Schema, error) {
+	personality := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Personality",
+		Description: "Personalidade histórica",
+		Fields: graphql.Fields{
+			"id":        personalityField(graphql.NewNonNull(graphql.ID), func(p *dto.PersonalityResponse) interface{} { return p.ID }),
+			"name":      personalityField(graphql.NewNonNull(graphql.String), func(p *dto.PersonalityResponse) interface{} { return p.Name }),
+			"history":   personalityField(graphql.NewNonNull(graphql.String), func(p *dto.PersonalityResponse) interface{} { return p.History }),
+			"version":   personalityField(graphql.NewNonNull(graphql.Int), func(p *dto.PersonalityResponse) interface{} { return p.Version }),
+			"createdAt": personalityField(graphql.NewNonNull(graphql.DateTime), func(p *dto.PersonalityResponse) interface{} { return p.CreatedAt }),
+			"updatedAt": personalityField(graphql.NewNonNull(graphql.DateTime), func(p *dto.PersonalityResponse) interface{} { return p.UpdatedAt }),
+		},
+	})
+
+	pageInfo := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"page":            pageField(graphql.Int, func(p *page) interface{} { return p.number }),
+			"perPage":         pageField(graphql.Int, func(p *page) interface{} { return p.perPage }),
+			"total":           pageField(graphql.Int, func(p *page) interface{} { return p.total }),
+			"totalPages":      pageField(graphql.Int, func(p *page) interface{} { return p.totalPages() }),
+			"hasNextPage":     pageField(graphql.Boolean, func(p *page) interface{} { return p.number < p.totalPages() }),
+			"hasPreviousPage": pageField(graphql.Boolean, func(p *page) interface{} { return p.number > 1 }),
+		},
+	})
+
+	personalityPage := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PersonalityPage",
+		Fields: graphql.Fields{
+			"items": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(personality))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					items := p.Source.(*page).items
+					out := make([]interface{}, len(items))
+					for i := range items {
+						out[i] = &items[i]
+					}
+					return out, nil
+				},
+			},
+			"pageInfo": &graphql.Field{
+				Type:    graphql.NewNonNull(pageInfo),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source, nil },
+			},
+		},
+	})
+
+	filterInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "PersonalityFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":         {Type: graphql.String, Description: "Trecho do nome, sem diferenciar maiúsculas"},
+			"updatedSince": {Type: graphql.DateTime, Description: "Alteradas a partir desta data"},
+		},
+	})
+	createInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreatePersonalityInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":    {Type: graphql.NewNonNull(graphql.String)},
+			"history": {Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	updateInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "UpdatePersonalityInput",
+		Description: "Campos omitidos mantêm o valor atual; ao menos um deve ser informado",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":    {Type: graphql.String},
+			"history": {Type: graphql.String},
+		},
+	})
+
+	withPage := func(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+		args["page"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPage}
+		args["perPage"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPerPage, Description: "Itens por página, até 100"}
+		return args
+	}
+	idArg := graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"personality": &graphql.Field{
+				Type:        personality,
+				Description: "Busca uma personalidade; buscas da mesma consulta são agrupadas",
+				Args:        idArg,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := parseID(p.Args["id"])
+					if err != nil {
+						return nil, err
+					}
+					return loaderFrom(p.Context).load(p.Context, id), nil
+				},
+			},
+			"personalities": &graphql.Field{
+				Type:        graphql.NewNonNull(personalityPage),
+				Description: "Lista as personalidades em ordem de ID",
+				Args:        withPage(graphql.FieldConfigArgument{"filter": &graphql.ArgumentConfig{Type: filterInput}}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var filter dto.PersonalityFilter
+					if raw, ok := p.Args["filter"].(map[string]interface{}); ok {
+						filter.Name, _ = raw["name"].(string)
+						filter.UpdatedSince, _ = raw["updatedSince"].(time.Time)
+					}
+					return list(p, svc, filter)
+				},
+			},
+			"search": &graphql.Field{
+				Type:        graphql.NewNonNull(personalityPage),
+				Description: "Busca o texto no nome e na história, sem diferenciar maiúsculas",
+				Args:        withPage(graphql.FieldConfigArgument{"text": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return list(p, svc, dto.PersonalityFilter{Text: p.Args["text"].(string)})
+				},
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createPersonality": &graphql.Field{
+				Type: graphql.NewNonNull(personality),
+				Args: graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createInput)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					input := p.Args["input"].(map[string]interface{})
+					req := &dto.CreatePersonalityRequest{}
+					req.Name, _ = input["name"].(string)
+					req.History, _ = input["history"].(string)
+					if err := validate(p.Context, req); err != nil {
+						return nil, err
+					}
+					return svc.Create(p.Context, req)
+				},
+			},
+			"updatePersonality": &graphql.Field{
+				Type: graphql.NewNonNull(personality),
+				Args: graphql.FieldConfigArgument{
+					"id":    idArg["id"],
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(updateInput)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := parseID(p.Args["id"])
+					if err != nil {
+						return nil, err
+					}
+					input := p.Args["input"].(map[string]interface{})
+					req := &dto.UpdatePersonalityRequest{}
+					req.Name, _ = input["name"].(string)
+					req.History, _ = input["history"].(string)
+					if err := validate(p.Context, req); err != nil {
+						return nil, err
+					}
+					return svc.Update(p.Context, id, req)
+				},
+			},
+			"deletePersonality": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Remove uma personalidade; erros indicam que ela não existe",
+				Args:        idArg,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := parseID(p.Args["id"])
+					if err != nil {
+						return nil, err
+					}
+					if err := svc.Delete(p.Context, id); err != nil {
+						return nil, err
+					}
+					return true, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func personalityField(typ graphql.Output, value func(*dto.PersonalityResponse) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return value(p.Source.(*dto.PersonalityResponse)), nil
+		},
+	}
+}
+
+// page é a fonte dos campos de PersonalityPage e PageInfo
+type page struct {
+	items   []dto.PersonalityResponse
+	number  int
+	perPage int
+	total   int64
+}
+
+func (p *page) totalPages() int {
+	return int((p.total + int64(p.perPage) - 1) / int64(p.perPage))
+}
+
+func pageField(typ graphql.Output, value func(*page) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewNonNull(typ),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return value(p.Source.(*page)), nil
+		},
+	}
+}
+
+// pageArgs valida os argumentos de paginação com os nomes usados no schema
+type pageArgs struct {
+	Page    int `json:"page" validate:"gte=1,lte=1000000"`
+	PerPage int `json:"perPage" validate:"gte=1,lte=100"`
+}
+
+// list valida filtro e página e consulta o serviço. Os itens da página
+// alimentam o loader, evitando novas buscas pelos mesmos IDs na consulta.
+func list(p graphql.ResolveParams, svc service.PersonalityService, filter dto.PersonalityFilter) (interface{}, error) {
+	args := pageArgs{Page: p.Args["page"].(int), PerPage: p.Args["perPage"].(int)}
+	if err := validate(p.Context, args); err != nil {
+		return nil, err
+	}
+	if err := validate(p.Context, filter); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.List(p.Context, filter, dto.PageRequest{Page: args.Page, PerPage: args.PerPage})
+	if err != nil {
+		return nil, err
+	}
+	loaderFrom(p.Context).prime(result.Items)
+	return &page{items: result.Items, number: args.Page, perPage: args.PerPage, total: result.Total}, nil
+}
+
+// validate aplica as regras de validação do DTO; os erros voltam por campo
+// nas extensões do erro GraphQL
+func validate(ctx context.Context, v interface{}) error {
+	validationErrors, err := customValidator.ValidateStructContext(ctx, v)
+	if err != nil {
+		return err
+	}
+	if validationErrors != nil {
+		return problem.Validation(validationErrors)
+	}
+	return nil
+}
+
+// parseID converte o ID GraphQL, serializado como string, para o ID numérico
+func parseID(raw interface{}) (uint, error) {
+	s, _ := raw.(string)
+	id, err := strconv.ParseUint(s, 10, 32)
+	if err != nil || id == 0 {
+		return 0, service.ErrInvalidID
+	}
+	return uint(id), nil
+}
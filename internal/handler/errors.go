@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"go-api-rest/internal/service"
+	"go-api-rest/pkg/codec"
+	"go-api-rest/pkg/logger"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/response"
+	"net/http"
+	"strings"
+)
+
+// Tipos de problema do domínio de personalidades
+var (
+	PersonalityNotFound = problem.Type{Code: "PERSONALITY_NOT_FOUND", Status: http.StatusNotFound, Title: "Personalidade não encontrada"}
+	NameConflict        = problem.Type{Code: "NAME_CONFLICT", Status: http.StatusConflict, Title: "Nome já cadastrado"}
+	InvalidID           = problem.Type{Code: "INVALID_ID", Status: http.StatusBadRequest, Title: "ID inválido"}
+)
+
+// errorMapper concentra a tradução dos erros do serviço para respostas HTTP
+var errorMapper = problem.NewMapper().
+	Register(service.ErrPersonalityNotFound, PersonalityNotFound).
+	Register(service.ErrPersonalityAlreadyExists, NameConflict).
+	Register(service.ErrInvalidID, InvalidID).
+	Register(codec.ErrUnsupportedMediaType, problem.UnsupportedMediaType)
+
+// MapError traduz um erro do serviço para o problema correspondente; erros
+// desconhecidos viram INTERNAL_ERROR
+func MapError(err error) *problem.Problem {
+	return errorMapper.Map(err)
+}
+
+// writeError responde com o problema correspondente a err; erros internos são
+// registrados no log, já que a resposta não traz detalhes
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	p := errorMapper.Map(err)
+	if p.Status >= http.StatusInternalServerError {
+		logger.ErrorContext(r.Context(), "Erro ao processar requisição", "error", err)
+	}
+	if p.Code == problem.UnsupportedMediaType.Code {
+		// Accept-Post (W3C LDP) e Accept-Patch (RFC 5789) anunciam os formatos aceitos
+		types := strings.Join(response.Registry().DecoderMediaTypes(), ", ")
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Accept-Post", types)
+		case http.MethodPatch:
+			w.Header().Set("Accept-Patch", types)
+		}
+	}
+	problem.Write(w, r, p)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"go-api-rest/internal/dto"
+	"go-api-rest/pkg/i18n"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/response"
+	customValidator "go-api-rest/pkg/validator"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// defaultPerPage é o tamanho de página quando per_page não é informado
+const defaultPerPage = 20
+
+// list responde a listagem paginada da v2 com metadados, links no corpo e no
+// cabeçalho Link (RFC 8288)
+func (h *PersonalityHandler) list(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePage(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	result, err := h.service.List(r.Context(), dto.PersonalityFilter{}, page)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if response.NotModified(w, r, pageValidators(page, result)) {
+		return
+	}
+	envelope := dto.PersonalityListEnvelope{Data: result.Items, Meta: pageMeta(page, result.Total)}
+	envelope.Links = pageLinks(r.URL, envelope.Meta)
+	setLinkHeader(w, envelope.Links)
+	response.Success(w, r, http.StatusOK, envelope)
+}
+
+// parsePage lê page e per_page da query; valores ausentes usam os padrões
+func parsePage(r *http.Request) (dto.PageRequest, error) {
+	page := dto.PageRequest{Page: 1, PerPage: defaultPerPage}
+	query := r.URL.Query()
+	fields := make(map[string]string)
+	for name, target := range map[string]*int{"page": &page.Page, "per_page": &page.PerPage} {
+		raw := query.Get(name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			fields[name] = i18n.T(r.Context(), "request.not_integer", name)
+			continue
+		}
+		*target = n
+	}
+	if len(fields) > 0 {
+		return page, problem.Validation(fields)
+	}
+
+	validationErrors, err := customValidator.ValidateStructContext(r.Context(), page)
+	if err != nil {
+		return page, err
+	}
+	if validationErrors != nil {
+		return page, problem.Validation(validationErrors)
+	}
+	return page, nil
+}
+
+func pageMeta(page dto.PageRequest, total int64) dto.PageMeta {
+	totalPages := int((total + int64(page.PerPage) - 1) / int64(page.PerPage))
+	return dto.PageMeta{Page: page.Page, PerPage: page.PerPage, Total: total, TotalPages: totalPages}
+}
+
+// pageLinks monta os links relativos preservando os demais parâmetros da query
+func pageLinks(u *url.URL, meta dto.PageMeta) dto.PageLinks {
+	link := func(page int) string {
+		query := u.Query()
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", strconv.Itoa(meta.PerPage))
+		return u.Path + "?" + query.Encode()
+	}
+
+	last := max(meta.TotalPages, 1)
+	links := dto.PageLinks{Self: link(meta.Page), First: link(1), Last: link(last)}
+	if meta.Page > 1 {
+		links.Prev = link(min(meta.Page-1, last))
+	}
+	if meta.Page < meta.TotalPages {
+		links.Next = link(meta.Page + 1)
+	}
+	return links
+}
+
+func setLinkHeader(w http.ResponseWriter, links dto.PageLinks) {
+	var values []string
+	for _, l := range []struct{ rel, href string }{
+		{"first", links.First}, {"prev", links.Prev}, {"next", links.Next}, {"last", links.Last},
+	} {
+		if l.href != "" {
+			values = append(values, fmt.Sprintf(`<%s>; rel="%s"`, l.href, l.rel))
+		}
+	}
+	w.Header().Add("Link", strings.Join(values, ", "))
+}
+
+// pageValidators resume IDs e versões da página e o total, que muda os
+// metadados mesmo quando os itens da página são os mesmos
+func pageValidators(page dto.PageRequest, result *dto.PersonalityPage) response.Validators {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d:%d:%d;", page.Page, page.PerPage, result.Total)
+	for _, p := range result.Items {
+		fmt.Fprintf(hash, "%d:%d;", p.ID, p.Version)
+	}
+	return response.Validators{ETag: fmt.Sprintf(`W/"v2-%x"`, hash.Sum(nil)[:16])}
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-api-rest/internal/config"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/events"
+	"go-api-rest/pkg/i18n"
+	"go-api-rest/pkg/logger"
+	"go-api-rest/pkg/problem"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventStreamContentType é o media type das respostas Server-Sent Events
+const EventStreamContentType = "text/event-stream"
+
+// defaultHeartbeatInterval vale quando EVENTS_HEARTBEAT_INTERVAL não é positivo
+const defaultHeartbeatInterval = 15 * time.Second
+
+// resetEvent avisa que eventos se perderam e o estado deve ser reconsultado
+const resetEvent = "reset"
+
+// PersonalityEventsHandler transmite as alterações publicadas no broker como
+// Server-Sent Events
+type PersonalityEventsHandler struct {
+	broker *events.Broker
+	cfg    config.EventsConfig
+}
+
+// NewPersonalityEventsHandler cria o handler do feed de alterações
+func NewPersonalityEventsHandler(broker *events.Broker, cfg config.EventsConfig) *PersonalityEventsHandler {
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = defaultHeartbeatInterval
+	}
+	return &PersonalityEventsHandler{broker: broker, cfg: cfg}
+}
+
+// ServeHTTP mantém o stream aberto até o cliente desconectar. Com Last-Event-ID
+// os eventos perdidos são reenviados do histórico do broker; quando já saíram
+// dele, o stream começa com um evento reset. Comentários periódicos mantêm a
+// conexão viva, e o cliente que não acompanha os eventos é desconectado para
+// retomar pelo Last-Event-ID.
+func (h *PersonalityEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ids, err := parseEventIDs(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", EventStreamContentType)
+	// Desliga o buffer de proxies como o nginx
+	w.Header().Set("X-Accel-Buffering", "no")
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var sub *events.Subscription
+	var replay []events.Event
+	reset := false
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		sub, replay, err = h.broker.SubscribeSince(lastEventID)
+		reset = errors.Is(err, events.ErrReplayUnavailable)
+	} else {
+		sub = h.broker.Subscribe()
+	}
+	defer sub.Close()
+
+	stream := &eventStream{w: w, rc: http.NewResponseController(w), timeout: h.cfg.WriteTimeout, eventID: h.broker.EventID}
+	// O prazo de escrita não deve sobrar para a próxima requisição da conexão
+	defer stream.rc.SetWriteDeadline(time.Time{})
+	w.WriteHeader(http.StatusOK)
+	// Envia os cabeçalhos de imediato; o comentário indica o início do stream
+	if err := stream.comment("stream"); err != nil {
+		return
+	}
+	if reset {
+		// O ID do reset faz uma reconexão retomar a partir deste ponto
+		if err := stream.send(sub.Start(), resetEvent, struct{}{}); err != nil {
+			return
+		}
+	}
+	for _, e := range replay {
+		if err := stream.event(e, ids); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.cfg.HeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if err := stream.comment("heartbeat"); err != nil {
+				return
+			}
+		case e, ok := <-sub.Events():
+			if !ok {
+				logger.WarnContext(r.Context(), "Cliente do feed de alterações desconectado", "error", sub.Err())
+				return
+			}
+			if err := stream.event(e, ids); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// parseEventIDs lê os IDs do filtro, repetidos (?id=1&id=2) ou separados por
+// vírgula (?id=1,2); sem IDs todos os eventos são enviados
+func parseEventIDs(r *http.Request) (map[uint]bool, error) {
+	values := r.URL.Query()["id"]
+	if len(values) == 0 {
+		return nil, nil
+	}
+	ids := make(map[uint]bool)
+	for _, value := range values {
+		for _, raw := range strings.Split(value, ",") {
+			id, err := strconv.ParseUint(strings.TrimSpace(raw), 10, 32)
+			if err != nil || id == 0 {
+				return nil, problem.Validation(map[string]string{"id": i18n.T(r.Context(), "request.not_integer", "id")})
+			}
+			ids[uint(id)] = true
+		}
+	}
+	return ids, nil
+}
+
+// eventStream escreve no formato text/event-stream, com prazo por escrita para
+// que um cliente parado não prenda a conexão
+type eventStream struct {
+	w       io.Writer
+	rc      *http.ResponseController
+	timeout time.Duration
+	eventID func(seq uint64) string
+}
+
+// event envia o evento quando ele passa pelo filtro de IDs
+func (s *eventStream) event(e events.Event, ids map[uint]bool) error {
+	if len(ids) > 0 && !ids[e.PersonalityID] {
+		return nil
+	}
+	return s.send(e.ID, string(e.Type), dto.PersonalityEvent{
+		Type:          string(e.Type),
+		PersonalityID: e.PersonalityID,
+		Personality:   e.Personality,
+		Time:          e.Time,
+	})
+}
+
+func (s *eventStream) send(seq uint64, name string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return s.write(fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", s.eventID(seq), name, payload))
+}
+
+func (s *eventStream) comment(text string) error {
+	return s.write(": " + text + "\n\n")
+}
+
+// write envia o texto ao cliente com o prazo renovado; writers sem suporte a
+// prazo ou flush seguem sem eles
+func (s *eventStream) write(text string) error {
+	if s.timeout > 0 {
+		_ = s.rc.SetWriteDeadline(time.Now().Add(s.timeout))
+	}
+	if _, err := io.WriteString(s.w, text); err != nil {
+		return err
+	}
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
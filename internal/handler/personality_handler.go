@@ -1,11 +1,14 @@
 package handler
 
 import (
-	"encoding/json"
-	"errors"
+	"crypto/sha256"
+	"fmt"
 	"go-api-rest/internal/dto"
 	"go-api-rest/internal/service"
-	"go-api-rest/pkg/logger"
+	"go-api-rest/pkg/i18n"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/reqctx"
+	"go-api-rest/pkg/request"
 	"go-api-rest/pkg/response"
 	customValidator "go-api-rest/pkg/validator"
 	"net/http"
@@ -26,131 +29,174 @@ func NewPersonalityHandler(service service.PersonalityService) *PersonalityHandl
 
 // Home retorna a página inicial da API
 func (h *PersonalityHandler) Home(w http.ResponseWriter, r *http.Request) {
-	response.Success(w, http.StatusOK, dto.SuccessResponse{
-		Message: "Bem-vindo à API REST de Personalidades em Go!",
+	response.Success(w, r, http.StatusOK, dto.SuccessResponse{
+		Message: i18n.T(r.Context(), "home.welcome"),
 	})
 }
 
-// GetAll retorna todas as personalidades
+// GetAll retorna todas as personalidades; na v2 a listagem é paginada
 func (h *PersonalityHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	personalities, err := h.service.GetAll()
+	if reqctx.APIVersion(r.Context()) >= 2 {
+		h.list(w, r)
+		return
+	}
+
+	personalities, err := h.service.GetAll(r.Context())
 	if err != nil {
-		logger.Errorf("Erro ao buscar personalidades: %v", err)
-		response.Error(w, http.StatusInternalServerError, "Erro ao buscar personalidades")
+		writeError(w, r, err)
 		return
 	}
 
-	response.Success(w, http.StatusOK, personalities)
+	if response.NotModified(w, r, collectionValidators(personalities)) {
+		return
+	}
+	response.Success(w, r, http.StatusOK, personalities)
 }
 
 // GetByID retorna uma personalidade por ID
 func (h *PersonalityHandler) GetByID(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	id, err := parseID(r)
 	if err != nil {
-		response.Error(w, http.StatusBadRequest, "ID inválido")
+		writeError(w, r, err)
 		return
 	}
 
-	personality, err := h.service.GetByID(uint(id))
+	personality, err := h.service.GetByID(r.Context(), id)
 	if err != nil {
-		if errors.Is(err, service.ErrPersonalityNotFound) {
-			response.Error(w, http.StatusNotFound, err.Error())
-			return
-		}
-		logger.Errorf("Erro ao buscar personalidade: %v", err)
-		response.Error(w, http.StatusInternalServerError, "Erro ao buscar personalidade")
+		writeError(w, r, err)
 		return
 	}
 
-	response.Success(w, http.StatusOK, personality)
+	if response.NotModified(w, r, personalityValidators(r, personality)) {
+		return
+	}
+	response.Success(w, r, http.StatusOK, represent(r, personality))
 }
 
 // Create cria uma nova personalidade
 func (h *PersonalityHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreatePersonalityRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "Dados inválidos")
-		return
-	}
-
-	// Validar dados
-	if validationErrors := customValidator.ValidateStruct(req); validationErrors != nil {
-		response.ValidationError(w, validationErrors)
+	if err := decodeAndValidate(r, &req); err != nil {
+		writeError(w, r, err)
 		return
 	}
 
-	personality, err := h.service.Create(&req)
+	personality, err := h.service.Create(r.Context(), &req)
 	if err != nil {
-		if errors.Is(err, service.ErrPersonalityAlreadyExists) {
-			response.Error(w, http.StatusConflict, err.Error())
-			return
-		}
-		logger.Errorf("Erro ao criar personalidade: %v", err)
-		response.Error(w, http.StatusInternalServerError, "Erro ao criar personalidade")
+		writeError(w, r, err)
 		return
 	}
 
-	response.Created(w, personality)
+	personalityValidators(r, personality).Set(w)
+	response.Created(w, r, represent(r, personality))
 }
 
 // Update atualiza uma personalidade existente
 func (h *PersonalityHandler) Update(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	id, err := parseID(r)
 	if err != nil {
-		response.Error(w, http.StatusBadRequest, "ID inválido")
+		writeError(w, r, err)
 		return
 	}
 
 	var req dto.UpdatePersonalityRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "Dados inválidos")
+	if err := decodeAndValidate(r, &req); err != nil {
+		writeError(w, r, err)
 		return
 	}
 
-	// Validar dados
-	if validationErrors := customValidator.ValidateStruct(req); validationErrors != nil {
-		response.ValidationError(w, validationErrors)
-		return
-	}
-
-	personality, err := h.service.Update(uint(id), &req)
+	personality, err := h.service.Update(r.Context(), id, &req)
 	if err != nil {
-		if errors.Is(err, service.ErrPersonalityNotFound) {
-			response.Error(w, http.StatusNotFound, err.Error())
-			return
-		}
-		if errors.Is(err, service.ErrPersonalityAlreadyExists) {
-			response.Error(w, http.StatusConflict, err.Error())
-			return
-		}
-		logger.Errorf("Erro ao atualizar personalidade: %v", err)
-		response.Error(w, http.StatusInternalServerError, "Erro ao atualizar personalidade")
+		writeError(w, r, err)
 		return
 	}
 
-	response.Success(w, http.StatusOK, personality)
+	personalityValidators(r, personality).Set(w)
+	response.Success(w, r, http.StatusOK, represent(r, personality))
 }
 
 // Delete remove uma personalidade
 func (h *PersonalityHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	id, err := parseID(r)
 	if err != nil {
-		response.Error(w, http.StatusBadRequest, "ID inválido")
+		writeError(w, r, err)
 		return
 	}
 
-	if err := h.service.Delete(uint(id)); err != nil {
-		if errors.Is(err, service.ErrPersonalityNotFound) {
-			response.Error(w, http.StatusNotFound, err.Error())
-			return
-		}
-		logger.Errorf("Erro ao deletar personalidade: %v", err)
-		response.Error(w, http.StatusInternalServerError, "Erro ao deletar personalidade")
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		writeError(w, r, err)
 		return
 	}
 
 	response.NoContent(w)
 }
+
+// parseID lê o parâmetro {id} da rota
+func parseID(r *http.Request) (uint, error) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		return 0, service.ErrInvalidID
+	}
+	return uint(id), nil
+}
+
+// decodeAndValidate decodifica o corpo conforme o Content-Type e valida o DTO
+func decodeAndValidate(r *http.Request, v interface{}) error {
+	if err := request.Decode(r, v); err != nil {
+		return err
+	}
+	validationErrors, err := customValidator.ValidateStructContext(r.Context(), v)
+	if err != nil {
+		return err
+	}
+	if validationErrors != nil {
+		return problem.Validation(validationErrors)
+	}
+	return nil
+}
+
+// represent aplica o formato da versão da API: a v1 devolve o recurso e a v2
+// o envolve em {"data": ...}
+func represent(r *http.Request, p *dto.PersonalityResponse) interface{} {
+	if reqctx.APIVersion(r.Context()) >= 2 {
+		return dto.PersonalityEnvelope{Data: *p}
+	}
+	return p
+}
+
+// ResourceSample devolve um valor vazio do tipo respondido pelas rotas de um
+// recurso na versão da requisição, para a negociação de conteúdo recusar
+// formatos que não o representam antes de executar o handler
+func ResourceSample(r *http.Request) interface{} {
+	return represent(r, &dto.PersonalityResponse{})
+}
+
+// CollectionSample é o equivalente de ResourceSample para a listagem, paginada
+// em um envelope a partir da v2
+func CollectionSample(r *http.Request) interface{} {
+	if reqctx.APIVersion(r.Context()) >= 2 {
+		return dto.PersonalityListEnvelope{}
+	}
+	return []dto.PersonalityResponse{}
+}
+
+// personalityValidators deriva ETag e Last-Modified do ID, da versão e de UpdatedAt.
+// O ETag é fraco porque a mesma versão pode ter representações diferentes; a
+// partir da v2 ele leva a versão da API, já que o formato muda entre versões.
+func personalityValidators(r *http.Request, p *dto.PersonalityResponse) response.Validators {
+	etag := fmt.Sprintf(`W/"%d-%d"`, p.ID, p.Version)
+	if version := reqctx.APIVersion(r.Context()); version >= 2 {
+		etag = fmt.Sprintf(`W/"v%d-%d-%d"`, version, p.ID, p.Version)
+	}
+	return response.Validators{ETag: etag, LastModified: p.UpdatedAt}
+}
+
+// collectionValidators resume os IDs e versões da lista em um único ETag.
+// Não há Last-Modified: uma remoção muda a lista sem alterar nenhum UpdatedAt.
+func collectionValidators(personalities []dto.PersonalityResponse) response.Validators {
+	hash := sha256.New()
+	for _, p := range personalities {
+		fmt.Fprintf(hash, "%d:%d;", p.ID, p.Version)
+	}
+	return response.Validators{ETag: fmt.Sprintf(`W/"%x"`, hash.Sum(nil)[:16])}
+}
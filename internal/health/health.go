@@ -0,0 +1,177 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"go-api-rest/pkg/response"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status representa o estado de um check ou da aplicação
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// ErrShuttingDown indica que a aplicação está encerrando e não aceita tráfego
+var ErrShuttingDown = errors.New("aplicação em processo de encerramento")
+
+// CheckFunc verifica uma dependência e retorna erro quando ela está indisponível
+type CheckFunc func(ctx context.Context) error
+
+// Check descreve uma verificação registrada
+type Check struct {
+	Name     string
+	Check    CheckFunc
+	Timeout  time.Duration // usa o timeout padrão do Registry quando zero
+	Critical bool          // checks críticos afetam o /readyz
+}
+
+// CheckResult é o resultado da execução de um check
+type CheckResult struct {
+	Status    Status  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Critical  bool    `json:"critical"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report é o relatório detalhado de saúde da aplicação
+type Report struct {
+	Status    Status                 `json:"status"`
+	Timestamp time.Time              `json:"timestamp"`
+	Checks    map[string]CheckResult `json:"checks"`
+}
+
+// Registry mantém os checks registrados e o estado de encerramento
+type Registry struct {
+	mu             sync.RWMutex
+	checks         []Check
+	defaultTimeout time.Duration
+	shuttingDown   atomic.Bool
+}
+
+// NewRegistry cria um Registry com o timeout padrão informado
+func NewRegistry(defaultTimeout time.Duration) *Registry {
+	return &Registry{defaultTimeout: defaultTimeout}
+}
+
+// Register adiciona um check, permitindo plugar novas dependências (cache, fila...)
+func (r *Registry) Register(check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, check)
+}
+
+// SetShuttingDown sinaliza que a aplicação está encerrando, tornando-a não pronta
+func (r *Registry) SetShuttingDown(shuttingDown bool) {
+	r.shuttingDown.Store(shuttingDown)
+}
+
+// Run executa os checks em paralelo. Com criticalOnly apenas os críticos são executados.
+func (r *Registry) Run(ctx context.Context, criticalOnly bool) Report {
+	r.mu.RLock()
+	checks := make([]Check, 0, len(r.checks))
+	for _, check := range r.checks {
+		if !criticalOnly || check.Critical {
+			checks = append(checks, check)
+		}
+	}
+	r.mu.RUnlock()
+
+	report := Report{
+		Status:    StatusUp,
+		Timestamp: time.Now().UTC(),
+		Checks:    make(map[string]CheckResult, len(checks)+1),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			result := r.runCheck(ctx, check)
+			mu.Lock()
+			report.Checks[check.Name] = result
+			mu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+
+	if r.shuttingDown.Load() {
+		report.Checks["shutdown"] = CheckResult{Status: StatusDown, Critical: true, Error: ErrShuttingDown.Error()}
+	}
+	for _, result := range report.Checks {
+		if result.Status == StatusDown && result.Critical {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// runCheck executa um check respeitando o timeout e medindo a latência
+func (r *Registry) runCheck(ctx context.Context, check Check) CheckResult {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = r.defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() { errCh <- check.Check(ctx) }()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		Critical:  check.Critical,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// LivenessHandler responde /healthz: o processo está vivo e atendendo requisições
+func (r *Registry) LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		response.JSON(w, http.StatusOK, map[string]Status{"status": StatusUp})
+	}
+}
+
+// ReadinessHandler responde /readyz executando apenas os checks críticos
+func (r *Registry) ReadinessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		writeReport(w, r.Run(req.Context(), true))
+	}
+}
+
+// ReportHandler responde /health com o relatório detalhado de todos os checks
+func (r *Registry) ReportHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		writeReport(w, r.Run(req.Context(), false))
+	}
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Cache-Control", "no-store")
+	status := http.StatusOK
+	if report.Status == StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+	response.JSON(w, status, report)
+}
@@ -0,0 +1,67 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRegistry_ReadinessIgnoresNonCriticalChecks(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	registry.Register(Check{Name: "database", Critical: true, Check: func(context.Context) error { return nil }})
+	registry.Register(Check{Name: "cache", Check: func(context.Context) error { return errors.New("indisponível") }})
+
+	rec := httptest.NewRecorder()
+	registry.ReadinessHandler()(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Esperava status 200, mas obteve %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	registry.ReportHandler()(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	var report Report
+	if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+		t.Fatalf("Relatório inválido: %v", err)
+	}
+	if report.Status != StatusUp || report.Checks["cache"].Status != StatusDown {
+		t.Errorf("Relatório inesperado: %+v", report)
+	}
+}
+
+func TestRegistry_CriticalFailureAndTimeout(t *testing.T) {
+	registry := NewRegistry(10 * time.Millisecond)
+	registry.Register(Check{Name: "database", Critical: true, Check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	start := time.Now()
+	report := registry.Run(context.Background(), true)
+	if time.Since(start) > time.Second {
+		t.Fatal("Check deveria respeitar o timeout")
+	}
+	if report.Status != StatusDown || report.Checks["database"].Error == "" {
+		t.Errorf("Esperava falha do check crítico, mas obteve %+v", report)
+	}
+}
+
+func TestRegistry_ShuttingDown(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	registry.SetShuttingDown(true)
+
+	rec := httptest.NewRecorder()
+	registry.ReadinessHandler()(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Esperava status 503 durante o encerramento, mas obteve %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	registry.LivenessHandler()(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Liveness deveria continuar 200, mas obteve %d", rec.Code)
+	}
+}
@@ -0,0 +1,199 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "go_api_rest"
+
+// Metrics reúne os coletores Prometheus da aplicação
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	httpInFlight *prometheus.GaugeVec
+
+	grpcRequests *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+	grpcInFlight *prometheus.GaugeVec
+
+	serviceOperations *prometheus.CounterVec
+	serviceDuration   *prometheus.HistogramVec
+
+	dbQueryDuration *prometheus.HistogramVec
+
+	cacheRequests *prometheus.CounterVec
+}
+
+// New cria os coletores e os registra junto com as métricas do runtime Go
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Total de requisições HTTP por rota, método e status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duração das requisições HTTP por rota, método e status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		httpInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "http_requests_in_flight",
+			Help:      "Requisições HTTP em andamento por rota e método.",
+		}, []string{"method", "route"}),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_total",
+			Help:      "Total de chamadas gRPC por método e código de status.",
+		}, []string{"method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "Duração das chamadas gRPC por método e código de status; streams contam até o fim.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		grpcInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_in_flight",
+			Help:      "Chamadas gRPC em andamento por método.",
+		}, []string{"method"}),
+		serviceOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "service_operations_total",
+			Help:      "Total de operações do serviço de personalidades por resultado.",
+		}, []string{"operation", "result"}),
+		serviceDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "service_operation_duration_seconds",
+			Help:      "Duração das operações do serviço de personalidades.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		dbQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Duração das queries SQL por operação e resultado.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "result"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_requests_total",
+			Help:      "Total de consultas ao cache de leitura por resultado.",
+		}, []string{"cache", "result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.httpInFlight,
+		m.grpcRequests,
+		m.grpcDuration,
+		m.grpcInFlight,
+		m.serviceOperations,
+		m.serviceDuration,
+		m.dbQueryDuration,
+		m.cacheRequests,
+	)
+	return m
+}
+
+// Registry retorna o registro Prometheus para coletores adicionais
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler expõe as métricas no formato texto do Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterDB exporta as estatísticas do pool de conexões (sql.DB.Stats)
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// standardMethods são os métodos HTTP aceitos como rótulo; os demais viram
+// OTHER para que o cliente não crie séries arbitrárias
+var standardMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// methodLabel limita o rótulo method aos métodos padrão
+func methodLabel(method string) string {
+	if standardMethods[method] {
+		return method
+	}
+	return "OTHER"
+}
+
+// TrackRequest incrementa as requisições em andamento e retorna a função
+// que registra o status e a duração ao final da requisição; métodos fora do
+// padrão são rotulados como OTHER
+func (m *Metrics) TrackRequest(method, route string) func(status int) {
+	start := time.Now()
+	method = methodLabel(method)
+	inFlight := m.httpInFlight.WithLabelValues(method, route)
+	inFlight.Inc()
+
+	return func(status int) {
+		inFlight.Dec()
+		code := strconv.Itoa(status)
+		m.httpRequests.WithLabelValues(method, route, code).Inc()
+		m.httpDuration.WithLabelValues(method, route, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// TrackRPC é o equivalente de TrackRequest para chamadas gRPC, rotuladas pelo
+// método completo e pelo código de status, ex: NotFound
+func (m *Metrics) TrackRPC(method string) func(code string) {
+	start := time.Now()
+	inFlight := m.grpcInFlight.WithLabelValues(method)
+	inFlight.Inc()
+
+	return func(code string) {
+		inFlight.Dec()
+		m.grpcRequests.WithLabelValues(method, code).Inc()
+		m.grpcDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveOperation registra o resultado e a duração de uma operação do serviço
+func (m *Metrics) ObserveOperation(operation, result string, duration time.Duration) {
+	m.serviceOperations.WithLabelValues(operation, result).Inc()
+	m.serviceDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// ObserveQuery implementa database.QueryObserver
+func (m *Metrics) ObserveQuery(operation string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	m.dbQueryDuration.WithLabelValues(operation, result).Observe(duration.Seconds())
+}
+
+// ObserveCache implementa cache.Observer
+func (m *Metrics) ObserveCache(name, result string) {
+	m.cacheRequests.WithLabelValues(name, result).Inc()
+}
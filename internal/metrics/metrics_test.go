@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// scrape devolve as métricas no formato texto, como o Prometheus as coleta
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	return string(body)
+}
+
+func TestTrackRequest_BoundsMethodLabel(t *testing.T) {
+	m := New()
+	for _, method := range []string{http.MethodGet, "PROPFIND", "XYZ123"} {
+		m.TrackRequest(method, "/api/personalities")(http.StatusOK)
+	}
+
+	body := scrape(t, m)
+	for _, expected := range []string{
+		`go_api_rest_http_requests_total{method="GET",route="/api/personalities",status="200"} 1`,
+		`go_api_rest_http_requests_total{method="OTHER",route="/api/personalities",status="200"} 2`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Esperava a série %q", expected)
+		}
+	}
+	for _, method := range []string{"PROPFIND", "XYZ123"} {
+		if strings.Contains(body, `method="`+method+`"`) {
+			t.Errorf("Método %s não deveria virar rótulo", method)
+		}
+	}
+}
+
+func TestRegisterDB_ExportsPoolStats(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormLogger.Discard})
+	if err != nil {
+		t.Fatalf("Erro ao abrir o sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Erro ao obter o sql.DB: %v", err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(3)
+
+	m := New()
+	if err := m.RegisterDB(sqlDB, "primary"); err != nil {
+		t.Fatalf("Erro ao registrar o pool: %v", err)
+	}
+	// O mesmo nome não pode ser registrado duas vezes
+	if err := m.RegisterDB(sqlDB, "primary"); err == nil {
+		t.Error("Esperava erro ao registrar o pool duplicado")
+	}
+
+	body := scrape(t, m)
+	for _, expected := range []string{
+		`go_sql_max_open_connections{db_name="primary"} 3`,
+		`go_sql_open_connections{db_name="primary"}`,
+		`go_sql_wait_count_total{db_name="primary"} 0`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Esperava a série %q", expected)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import "net/http"
+
+// LimitBody limita o corpo da requisição a maxBytes; leituras além do limite
+// falham com *http.MaxBytesError, respondido com 413 pelo decode dos handlers
+func LimitBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if maxBytes > 0 {
+				r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
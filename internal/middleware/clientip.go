@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"go-api-rest/pkg/logger"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TrustedProxies representa as redes cujos cabeçalhos X-Forwarded-For são confiáveis
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies converte uma lista de IPs ou CIDRs em TrustedProxies
+func ParseTrustedProxies(entries []string) TrustedProxies {
+	proxies := make(TrustedProxies, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			logger.Warn("Proxy confiável inválido ignorado", "entry", entry)
+			continue
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies
+}
+
+// Contains indica se o IP pertence a algum proxy confiável
+func (p TrustedProxies) Contains(ip net.IP) bool {
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP retorna o IP real do cliente. O X-Forwarded-For só é considerado
+// quando a conexão vem de um proxy confiável, sendo percorrido da direita
+// para a esquerda até o primeiro endereço que não pertence a um proxy.
+func (p TrustedProxies) ClientIP(r *http.Request) string {
+	return p.Resolve(remoteIP(r), forwardedFor(r))
+}
+
+// Resolve aplica a regra de ClientIP ao endereço da conexão e aos endereços
+// do X-Forwarded-For, em ordem
+func (p TrustedProxies) Resolve(remote string, hops []string) string {
+	ip := net.ParseIP(remote)
+	if ip == nil || !p.Contains(ip) {
+		return remote
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(hops[i])
+		if hop == nil {
+			break
+		}
+		if !p.Contains(hop) || i == 0 {
+			return hop.String()
+		}
+	}
+	return remote
+}
+
+// remoteIP extrai o IP de r.RemoteAddr
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// forwardedFor retorna os endereços de todos os cabeçalhos X-Forwarded-For
+func forwardedFor(r *http.Request) []string {
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(header, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	return hops
+}
@@ -0,0 +1,323 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"go-api-rest/internal/config"
+	"go-api-rest/pkg/problem"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codificações suportadas, em ordem de preferência do servidor para q-values iguais
+const (
+	EncodingBrotli = "br"
+	EncodingZstd   = "zstd"
+	EncodingGzip   = "gzip"
+)
+
+// compressor é o formato comum aos encoders reaproveitados via sync.Pool
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+var encoderPools = map[string]*sync.Pool{
+	EncodingBrotli: {New: func() interface{} {
+		return brotli.NewWriterLevel(nil, brotli.DefaultCompression)
+	}},
+	EncodingZstd: {New: func() interface{} {
+		// Concorrência 1 evita goroutines extras por resposta
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return enc
+	}},
+	EncodingGzip: {New: func() interface{} {
+		return gzip.NewWriter(nil)
+	}},
+}
+
+// incompressibleTypes já são comprimidos e não ganham nada com nova compressão
+var incompressibleTypes = []string{
+	"image/", "video/", "audio/", "font/woff",
+	"application/zip", "application/gzip", "application/x-gzip",
+	"application/zstd", "application/x-brotli", "application/pdf",
+	"application/octet-stream",
+}
+
+// Compression comprime respostas conforme o Accept-Encoding do cliente
+type Compression struct {
+	encodings []string
+	minSize   int
+}
+
+// NewCompression cria o middleware com as codificações e o tamanho mínimo configurados
+func NewCompression(cfg config.CompressionConfig) *Compression {
+	var encodings []string
+	for _, enc := range cfg.Encodings {
+		enc = strings.ToLower(strings.TrimSpace(enc))
+		if _, ok := encoderPools[enc]; ok {
+			encodings = append(encodings, enc)
+		}
+	}
+	return &Compression{encodings: encodings, minSize: cfg.MinSize}
+}
+
+// Middleware aplica a compressão às respostas
+func (c *Compression) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), c.encodings)
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Sem defer: em caso de panic o Recovery ainda precisa escrever o 500
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: c.minSize, status: http.StatusOK}
+		next.ServeHTTP(cw, r)
+		cw.close()
+	})
+}
+
+// negotiateEncoding escolhe a codificação com maior q-value entre as suportadas;
+// empates seguem a ordem de supported. Retorna "" para enviar sem compressão.
+func negotiateEncoding(header string, supported []string) string {
+	if header == "" || len(supported) == 0 {
+		return ""
+	}
+
+	weights := make(map[string]float64)
+	wildcard := -1.0
+	for _, part := range strings.Split(header, ",") {
+		name, q := parseQValue(part)
+		if name == "" {
+			continue
+		}
+		if name == "*" {
+			wildcard = q
+			continue
+		}
+		weights[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, enc := range supported {
+		q, ok := weights[enc]
+		if !ok {
+			if enc == EncodingGzip {
+				// x-gzip é um alias de gzip (RFC 9110, seção 8.4.1.3)
+				q, ok = weights["x-gzip"]
+			}
+			if !ok && wildcard >= 0 {
+				q, ok = wildcard, true
+			}
+		}
+		if ok && q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+// parseQValue separa o nome e o q-value de um item como "gzip;q=0.8"
+func parseQValue(part string) (string, float64) {
+	name, params, _ := strings.Cut(part, ";")
+	name = strings.ToLower(strings.TrimSpace(name))
+	q := 1.0
+	for _, param := range strings.Split(params, ";") {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || strings.ToLower(strings.TrimSpace(key)) != "q" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			return name, 0
+		}
+		q = parsed
+	}
+	return name, q
+}
+
+// compressWriter acumula o início da resposta até saber se vale comprimir
+type compressWriter struct {
+	http.ResponseWriter
+	encoding    string
+	minSize     int
+	status      int
+	wroteHeader bool // WriteHeader foi chamado pelo handler
+	decided     bool // cabeçalhos já enviados ao cliente
+	buf         bytes.Buffer
+	enc         compressor
+}
+
+func (w *compressWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = statusCode
+
+	// Respostas informativas não encerram a resposta
+	if statusCode >= 100 && statusCode < 200 {
+		w.wroteHeader = false
+		w.ResponseWriter.WriteHeader(statusCode)
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.decided {
+		w.buf.Write(b)
+		if w.buf.Len() < w.minSize {
+			return len(b), nil
+		}
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	if w.enc != nil {
+		return w.enc.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush envia o que já foi escrito; em streaming a compressão começa no primeiro flush
+func (w *compressWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.decided {
+		w.decide(true)
+	}
+	if w.enc != nil {
+		w.enc.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap permite que http.ResponseController acesse o writer original
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// decide envia os cabeçalhos, escolhendo entre comprimir e repassar, e
+// escreve o conteúdo acumulado
+func (w *compressWriter) decide(compress bool) error {
+	w.decided = true
+	if compress && w.shouldCompress() {
+		h := w.Header()
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+		// A representação comprimida difere byte a byte da original
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+		w.enc = encoderPools[w.encoding].Get().(compressor)
+		w.enc.Reset(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if w.enc != nil {
+		_, err = w.enc.Write(w.buf.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+	w.buf.Reset()
+	return err
+}
+
+// shouldCompress verifica status, codificação existente e tipo do conteúdo
+func (w *compressWriter) shouldCompress() bool {
+	if w.status < http.StatusOK || w.status == http.StatusNoContent ||
+		w.status == http.StatusNotModified || w.status == http.StatusPartialContent {
+		return false
+	}
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	for _, prefix := range incompressibleTypes {
+		if strings.HasPrefix(mediaType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// close finaliza a resposta: o que não chegou ao tamanho mínimo segue sem compressão
+func (w *compressWriter) close() {
+	if !w.wroteHeader {
+		// O handler não escreveu nada; o servidor envia o status padrão
+		return
+	}
+	if !w.decided {
+		w.decide(false)
+	}
+	if w.enc != nil {
+		w.enc.Close()
+		w.enc.Reset(nil)
+		encoderPools[w.encoding].Put(w.enc)
+		w.enc = nil
+	}
+}
+
+// supportedRequestEncodings lista as codificações aceitas no corpo das requisições
+var supportedRequestEncodings = []string{EncodingGzip}
+
+// DecompressRequest descomprime corpos enviados com Content-Encoding: gzip,
+// limitando o tamanho descomprimido a maxBytes. Outras codificações recebem 415.
+func DecompressRequest(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+			switch encoding {
+			case "", "identity":
+				next.ServeHTTP(w, r)
+				return
+			case EncodingGzip, "x-gzip":
+			default:
+				w.Header().Set("Accept-Encoding", strings.Join(supportedRequestEncodings, ", "))
+				problem.Write(w, r, problem.UnsupportedEncoding.Localized("request.unsupported_encoding", encoding))
+				return
+			}
+
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				problem.Write(w, r, problem.MalformedBody.Localized("request.invalid_gzip"))
+				return
+			}
+			defer gz.Close()
+
+			r.Body = http.MaxBytesReader(w, readCloser{Reader: gz, Closer: r.Body}, maxBytes)
+			r.Header.Del("Content-Encoding")
+			r.Header.Del("Content-Length")
+			r.ContentLength = -1
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// readCloser combina um leitor sobre o corpo com o Close do corpo original
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,222 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"go-api-rest/internal/config"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+func newTestCompression() *Compression {
+	return NewCompression(config.CompressionConfig{
+		Enabled:   true,
+		Encodings: []string{"br", "zstd", "gzip"},
+		MinSize:   64,
+	})
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	supported := []string{EncodingBrotli, EncodingZstd, EncodingGzip}
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"gzip, br", "br"},
+		{"gzip;q=1.0, br;q=0.5", "gzip"},
+		{"br;q=0, gzip", "gzip"},
+		{"zstd, gzip;q=0.9", "zstd"},
+		{"*", "br"},
+		{"*;q=0.5, br;q=0", "zstd"},
+		{"x-gzip", "gzip"},
+		{"identity", ""},
+		{"deflate", ""},
+		{"gzip;q=abc", ""},
+	}
+
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.header, supported); got != tt.want {
+			t.Errorf("Accept-Encoding %q: esperava %q, obteve %q", tt.header, tt.want, got)
+		}
+	}
+}
+
+func TestCompression_CompressesLargeResponses(t *testing.T) {
+	body := strings.Repeat(`{"name":"Ada Lovelace"}`, 20)
+	readers := map[string]func(io.Reader) (io.Reader, error){
+		"gzip": func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		"br":   func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil },
+		"zstd": func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) },
+	}
+
+	for encoding, newReader := range readers {
+		t.Run(encoding, func(t *testing.T) {
+			handler := newTestCompression().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("ETag", `"abc"`)
+				io.WriteString(w, body)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Encoding", encoding)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Encoding"); got != encoding {
+				t.Fatalf("Esperava Content-Encoding %q, obteve %q", encoding, got)
+			}
+			if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Esperava Vary Accept-Encoding, obteve %q", got)
+			}
+			if got := rec.Header().Get("ETag"); got != `W/"abc"` {
+				t.Errorf("Esperava ETag fraco, obteve %q", got)
+			}
+
+			reader, err := newReader(rec.Body)
+			if err != nil {
+				t.Fatalf("Erro ao abrir o corpo comprimido: %v", err)
+			}
+			decoded, err := io.ReadAll(reader)
+			if err != nil || string(decoded) != body {
+				t.Errorf("Corpo descomprimido inesperado: %q (erro: %v)", decoded, err)
+			}
+		})
+	}
+}
+
+func TestCompression_SkipsSmallAndIncompressibleResponses(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"abaixo do mínimo", "application/json", `{"ok":true}`, http.StatusOK},
+		{"imagem", "image/png", strings.Repeat("x", 200), http.StatusOK},
+		{"sem conteúdo", "application/json", "", http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestCompression().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Encoding"); got != "" {
+				t.Errorf("Esperava resposta sem compressão, obteve %q", got)
+			}
+			if rec.Code != tt.status || rec.Body.String() != tt.body {
+				t.Errorf("Esperava %d %q, obteve %d %q", tt.status, tt.body, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompression_FlushStreamsCompressedData(t *testing.T) {
+	flushed := make(chan struct{})
+	proceed := make(chan struct{})
+	handler := newTestCompression().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, "data: primeiro\n\n")
+		w.(http.Flusher).Flush()
+		close(flushed)
+		<-proceed
+		io.WriteString(w, "data: segundo\n\n")
+	}))
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Erro na requisição: %v", err)
+	}
+	defer resp.Body.Close()
+	<-flushed
+
+	if got := resp.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Esperava Content-Encoding gzip, obteve %q", got)
+	}
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatalf("Erro ao abrir o corpo comprimido: %v", err)
+	}
+
+	// O primeiro evento chega antes do handler terminar
+	first := make([]byte, len("data: primeiro\n\n"))
+	if _, err := io.ReadFull(gz, first); err != nil || string(first) != "data: primeiro\n\n" {
+		t.Fatalf("Esperava o primeiro evento, obteve %q (erro: %v)", first, err)
+	}
+
+	close(proceed)
+	rest, _ := io.ReadAll(gz)
+	if string(rest) != "data: segundo\n\n" {
+		t.Errorf("Esperava o segundo evento, obteve %q", rest)
+	}
+}
+
+func TestDecompressRequest(t *testing.T) {
+	echo := DecompressRequest(1024)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.Write(body)
+	}))
+
+	compress := func(s string) *bytes.Buffer {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		io.WriteString(gz, s)
+		gz.Close()
+		return &buf
+	}
+
+	t.Run("gzip", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", compress(`{"name":"Ada"}`))
+		req.Header.Set("Content-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		echo.ServeHTTP(rec, req)
+		if rec.Body.String() != `{"name":"Ada"}` {
+			t.Errorf("Corpo descomprimido inesperado: %q", rec.Body.String())
+		}
+	})
+
+	t.Run("limite descomprimido", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", compress(strings.Repeat("a", 4096)))
+		req.Header.Set("Content-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		echo.ServeHTTP(rec, req)
+		if rec.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("Esperava leitura interrompida pelo limite, obteve %d", rec.Code)
+		}
+	})
+
+	t.Run("codificação não suportada", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("x"))
+		req.Header.Set("Content-Encoding", "br")
+		rec := httptest.NewRecorder()
+		echo.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnsupportedMediaType || rec.Header().Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Esperava 415 com Accept-Encoding gzip, obteve %d %q", rec.Code, rec.Header().Get("Accept-Encoding"))
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"bytes"
+	"go-api-rest/internal/config"
+	"go-api-rest/pkg/logger"
+	"go-api-rest/pkg/openapi"
+	"go-api-rest/pkg/problem"
+	"io"
+	"net/http"
+)
+
+// Modos da validação de contrato
+const (
+	ContractOff    = "off"
+	ContractLog    = "log"
+	ContractReject = "reject"
+)
+
+// ValidateContract confere requisições e respostas contra o documento OpenAPI.
+// No modo log as divergências só são registradas; no modo reject requisições
+// fora do contrato recebem 400 antes do handler e respostas fora do contrato
+// são trocadas por 500, ambos com as divergências em errors. Rotas que o
+// documento não descreve passam sem validação.
+func ValidateContract(v *openapi.Validator, cfg config.ContractConfig, maxBodyBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if cfg.Mode != ContractLog && cfg.Mode != ContractReject {
+			return next
+		}
+		reject := cfg.Mode == ContractReject
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			match, ok := v.Match(r.Method, r.URL.Path)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if violations := v.ValidateRequest(match, r, peekBody(r, maxBodyBytes)); len(violations) > 0 {
+				logViolations(r, match, "Requisição fora do contrato OpenAPI", violations)
+				if reject {
+					problem.Write(w, r, contractProblem(problem.ValidationFailed, "request.contract_violation", violations))
+					return
+				}
+			}
+
+			if !cfg.Responses {
+				next.ServeHTTP(w, r)
+				return
+			}
+			cw := &contractWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(cw, r)
+			if cw.streaming {
+				return
+			}
+
+			violations := v.ValidateResponse(match, r.Method, cw.status, w.Header(), cw.body.Bytes())
+			if len(violations) > 0 {
+				logViolations(r, match, "Resposta fora do contrato OpenAPI", violations, "status", cw.status)
+				if reject {
+					for _, name := range []string{"Content-Length", "ETag", "Last-Modified"} {
+						w.Header().Del(name)
+					}
+					w.Header().Set("Cache-Control", "no-store")
+					problem.Write(w, r, contractProblem(problem.Internal, "response.contract_violation", violations))
+					return
+				}
+			}
+			cw.flush()
+		})
+	}
+}
+
+// peekBody lê o corpo para validação e o devolve intacto ao handler. Corpos
+// acima de maxBytes retornam nil, sem inspeção, e seguem para o limite da rota.
+func peekBody(r *http.Request, maxBytes int64) []byte {
+	if r.Body == nil || r.Body == http.NoBody {
+		return []byte{}
+	}
+	limit := maxBytes
+	if limit <= 0 {
+		limit = 1 << 20
+	}
+	buf, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(buf), r.Body), Closer: r.Body}
+	if err != nil || int64(len(buf)) > limit {
+		return nil
+	}
+	return buf
+}
+
+func contractProblem(typ problem.Type, key string, violations []openapi.Violation) *problem.Problem {
+	p := typ.Localized(key)
+	for _, violation := range violations {
+		field := violation.Field
+		if field == "" {
+			field = violation.In
+		}
+		p.Errors = append(p.Errors, problem.FieldError{Field: field, Message: violation.Message})
+	}
+	return p
+}
+
+func logViolations(r *http.Request, match *openapi.Match, message string, violations []openapi.Violation, args ...any) {
+	details := make([]string, len(violations))
+	for i, violation := range violations {
+		details[i] = violation.String()
+	}
+	args = append(args, "method", r.Method, "route", match.Path, "operation", match.Operation.OperationID, "violations", details)
+	logger.WarnContext(r.Context(), message, args...)
+}
+
+// contractWriter retém a resposta até a validação. Um Flush indica resposta
+// em streaming: o que foi retido é enviado e o restante segue sem validação.
+type contractWriter struct {
+	http.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+	streaming   bool
+}
+
+func (w *contractWriter) WriteHeader(statusCode int) {
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+	if !w.wroteHeader {
+		w.status = statusCode
+		w.wroteHeader = true
+	}
+}
+
+func (w *contractWriter) Write(b []byte) (int, error) {
+	if w.streaming {
+		return w.ResponseWriter.Write(b)
+	}
+	w.wroteHeader = true
+	return w.body.Write(b)
+}
+
+// Flush encerra a retenção e passa a repassar a resposta diretamente
+func (w *contractWriter) Flush() {
+	if !w.streaming {
+		w.flush()
+		w.streaming = true
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// flush envia o status e o corpo retidos
+func (w *contractWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.body.Len() > 0 {
+		w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
+
+// Unwrap permite que http.ResponseController acesse o writer original
+func (w *contractWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"encoding/json"
+	"go-api-rest/internal/config"
+	"go-api-rest/pkg/openapi"
+	"go-api-rest/pkg/problem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestContractHandler(mode string, handler http.HandlerFunc) http.Handler {
+	g := openapi.New(openapi.Info{Title: "teste", Version: "1"})
+	body := g.SchemaNamed("Item", struct {
+		Name string `json:"name" validate:"required,min=3"`
+	}{})
+	content := map[string]*openapi.MediaType{"application/json": {Schema: body}}
+	g.Add(http.MethodPost, "/items", &openapi.Operation{
+		OperationID: "createItem",
+		RequestBody: &openapi.RequestBody{Required: true, Content: content},
+		Responses:   map[string]*openapi.Response{"201": {Description: "criado", Content: content}},
+	})
+	validate := ValidateContract(openapi.NewValidator(g.Document()), config.ContractConfig{Mode: mode, Responses: true}, 1024)
+	return validate(handler)
+}
+
+// echo devolve o corpo recebido, como um handler que segue o contrato
+func echo(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", `"1"`)
+	w.WriteHeader(http.StatusCreated)
+	w.Write(body)
+}
+
+func TestValidateContract_Reject(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		handler http.HandlerFunc
+		status  int
+		code    string
+		fields  []string
+	}{
+		{"dentro do contrato", `{"name":"Ada"}`, echo, http.StatusCreated, "", nil},
+		{"requisição fora do contrato", `{"name":"A","age":3}`, echo, http.StatusBadRequest, "VALIDATION_FAILED", []string{"age", "name"}},
+		{"resposta fora do contrato", `{"name":"Ada"}`, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":1}`))
+		}, http.StatusInternalServerError, "INTERNAL_ERROR", []string{"name", "id"}},
+		{"status não documentado", `{"name":"Ada"}`, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}, http.StatusInternalServerError, "INTERNAL_ERROR", []string{"response"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestContractHandler(ContractReject, tt.handler)
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Esperava status %d, obteve %d: %s", tt.status, rec.Code, rec.Body)
+			}
+			if tt.code == "" {
+				if rec.Body.String() != tt.body || rec.Header().Get("ETag") == "" {
+					t.Errorf("Resposta alterada: %q %v", rec.Body, rec.Header())
+				}
+				return
+			}
+			var p problem.Problem
+			if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+				t.Fatalf("Corpo não é um problem+json: %v", err)
+			}
+			if p.Code != tt.code || len(p.Errors) != len(tt.fields) {
+				t.Fatalf("Problema inesperado: %+v", p)
+			}
+			for i, field := range tt.fields {
+				if p.Errors[i].Field != field {
+					t.Errorf("Erro %d: esperava campo %q, obteve %+v", i, field, p.Errors[i])
+				}
+			}
+			if tt.status == http.StatusInternalServerError && (rec.Header().Get("ETag") != "" || rec.Header().Get("Cache-Control") != "no-store") {
+				t.Errorf("Cabeçalhos da resposta descartada permaneceram: %v", rec.Header())
+			}
+		})
+	}
+}
+
+func TestValidateContract_LogOnly(t *testing.T) {
+	h := newTestContractHandler(ContractLog, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"name":"A"}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("No modo log a resposta deve seguir inalterada, obteve %d", rec.Code)
+	}
+}
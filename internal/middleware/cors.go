@@ -0,0 +1,237 @@
+package middleware
+
+import (
+	"errors"
+	"go-api-rest/internal/config"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/response"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// CORS aplica a política de Cross-Origin Resource Sharing configurada
+type CORS struct {
+	allowAll         bool
+	origins          []string
+	wildcards        []originPattern
+	methods          []string
+	headers          []string
+	allowAnyHeader   bool
+	exposedHeaders   string
+	allowCredentials bool
+	maxAge           int
+}
+
+// originPattern representa uma origem com subdomínio curinga, ex: https://*.example.com
+type originPattern struct {
+	scheme string
+	suffix string
+}
+
+// ErrWildcardCredentials recusa a origem "*" com credenciais: a política
+// refletiria qualquer origem e entregaria respostas autenticadas a qualquer site
+var ErrWildcardCredentials = errors.New("CORS_ALLOWED_ORIGINS=* não pode ser usado com CORS_ALLOW_CREDENTIALS")
+
+// NewCORS cria a política de CORS a partir da configuração
+func NewCORS(cfg config.CORSConfig) (*CORS, error) {
+	c := &CORS{
+		exposedHeaders:   strings.Join(cfg.ExposedHeaders, ", "),
+		allowCredentials: cfg.AllowCredentials,
+		maxAge:           cfg.MaxAge,
+	}
+
+	for _, origin := range cfg.AllowedOrigins {
+		origin = strings.ToLower(strings.TrimSuffix(origin, "/"))
+		switch {
+		case origin == "*":
+			c.allowAll = true
+		case strings.Contains(origin, "://*."):
+			scheme, host, _ := strings.Cut(origin, "://*")
+			c.wildcards = append(c.wildcards, originPattern{scheme: scheme, suffix: host})
+		default:
+			c.origins = append(c.origins, origin)
+		}
+	}
+
+	for _, method := range cfg.AllowedMethods {
+		c.methods = append(c.methods, strings.ToUpper(method))
+	}
+	for _, header := range cfg.AllowedHeaders {
+		if header == "*" {
+			c.allowAnyHeader = true
+			continue
+		}
+		c.headers = append(c.headers, http.CanonicalHeaderKey(header))
+	}
+
+	if c.allowAll && c.allowCredentials {
+		return nil, ErrWildcardCredentials
+	}
+	return c, nil
+}
+
+// Middleware adiciona os headers de CORS às respostas de origens permitidas
+func (c *CORS) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.writeOriginHeaders(w, r)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RegisterPreflight registra no roteador o tratamento de requisições OPTIONS.
+// Deve ser chamado após todas as rotas. Um MatcherFunc é usado no lugar de
+// Methods("OPTIONS") para que a rota não provoque 405 nas demais consultas.
+func (c *CORS) RegisterPreflight(router *mux.Router) {
+	router.MatcherFunc(func(r *http.Request, _ *mux.RouteMatch) bool {
+		return r.Method == http.MethodOptions
+	}).Handler(c.preflight(router))
+}
+
+// preflight responde requisições OPTIONS. A resposta só é positiva quando
+// existe uma rota registrada para o caminho e o método solicitados.
+func (c *CORS) preflight(router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedMethod := r.Header.Get("Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+		// OPTIONS sem cabeçalhos de preflight apenas informa os métodos da rota
+		if r.Header.Get("Origin") == "" || requestedMethod == "" {
+			allowed := c.allowedMethodsFor(router, r)
+			if len(allowed) == 0 {
+				problem.Write(w, r, problem.RouteNotFound.New(""))
+				return
+			}
+			w.Header().Set("Allow", strings.Join(append(allowed, http.MethodOptions), ", "))
+			response.NoContent(w)
+			return
+		}
+
+		if !c.originAllowed(r.Header.Get("Origin")) {
+			problem.Write(w, r, problem.OriginNotAllowed.New(""))
+			return
+		}
+
+		requestedMethod = strings.ToUpper(requestedMethod)
+		if !slices.Contains(c.methods, requestedMethod) {
+			problem.Write(w, r, problem.MethodNotAllowed.New(""))
+			return
+		}
+
+		allowed := c.allowedMethodsFor(router, r)
+		if len(allowed) == 0 {
+			problem.Write(w, r, problem.RouteNotFound.New(""))
+			return
+		}
+		if !slices.Contains(allowed, requestedMethod) {
+			problem.Write(w, r, problem.MethodNotAllowed.New(""))
+			return
+		}
+
+		requestedHeaders, ok := c.headersAllowed(r.Header.Get("Access-Control-Request-Headers"))
+		if !ok {
+			problem.Write(w, r, problem.HeadersNotAllowed.New(""))
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(allowed, ", "))
+		if len(requestedHeaders) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(requestedHeaders, ", "))
+		}
+		if c.maxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(c.maxAge))
+		}
+		response.NoContent(w)
+	})
+}
+
+// writeOriginHeaders define os headers de origem, credenciais e exposição
+func (c *CORS) writeOriginHeaders(w http.ResponseWriter, r *http.Request) {
+	// A resposta varia conforme a origem, exceto quando qualquer origem recebe "*"
+	if !c.allowAll {
+		w.Header().Add("Vary", "Origin")
+	}
+
+	origin := r.Header.Get("Origin")
+	if origin == "" || !c.originAllowed(origin) {
+		return
+	}
+
+	if c.allowAll {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+	if c.allowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+	if c.exposedHeaders != "" {
+		w.Header().Set("Access-Control-Expose-Headers", c.exposedHeaders)
+	}
+}
+
+// originAllowed verifica se a origem está na lista ou casa com um curinga
+func (c *CORS) originAllowed(origin string) bool {
+	if c.allowAll {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+	if slices.Contains(c.origins, origin) {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	for _, pattern := range c.wildcards {
+		if u.Scheme == pattern.scheme && strings.HasSuffix(u.Host, pattern.suffix) && len(u.Host) > len(pattern.suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// headersAllowed valida os cabeçalhos solicitados no preflight
+func (c *CORS) headersAllowed(requested string) ([]string, bool) {
+	var headers []string
+	for _, header := range strings.Split(requested, ",") {
+		header = http.CanonicalHeaderKey(strings.TrimSpace(header))
+		if header == "" {
+			continue
+		}
+		if !c.allowAnyHeader && !slices.Contains(c.headers, header) {
+			return nil, false
+		}
+		headers = append(headers, header)
+	}
+	return headers, true
+}
+
+// allowedMethodsFor lista os métodos configurados que possuem rota para o
+// caminho. Com NotFoundHandler e MethodNotAllowedHandler definidos, Match
+// também aceita caminhos e métodos sem rota, indicando o caso em MatchErr.
+func (c *CORS) allowedMethodsFor(router *mux.Router, r *http.Request) []string {
+	var allowed []string
+	for _, method := range c.methods {
+		var match mux.RouteMatch
+		if router.Match(withMethod(r, method), &match) && match.MatchErr == nil {
+			allowed = append(allowed, method)
+		}
+	}
+	return allowed
+}
+
+// withMethod retorna uma cópia rasa da requisição com outro método
+func withMethod(r *http.Request, method string) *http.Request {
+	clone := new(http.Request)
+	*clone = *r
+	clone.Method = method
+	return clone
+}
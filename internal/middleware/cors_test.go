@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"errors"
+	"go-api-rest/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func newTestCORSRouter(t *testing.T, cfg config.CORSConfig) *mux.Router {
+	t.Helper()
+	cors, err := NewCORS(cfg)
+	if err != nil {
+		t.Fatalf("Erro ao criar a política de CORS: %v", err)
+	}
+	r := mux.NewRouter()
+	r.Use(cors.Middleware)
+	api := r.PathPrefix("/api/personalities").Subrouter()
+	api.HandleFunc("", func(w http.ResponseWriter, r *http.Request) {}).Methods("GET")
+	api.HandleFunc("/{id:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {}).Methods("PUT")
+	cors.RegisterPreflight(r)
+	return r
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	router := newTestCORSRouter(t, config.CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.example.org"},
+		AllowedMethods:   []string{"GET", "PUT", "PATCH"},
+		AllowedHeaders:   []string{"Content-Type"},
+		AllowCredentials: true,
+		MaxAge:           300,
+	})
+
+	tests := []struct {
+		name     string
+		path     string
+		origin   string
+		method   string
+		expected int
+	}{
+		{"rota registrada", "/api/personalities/1", "https://app.example.com", "PUT", http.StatusNoContent},
+		{"subdomínio curinga", "/api/personalities", "https://admin.example.org", "GET", http.StatusNoContent},
+		{"domínio raiz não casa com curinga", "/api/personalities", "https://example.org", "GET", http.StatusForbidden},
+		{"origem não permitida", "/api/personalities", "https://evil.com", "GET", http.StatusForbidden},
+		{"rota inexistente", "/api/unknown", "https://app.example.com", "GET", http.StatusNotFound},
+		{"método sem rota", "/api/personalities", "https://app.example.com", "PATCH", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", tt.method)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Fatalf("Esperava status %d, mas obteve %d", tt.expected, rec.Code)
+			}
+			if tt.expected == http.StatusNoContent {
+				if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.origin {
+					t.Errorf("Esperava Access-Control-Allow-Origin %s, mas obteve %s", tt.origin, got)
+				}
+				if rec.Header().Get("Access-Control-Allow-Credentials") != "true" {
+					t.Error("Esperava Access-Control-Allow-Credentials true")
+				}
+				if rec.Header().Get("Access-Control-Max-Age") != "300" {
+					t.Errorf("Esperava Access-Control-Max-Age 300, mas obteve %s", rec.Header().Get("Access-Control-Max-Age"))
+				}
+			}
+		})
+	}
+}
+
+func TestCORS_ActualRequestHeaders(t *testing.T) {
+	router := newTestCORSRouter(t, config.CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET"},
+		ExposedHeaders: []string{"ETag", "X-Request-ID"},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/personalities", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" {
+		t.Errorf("Origem permitida não foi ecoada: %v", rec.Header())
+	}
+	if rec.Header().Get("Access-Control-Expose-Headers") != "ETag, X-Request-ID" {
+		t.Errorf("Headers expostos inesperados: %s", rec.Header().Get("Access-Control-Expose-Headers"))
+	}
+	if rec.Header().Get("Vary") != "Origin" {
+		t.Errorf("Esperava Vary: Origin, mas obteve %q", rec.Header().Get("Vary"))
+	}
+
+	req.Header.Set("Origin", "https://other.com")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Error("Origem não permitida não deveria receber Access-Control-Allow-Origin")
+	}
+}
+
+func TestNewCORS_RejectsWildcardWithCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.CORSConfig
+		wantErr error
+	}{
+		{"curinga com credenciais", config.CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}, ErrWildcardCredentials},
+		{"curinga sem credenciais", config.CORSConfig{AllowedOrigins: []string{"*"}}, nil},
+		{"origens explícitas com credenciais", config.CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCORS(tt.cfg); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Esperava erro %v, obteve %v", tt.wantErr, err)
+			}
+		})
+	}
+}
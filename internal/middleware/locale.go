@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"go-api-rest/pkg/i18n"
+	"go-api-rest/pkg/reqctx"
+	"net/http"
+)
+
+// Locale negocia o idioma da resposta pelo Accept-Language, anunciando-o em
+// Content-Language e guardando-o no contexto para as mensagens traduzidas
+func Locale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := i18n.Negotiate(r.Header.Get("Accept-Language"))
+
+		w.Header().Add("Vary", "Accept-Language")
+		w.Header().Set("Content-Language", locale)
+		next.ServeHTTP(w, r.WithContext(reqctx.WithLocale(r.Context(), locale)))
+	})
+}
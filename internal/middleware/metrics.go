@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"go-api-rest/internal/metrics"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Metrics registra as métricas RED de cada requisição rotulada pelo template da rota
+func Metrics(m *metrics.Metrics) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			done := m.TrackRequest(r.Method, routeTemplate(r))
+			rw := newResponseWriter(w)
+
+			defer func() { done(rw.status) }()
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
@@ -1,62 +1,105 @@
 package middleware
 
 import (
+	"crypto/subtle"
+	"go-api-rest/pkg/codec"
 	"go-api-rest/pkg/logger"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/response"
 	"net/http"
+	"runtime/debug"
+	"strings"
 	"time"
+
+	"github.com/gorilla/mux"
 )
 
-// ContentTypeJSON define o Content-Type como application/json
-func ContentTypeJSON(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		next.ServeHTTP(w, r)
-	})
+// NegotiateContent recusa com 406 requisições cujo Accept não casa com nenhum
+// formato capaz de representar a resposta da rota, antes que o handler execute
+// efeitos colaterais. sample devolve um valor vazio do tipo respondido, já que
+// nem todo formato representa todo valor (ex: CSV só representa coleções);
+// com sample nil basta que algum formato registrado case com o Accept. O
+// formato final é escolhido por response.Write conforme o valor da resposta.
+func NegotiateContent(reg *codec.Registry, sample func(r *http.Request) interface{}) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accept := r.Header.Get("Accept")
+			acceptable := reg.Acceptable(accept)
+			if acceptable && sample != nil {
+				_, err := reg.Negotiate(accept, sample(r))
+				acceptable = err == nil
+			}
+			if !acceptable {
+				w.Header().Add("Vary", "Accept")
+				response.NotAcceptable(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
-// Logging registra informações sobre cada requisição
-func Logging(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
+// AccessLog registra uma linha estruturada por requisição com método, rota,
+// status, bytes, latência, IP do cliente e user agent
+func AccessLog(proxies TrustedProxies) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rw := newResponseWriter(w)
 
-		// Log da requisição
-		logger.Infof("Iniciando %s %s", r.Method, r.URL.Path)
+			next.ServeHTTP(rw, r)
 
-		// Processar requisição
-		next.ServeHTTP(w, r)
+			log := logger.InfoContext
+			if rw.status >= http.StatusInternalServerError {
+				log = logger.ErrorContext
+			}
+			log(r.Context(), "http_request",
+				"method", r.Method,
+				"route", routeTemplate(r),
+				"path", r.URL.Path,
+				"status", rw.status,
+				"bytes", rw.bytes,
+				"latency", time.Since(start),
+				"client_ip", proxies.ClientIP(r),
+				"user_agent", r.UserAgent(),
+			)
+		})
+	}
+}
 
-		// Log da duração
-		duration := time.Since(start)
-		logger.Infof("Completado %s %s em %v", r.Method, r.URL.Path, duration)
-	})
+// routeTemplate retorna o template da rota do mux ou "unmatched" quando não houve casamento
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return "unmatched"
 }
 
-// Recovery recupera de panics e retorna um erro 500
+// Recovery recupera de panics e responde 500 como problem+json, como os demais erros
 func Recovery(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				logger.Errorf("Panic recuperado: %v", err)
-				http.Error(w, "Erro interno do servidor", http.StatusInternalServerError)
+				logger.ErrorContext(r.Context(), "Panic recuperado", "error", err, "stack", string(debug.Stack()))
+				problem.Write(w, r, problem.Internal.New(""))
 			}
 		}()
 		next.ServeHTTP(w, r)
 	})
 }
 
-// CORS configura os headers CORS
-func CORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-
-		// Responder a preflight requests
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
-
-		next.ServeHTTP(w, r)
-	})
+// RequireToken protege rotas administrativas exigindo "Authorization: Bearer <token>"
+func RequireToken(token string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				problem.Write(w, r, problem.Unauthorized.Localized("request.invalid_token"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
 }
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"encoding/json"
+	"go-api-rest/pkg/problem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecovery_WritesProblem(t *testing.T) {
+	handler := Recovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("falha inesperada")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/personalities", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Esperava status 500, mas obteve %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != problem.ContentType {
+		t.Errorf("Esperava Content-Type %s, obteve %q", problem.ContentType, got)
+	}
+	var body problem.Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Code != problem.Internal.Code {
+		t.Errorf("Esperava o problema %s, obteve %s (%v)", problem.Internal.Code, rec.Body.String(), err)
+	}
+}
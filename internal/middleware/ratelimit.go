@@ -0,0 +1,295 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go-api-rest/internal/config"
+	"go-api-rest/pkg/logger"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/reqctx"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimit define a capacidade de um token bucket
+type RateLimit struct {
+	Rate  float64 // tokens repostos por segundo
+	Burst int     // capacidade máxima do bucket
+}
+
+// RateLimitResult é o resultado de uma tentativa de consumir um token
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration // tempo até o próximo token, quando bloqueado
+	ResetAfter time.Duration // tempo até o bucket ficar cheio novamente
+}
+
+// RateLimitStore armazena o estado dos buckets de cada cliente
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+}
+
+// NewRateLimitStore cria o store configurado ("memory" ou "redis")
+func NewRateLimitStore(cfg config.RateLimitConfig) (RateLimitStore, error) {
+	switch cfg.Store {
+	case "", "memory":
+		return NewMemoryRateLimitStore(), nil
+	case "redis":
+		opts, err := redis.ParseURL(cfg.RedisURL)
+		if err != nil {
+			return nil, fmt.Errorf("URL do Redis inválida: %w", err)
+		}
+		return NewRedisRateLimitStore(redis.NewClient(opts), "ratelimit:"), nil
+	default:
+		return nil, fmt.Errorf("store de rate limit desconhecido: %s", cfg.Store)
+	}
+}
+
+// RateLimiter limita requisições por cliente usando token buckets
+type RateLimiter struct {
+	store        RateLimitStore
+	read         RateLimit
+	write        RateLimit
+	apiKeyHeader string
+	apiKeys      map[string]bool // digests das API keys configuradas
+	proxies      TrustedProxies
+}
+
+// NewRateLimiter cria um novo RateLimiter com limites separados para leitura e escrita
+func NewRateLimiter(cfg config.RateLimitConfig, store RateLimitStore, proxies TrustedProxies) *RateLimiter {
+	apiKeys := make(map[string]bool, len(cfg.APIKeys))
+	for _, key := range cfg.APIKeys {
+		apiKeys[apiKeyDigest(key)] = true
+	}
+	return &RateLimiter{
+		store:        store,
+		read:         RateLimit{Rate: cfg.ReadRate, Burst: cfg.ReadBurst},
+		write:        RateLimit{Rate: cfg.WriteRate, Burst: cfg.WriteBurst},
+		apiKeyHeader: cfg.APIKeyHeader,
+		apiKeys:      apiKeys,
+		proxies:      proxies,
+	}
+}
+
+// Middleware aplica o limite de requisições e retorna 429 quando excedido
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := l.ClientKey(r.Header.Get(l.apiKeyHeader), reqctx.User(r.Context()), l.proxies.ClientIP(r))
+		result, limit, err := l.Take(r.Context(), client, !isReadMethod(r.Method))
+		if err != nil {
+			// Em caso de falha no store a requisição segue sem limitação
+			logger.ErrorContext(r.Context(), "Erro ao consultar rate limit", "error", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			problem.Write(w, r, problem.RateLimited.Localized("request.rate_limited"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Take consome um token do cliente no limite de escrita ou de leitura
+func (l *RateLimiter) Take(ctx context.Context, client string, write bool) (RateLimitResult, RateLimit, error) {
+	class, limit := "read", l.read
+	if write {
+		class, limit = "write", l.write
+	}
+	result, err := l.store.Take(ctx, class+":"+client, limit)
+	return result, limit, err
+}
+
+// APIKeyHeader retorna o cabeçalho cuja API key identifica o cliente; vazio
+// quando não configurado
+func (l *RateLimiter) APIKeyHeader() string {
+	return l.apiKeyHeader
+}
+
+// ClientKey identifica o cliente pela API key, pelo usuário ou pelo IP, nessa
+// ordem. Só API keys configuradas em RATE_LIMIT_API_KEYS contam: uma chave
+// qualquer enviada pelo cliente criaria um bucket novo a cada requisição.
+func (l *RateLimiter) ClientKey(apiKey, user, ip string) string {
+	if apiKey != "" {
+		if digest := apiKeyDigest(apiKey); l.apiKeys[digest] {
+			return "key:" + digest[:16]
+		}
+	}
+	if user != "" {
+		return "user:" + user
+	}
+	return "ip:" + ip
+}
+
+// apiKeyDigest evita guardar e usar nas chaves do store a API key em claro
+func apiKeyDigest(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// isReadMethod indica se o método HTTP é somente leitura
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// ceilSeconds arredonda uma duração para cima em segundos
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// refill repõe os tokens do bucket e tenta consumir um deles
+func refill(tokens float64, elapsed time.Duration, limit RateLimit) (float64, RateLimitResult) {
+	tokens = math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate)
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+	return tokens, newRateLimitResult(allowed, tokens, limit)
+}
+
+// newRateLimitResult monta o resultado a partir do saldo de tokens restante
+func newRateLimitResult(allowed bool, tokens float64, limit RateLimit) RateLimitResult {
+	result := RateLimitResult{
+		Allowed:   allowed,
+		Remaining: int(math.Floor(tokens)),
+	}
+	if limit.Rate > 0 {
+		if !allowed {
+			result.RetryAfter = secondsToDuration((1 - tokens) / limit.Rate)
+		}
+		result.ResetAfter = secondsToDuration((float64(limit.Burst) - tokens) / limit.Rate)
+	}
+	return result
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// memoryBucket guarda o estado de um bucket em memória
+type memoryBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// MemoryRateLimitStore mantém os buckets em memória, adequado para uma única instância
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryRateLimitStore cria um novo store em memória
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*memoryBucket),
+		now:     time.Now,
+	}
+}
+
+// Take consome um token do bucket do cliente
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, exists := s.buckets[key]
+	if !exists {
+		b = &memoryBucket{tokens: float64(limit.Burst), last: now}
+		s.buckets[key] = b
+	}
+
+	tokens, result := refill(b.tokens, now.Sub(b.last), limit)
+	b.tokens, b.last = tokens, now
+	return result, nil
+}
+
+// sweep remove periodicamente buckets inativos para limitar o uso de memória
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if now.Sub(b.last) > 10*time.Minute {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// tokenBucketScript atualiza o bucket de forma atômica usando o relógio do Redis,
+// evitando divergências entre os relógios das instâncias
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+local ttl = 60
+if rate > 0 then
+	ttl = math.ceil(burst / rate) + 1
+end
+redis.call('EXPIRE', KEYS[1], ttl)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisRateLimitStore compartilha os buckets entre instâncias através do Redis
+type RedisRateLimitStore struct {
+	client redis.Scripter
+	prefix string
+}
+
+// NewRedisRateLimitStore cria um novo store baseado em Redis
+func NewRedisRateLimitStore(client redis.Scripter, prefix string) *RedisRateLimitStore {
+	return &RedisRateLimitStore{client: client, prefix: prefix}
+}
+
+// Take consome um token do bucket do cliente
+func (s *RedisRateLimitStore) Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	values, err := tokenBucketScript.Run(ctx, s.client, []string{s.prefix + key}, limit.Rate, limit.Burst).Slice()
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+	if len(values) != 2 {
+		return RateLimitResult{}, fmt.Errorf("resposta inesperada do Redis: %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	raw, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("saldo de tokens inválido: %w", err)
+	}
+	return newRateLimitResult(allowed == 1, tokens, limit), nil
+}
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"context"
+	"go-api-rest/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRateLimitConfig() config.RateLimitConfig {
+	return config.RateLimitConfig{
+		Enabled:      true,
+		ReadRate:     1,
+		ReadBurst:    2,
+		WriteRate:    1,
+		WriteBurst:   1,
+		APIKeyHeader: "X-API-Key",
+	}
+}
+
+func TestMemoryRateLimitStore_RefillsOverTime(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	limit := RateLimit{Rate: 1, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		result, _ := store.Take(context.Background(), "cliente", limit)
+		if !result.Allowed {
+			t.Fatalf("Requisição %d deveria ser permitida", i+1)
+		}
+	}
+
+	result, _ := store.Take(context.Background(), "cliente", limit)
+	if result.Allowed {
+		t.Fatal("Terceira requisição deveria ser bloqueada")
+	}
+	if result.RetryAfter != time.Second {
+		t.Errorf("Esperava Retry-After de 1s, mas obteve %v", result.RetryAfter)
+	}
+
+	now = now.Add(time.Second)
+	result, _ = store.Take(context.Background(), "cliente", limit)
+	if !result.Allowed {
+		t.Error("Requisição deveria ser permitida após reposição do token")
+	}
+}
+
+func TestRedisRateLimitStore_SharesBucket(t *testing.T) {
+	mr := miniredis.RunT(t)
+	mr.SetTime(time.Unix(1700000000, 0))
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	// Duas instâncias usando o mesmo Redis devem compartilhar o bucket
+	first := NewRedisRateLimitStore(client, "test:")
+	second := NewRedisRateLimitStore(client, "test:")
+	limit := RateLimit{Rate: 1, Burst: 2}
+
+	if result, err := first.Take(context.Background(), "cliente", limit); err != nil || !result.Allowed {
+		t.Fatalf("Primeira requisição deveria ser permitida: %+v, %v", result, err)
+	}
+	result, err := second.Take(context.Background(), "cliente", limit)
+	if err != nil || !result.Allowed {
+		t.Fatalf("Segunda requisição deveria ser permitida: %+v, %v", result, err)
+	}
+	if result.Remaining != 0 {
+		t.Errorf("Esperava 0 tokens restantes, mas obteve %d", result.Remaining)
+	}
+
+	result, _ = first.Take(context.Background(), "cliente", limit)
+	if result.Allowed {
+		t.Fatal("Terceira requisição deveria ser bloqueada")
+	}
+
+	mr.SetTime(time.Unix(1700000001, 0))
+	if result, _ := second.Take(context.Background(), "cliente", limit); !result.Allowed {
+		t.Error("Requisição deveria ser permitida após reposição do token")
+	}
+}
+
+func TestRateLimiter_Returns429WithHeaders(t *testing.T) {
+	limiter := NewRateLimiter(newTestRateLimitConfig(), NewMemoryRateLimitStore(), nil)
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func(method string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/personalities", nil)
+		req.RemoteAddr = "192.0.2.10:1234"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := send(http.MethodPost); rec.Code != http.StatusOK {
+		t.Fatalf("Esperava status 200, mas obteve %d", rec.Code)
+	}
+
+	rec := send(http.MethodPost)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Esperava status 429, mas obteve %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") != "1" {
+		t.Errorf("Esperava Retry-After 1, mas obteve %q", rec.Header().Get("Retry-After"))
+	}
+	if rec.Header().Get("RateLimit-Limit") != "1" || rec.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("Headers RateLimit-* inesperados: %v", rec.Header())
+	}
+
+	// Leituras usam um bucket separado
+	if rec := send(http.MethodGet); rec.Code != http.StatusOK {
+		t.Errorf("Leitura não deveria ser afetada pelo limite de escrita, status %d", rec.Code)
+	}
+}
+
+func TestRateLimiter_APIKeys(t *testing.T) {
+	cfg := newTestRateLimitConfig()
+	cfg.APIKeys = []string{"chave-valida"}
+	limiter := NewRateLimiter(cfg, NewMemoryRateLimitStore(), nil)
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func(apiKey string) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/personalities", nil)
+		req.RemoteAddr = "192.0.2.10:1234"
+		req.Header.Set("X-API-Key", apiKey)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := send("aleatoria-1"); code != http.StatusOK {
+		t.Fatalf("Esperava status 200, mas obteve %d", code)
+	}
+	// Chaves desconhecidas caem no bucket do IP; trocá-las não renova o limite
+	if code := send("aleatoria-2"); code != http.StatusTooManyRequests {
+		t.Errorf("Trocar a API key não deveria renovar o limite, status %d", code)
+	}
+	// A chave configurada tem um bucket próprio
+	if code := send("chave-valida"); code != http.StatusOK {
+		t.Errorf("API key configurada deveria ter um bucket próprio, status %d", code)
+	}
+	if code := send("chave-valida"); code != http.StatusTooManyRequests {
+		t.Errorf("Esperava status 429 para a API key configurada, obteve %d", code)
+	}
+}
+
+func TestTrustedProxies_ClientIP(t *testing.T) {
+	proxies := ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.1"})
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded string
+		expected  string
+	}{
+		{"sem proxy", "203.0.113.5:4000", "", "203.0.113.5"},
+		{"proxy não confiável", "203.0.113.5:4000", "198.51.100.7", "203.0.113.5"},
+		{"proxy confiável", "10.1.1.1:4000", "198.51.100.7", "198.51.100.7"},
+		{"cadeia de proxies", "10.1.1.1:4000", "6.6.6.6, 198.51.100.7, 192.0.2.1", "198.51.100.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remote
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if ip := proxies.ClientIP(req); ip != tt.expected {
+				t.Errorf("Esperava IP %s, mas obteve %s", tt.expected, ip)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"go-api-rest/pkg/reqctx"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// primaryReadCookie guarda até quando a sessão deve ler do banco primário
+const primaryReadCookie = "rw_primary_until"
+
+// ReadYourWrites garante que a sessão leia suas próprias escritas: após uma
+// requisição de escrita, as leituras seguintes da mesma sessão ignoram as
+// réplicas durante a janela informada
+func ReadYourWrites(window time.Duration) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			now := time.Now()
+
+			if !isReadMethod(r.Method) {
+				until := now.Add(window)
+				http.SetCookie(w, &http.Cookie{
+					Name:     primaryReadCookie,
+					Value:    strconv.FormatInt(until.UnixMilli(), 10),
+					Path:     "/",
+					MaxAge:   int(math.Ceil(window.Seconds())),
+					HttpOnly: true,
+					SameSite: http.SameSiteLaxMode,
+				})
+				r = r.WithContext(reqctx.WithPrimaryRead(r.Context()))
+			} else if cookie, err := r.Cookie(primaryReadCookie); err == nil {
+				if until, err := strconv.ParseInt(cookie.Value, 10, 64); err == nil && now.UnixMilli() < until {
+					r = r.WithContext(reqctx.WithPrimaryRead(r.Context()))
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
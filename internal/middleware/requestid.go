@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"go-api-rest/pkg/reqctx"
+	"net/http"
+)
+
+// RequestIDHeader é o cabeçalho usado para propagar o ID de correlação
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID aceita o X-Request-ID recebido ou gera um novo, devolvendo-o na
+// resposta e armazenando-o no contexto da requisição
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := EnsureRequestID(r.Header.Get(RequestIDHeader))
+		w.Header().Set(RequestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(reqctx.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+// EnsureRequestID retorna o ID recebido quando válido ou um novo ID
+func EnsureRequestID(requestID string) string {
+	if !validRequestID(requestID) {
+		return newRequestID()
+	}
+	return requestID
+}
+
+// validRequestID aceita apenas IDs curtos com caracteres seguros para logs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID gera um ID aleatório de 128 bits
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"go-api-rest/pkg/reqctx"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	var fromContext string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fromContext = reqctx.RequestID(r.Context())
+	}))
+
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"reaproveita ID válido", "abc-123", true},
+		{"gera ID quando ausente", "", false},
+		{"substitui ID inválido", "id com espaços\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			echoed := rec.Header().Get(RequestIDHeader)
+			if echoed == "" || echoed != fromContext {
+				t.Fatalf("ID da resposta (%q) difere do contexto (%q)", echoed, fromContext)
+			}
+			if tt.keep && echoed != tt.incoming {
+				t.Errorf("Esperava ID %q, mas obteve %q", tt.incoming, echoed)
+			}
+			if !tt.keep && echoed == tt.incoming {
+				t.Errorf("Esperava um novo ID, mas obteve %q", echoed)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"fmt"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/reqctx"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// vendorMediaType casa os media types de versão, ex: application/vnd.go-api-rest.v2+json
+var vendorMediaType = regexp.MustCompile(`^application/vnd\.go-api-rest\.v(\d+)(?:\+([a-z]+))?$`)
+
+// vendorSuffixes traduz o sufixo do media type de versão para o formato base
+var vendorSuffixes = map[string]string{
+	"":        "application/json",
+	"json":    "application/json",
+	"xml":     "application/xml",
+	"yaml":    "application/yaml",
+	"msgpack": "application/msgpack",
+}
+
+// PinVersion fixa a versão das rotas com versão na URL, ex: /api/v2. Uma
+// versão pedida no Accept é ignorada, mas o Accept é normalizado para a
+// negociação de formato.
+func PinVersion(version int) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accept, _ := parseVersionedAccept(r.Header.Get("Accept"))
+			serveVersion(next, w, r, version, accept)
+		})
+	}
+}
+
+// NegotiateVersion escolhe a versão das rotas sem versão na URL pelo Accept,
+// com o media type application/vnd.go-api-rest.v2+json (sufixos json, xml,
+// yaml e msgpack) ou o parâmetro version, ex: application/json; version=2.
+// Sem versão no Accept vale defaultVersion; versões fora de supported recebem 406.
+func NegotiateVersion(defaultVersion int, supported ...int) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept")
+			accept, version := parseVersionedAccept(r.Header.Get("Accept"))
+			if version == 0 {
+				version = defaultVersion
+			}
+			if !slices.Contains(supported, version) {
+				problem.Write(w, r, problem.UnsupportedVersion.Localized("request.unsupported_version", version, joinVersions(supported)))
+				return
+			}
+			serveVersion(next, w, r, version, accept)
+		})
+	}
+}
+
+func serveVersion(next http.Handler, w http.ResponseWriter, r *http.Request, version int, accept string) {
+	w.Header().Set("API-Version", strconv.Itoa(version))
+	if accept != r.Header.Get("Accept") {
+		r = r.Clone(r.Context())
+		r.Header.Set("Accept", accept)
+	}
+	next.ServeHTTP(w, r.WithContext(reqctx.WithAPIVersion(r.Context(), version)))
+}
+
+// parseVersionedAccept troca os media types de versão pelos formatos base e
+// remove o parâmetro version. A versão retornada é a da faixa de maior q que
+// pede uma, ou zero.
+func parseVersionedAccept(accept string) (string, int) {
+	if accept == "" {
+		return accept, 0
+	}
+	parts := strings.Split(accept, ",")
+	version, bestQ := 0, -1.0
+	for i, part := range parts {
+		mediaType, rawParams, _ := strings.Cut(part, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+		partVersion := 0
+		if m := vendorMediaType.FindStringSubmatch(mediaType); m != nil {
+			base, ok := vendorSuffixes[m[2]]
+			if !ok {
+				continue
+			}
+			mediaType = base
+			partVersion, _ = strconv.Atoi(m[1])
+		}
+
+		q := 1.0
+		var params []string
+		for _, param := range strings.Split(rawParams, ";") {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found {
+				continue
+			}
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "version":
+				partVersion, _ = strconv.Atoi(strings.Trim(strings.TrimSpace(value), `"v`))
+				continue
+			case "q":
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = parsed
+				}
+			}
+			params = append(params, strings.TrimSpace(param))
+		}
+
+		if partVersion > 0 && q > bestQ {
+			version, bestQ = partVersion, q
+		}
+		parts[i] = strings.Join(append([]string{mediaType}, params...), ";")
+	}
+	return strings.Join(parts, ", "), version
+}
+
+func joinVersions(versions []int) string {
+	names := make([]string, len(versions))
+	for i, v := range versions {
+		names[i] = "v" + strconv.Itoa(v)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Deprecation anuncia a descontinuação de uma versão nas respostas em que ela
+// foi usada: Deprecation (RFC 9745) com a data do anúncio, Sunset (RFC 8594)
+// com a data de remoção e Link rel="successor-version" para a rota
+// equivalente da versão seguinte, calculada por successor. Datas zero omitem
+// o cabeçalho correspondente.
+func Deprecation(version int, deprecatedAt, sunset time.Time, successor func(*http.Request) string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if deprecatedAt.IsZero() && sunset.IsZero() {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if reqctx.APIVersion(r.Context()) == version {
+				h := w.Header()
+				if !deprecatedAt.IsZero() {
+					h.Set("Deprecation", fmt.Sprintf("@%d", deprecatedAt.Unix()))
+				}
+				if !sunset.IsZero() {
+					h.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+				}
+				if link := successor(r); link != "" {
+					h.Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, link))
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"go-api-rest/pkg/reqctx"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// versionEcho devolve a versão do contexto e o Accept recebido
+func versionEcho(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Version", strconv.Itoa(reqctx.APIVersion(r.Context())))
+	w.Header().Set("X-Accept", r.Header.Get("Accept"))
+}
+
+func TestNegotiateVersion(t *testing.T) {
+	h := NegotiateVersion(1, 1, 2)(http.HandlerFunc(versionEcho))
+	tests := []struct {
+		accept  string
+		status  int
+		version string
+		base    string
+	}{
+		{"", http.StatusOK, "1", ""},
+		{"application/json", http.StatusOK, "1", "application/json"},
+		{"application/vnd.go-api-rest.v2+json", http.StatusOK, "2", "application/json"},
+		{"application/vnd.go-api-rest.v2+xml", http.StatusOK, "2", "application/xml"},
+		{"application/vnd.go-api-rest.v2", http.StatusOK, "2", "application/json"},
+		{"application/yaml; version=2; q=0.9", http.StatusOK, "2", "application/yaml;q=0.9"},
+		{"application/vnd.go-api-rest.v1+json;q=0.5, application/vnd.go-api-rest.v2+json", http.StatusOK, "2", "application/json;q=0.5, application/json"},
+		{"application/vnd.go-api-rest.v3+json", http.StatusNotAcceptable, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Esperava status %d, obteve %d", tt.status, rec.Code)
+			}
+			if rec.Header().Get("Vary") != "Accept" {
+				t.Errorf("Esperava Vary: Accept, obteve %q", rec.Header().Get("Vary"))
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("X-Version"); got != tt.version || rec.Header().Get("API-Version") != tt.version {
+				t.Errorf("Esperava versão %s, obteve %s (API-Version %s)", tt.version, got, rec.Header().Get("API-Version"))
+			}
+			if got := rec.Header().Get("X-Accept"); got != tt.base {
+				t.Errorf("Esperava Accept %q, obteve %q", tt.base, got)
+			}
+		})
+	}
+}
+
+func TestDeprecation(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 4, 1, 0, 0, 0, 0, time.UTC)
+	successor := func(r *http.Request) string { return "/v2" + r.URL.Path }
+	h := Deprecation(1, deprecatedAt, sunset, successor)(http.HandlerFunc(versionEcho))
+
+	for _, version := range []int{1, 2} {
+		req := httptest.NewRequest(http.MethodGet, "/itens", nil)
+		req = req.WithContext(reqctx.WithAPIVersion(req.Context(), version))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		header := rec.Header()
+		if version != 1 {
+			if header.Get("Deprecation") != "" || header.Get("Sunset") != "" || header.Get("Link") != "" {
+				t.Errorf("A v%d não deveria ser anunciada como descontinuada: %v", version, header)
+			}
+			continue
+		}
+		if got := header.Get("Deprecation"); got != "@1790812800" {
+			t.Errorf("Deprecation inesperado: %q", got)
+		}
+		if got := header.Get("Sunset"); got != "Thu, 01 Apr 2027 00:00:00 GMT" {
+			t.Errorf("Sunset inesperado: %q", got)
+		}
+		if got := header.Get("Link"); got != `</v2/itens>; rel="successor-version"` {
+			t.Errorf("Link inesperado: %q", got)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-api-rest/internal/cache"
+	"go-api-rest/internal/config"
+	"go-api-rest/models"
+	"go-api-rest/pkg/logger"
+	"go-api-rest/pkg/reqctx"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+// cacheName identifica o cache nas métricas
+const cacheName = "personality"
+
+// negativeEntry marca no cache um ID que não existe no repositório
+var negativeEntry = []byte("null")
+
+// generationStripes é o número de faixas de IDs com contador de invalidações
+const generationStripes = 256
+
+// generation conta as invalidações dos IDs de uma faixa. O lock é mantido ao
+// gravar no cache para que a gravação e a invalidação não se intercalem. Com o
+// Redis compartilhado, só as escritas desta instância são consideradas.
+type generation struct {
+	mu sync.Mutex
+	n  uint64
+}
+
+// cachedPersonalityRepository faz cache de leitura de FindByID e FindByIDs; as
+// demais consultas vão direto ao repositório envolvido
+type cachedPersonalityRepository struct {
+	next        PersonalityRepository
+	cache       cache.Cache
+	observer    cache.Observer
+	ttl         time.Duration
+	negativeTTL time.Duration
+	group       singleflight.Group
+	generations [generationStripes]generation
+}
+
+// NewCachedPersonalityRepository envolve um PersonalityRepository com cache de
+// leitura. Escritas invalidam a entrada do registro; observer pode ser nil.
+func NewCachedPersonalityRepository(next PersonalityRepository, store cache.Cache, cfg config.CacheConfig, observer cache.Observer) PersonalityRepository {
+	return &cachedPersonalityRepository{
+		next:        next,
+		cache:       store,
+		observer:    observer,
+		ttl:         cfg.TTL,
+		negativeTTL: cfg.NegativeTTL,
+	}
+}
+
+// WithCache aplica o cache configurado quando cfg.Enabled é verdadeiro;
+// caso contrário devolve next sem alterações
+func WithCache(next PersonalityRepository, cfg config.CacheConfig, observer cache.Observer) (PersonalityRepository, error) {
+	if !cfg.Enabled {
+		return next, nil
+	}
+	store, err := cache.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewCachedPersonalityRepository(next, store, cfg, observer), nil
+}
+
+func (r *cachedPersonalityRepository) Create(ctx context.Context, personality *models.Personality) error {
+	if err := r.next.Create(ctx, personality); err != nil {
+		return err
+	}
+	// O novo ID pode ter sido consultado antes e estar em cache como inexistente
+	r.invalidate(ctx, personality.ID)
+	return nil
+}
+
+func (r *cachedPersonalityRepository) FindAll(ctx context.Context) ([]models.Personality, error) {
+	return r.next.FindAll(ctx)
+}
+
+func (r *cachedPersonalityRepository) FindPage(ctx context.Context, filter PersonalityFilter, offset, limit int) ([]models.Personality, int64, error) {
+	return r.next.FindPage(ctx, filter, offset, limit)
+}
+
+func (r *cachedPersonalityRepository) FindByID(ctx context.Context, id uint) (*models.Personality, error) {
+	// Leituras feitas durante escritas precisam do estado atual do banco
+	if reqctx.PrimaryRead(ctx) {
+		return r.next.FindByID(ctx, id)
+	}
+
+	key := cacheKey(id)
+	if personality, found := r.lookup(ctx, key); found {
+		if personality == nil {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return personality, nil
+	}
+
+	// Requisições simultâneas para o mesmo ID compartilham uma única consulta;
+	// a consulta não é cancelada se apenas um dos chamadores desistir
+	result := r.group.DoChan(key, func() (interface{}, error) {
+		return r.load(context.WithoutCancel(ctx), id)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		// Cada chamador recebe sua própria cópia
+		personality := *res.Val.(*models.Personality)
+		return &personality, nil
+	}
+}
+
+// FindByIDs responde do cache os IDs conhecidos e busca os demais em uma
+// única consulta, gravando o resultado no cache
+func (r *cachedPersonalityRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Personality, error) {
+	if reqctx.PrimaryRead(ctx) {
+		return r.next.FindByIDs(ctx, ids)
+	}
+
+	ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+	personalities := make([]models.Personality, 0, len(ids))
+	var missing []uint
+	for _, id := range ids {
+		personality, found := r.lookup(ctx, cacheKey(id))
+		switch {
+		case !found:
+			missing = append(missing, id)
+		case personality != nil:
+			personalities = append(personalities, *personality)
+		}
+	}
+	if len(missing) == 0 {
+		return personalities, nil
+	}
+
+	generations := make(map[uint]uint64, len(missing))
+	for _, id := range missing {
+		generations[id] = r.generation(id)
+	}
+	loaded, err := r.next.FindByIDs(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, personality := range loaded {
+		if value, err := json.Marshal(personality); err == nil {
+			r.store(ctx, personality.ID, generations[personality.ID], value, r.ttl)
+		}
+	}
+	if r.negativeTTL > 0 {
+		for _, id := range missing {
+			if !slices.ContainsFunc(loaded, func(p models.Personality) bool { return p.ID == id }) {
+				r.store(ctx, id, generations[id], negativeEntry, r.negativeTTL)
+			}
+		}
+	}
+	personalities = append(personalities, loaded...)
+	sort.Slice(personalities, func(i, j int) bool { return personalities[i].ID < personalities[j].ID })
+	return personalities, nil
+}
+
+func (r *cachedPersonalityRepository) Update(ctx context.Context, personality *models.Personality) error {
+	err := r.next.Update(ctx, personality)
+	r.invalidate(ctx, personality.ID)
+	return err
+}
+
+func (r *cachedPersonalityRepository) Delete(ctx context.Context, id uint) error {
+	err := r.next.Delete(ctx, id)
+	r.invalidate(ctx, id)
+	return err
+}
+
+func (r *cachedPersonalityRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	return r.next.ExistsByName(ctx, name)
+}
+
+// lookup consulta o cache; um resultado encontrado com personality nil indica
+// um registro inexistente. Falhas do cache são tratadas como miss.
+func (r *cachedPersonalityRepository) lookup(ctx context.Context, key string) (*models.Personality, bool) {
+	value, found, err := r.cache.Get(ctx, key)
+	switch {
+	case err != nil:
+		r.observe("error")
+		logger.WarnContext(ctx, "Erro ao consultar o cache", "key", key, "error", err)
+		return nil, false
+	case !found:
+		r.observe("miss")
+		return nil, false
+	case string(value) == string(negativeEntry):
+		r.observe("negative_hit")
+		return nil, true
+	}
+
+	var personality models.Personality
+	if err := json.Unmarshal(value, &personality); err != nil {
+		r.observe("error")
+		logger.WarnContext(ctx, "Entrada de cache inválida", "key", key, "error", err)
+		return nil, false
+	}
+	r.observe("hit")
+	return &personality, true
+}
+
+// load busca no repositório e grava o resultado no cache
+func (r *cachedPersonalityRepository) load(ctx context.Context, id uint) (*models.Personality, error) {
+	gen := r.generation(id)
+	personality, err := r.next.FindByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if r.negativeTTL > 0 {
+			r.store(ctx, id, gen, negativeEntry, r.negativeTTL)
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(personality)
+	if err == nil {
+		r.store(ctx, id, gen, value, r.ttl)
+	}
+	return personality, nil
+}
+
+// generation lê o contador de invalidações do ID, antes da consulta ao
+// repositório cujo resultado será gravado
+func (r *cachedPersonalityRepository) generation(id uint) uint64 {
+	g := &r.generations[id%generationStripes]
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.n
+}
+
+// store grava o valor lido na geração gen. Se o ID foi invalidado desde então,
+// a leitura pode ser anterior à escrita e a gravação é descartada; sem isso
+// uma consulta lenta devolveria ao cache o estado que a escrita substituiu.
+func (r *cachedPersonalityRepository) store(ctx context.Context, id uint, gen uint64, value []byte, ttl time.Duration) {
+	g := &r.generations[id%generationStripes]
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.n != gen {
+		return
+	}
+	key := cacheKey(id)
+	if err := r.cache.Set(ctx, key, value, ttl); err != nil {
+		logger.WarnContext(ctx, "Erro ao gravar no cache", "key", key, "error", err)
+	}
+}
+
+// invalidate remove a entrada do registro e descarta as gravações de leituras
+// em andamento; uma falha aqui deixa o cache desatualizado até o TTL expirar e
+// por isso é registrada como erro
+func (r *cachedPersonalityRepository) invalidate(ctx context.Context, id uint) {
+	g := &r.generations[id%generationStripes]
+	g.mu.Lock()
+	g.n++
+	g.mu.Unlock()
+
+	key := cacheKey(id)
+	r.group.Forget(key)
+	if err := r.cache.Delete(ctx, key); err != nil {
+		logger.ErrorContext(ctx, "Erro ao invalidar o cache", "key", key, "error", err)
+	}
+}
+
+func (r *cachedPersonalityRepository) observe(result string) {
+	if r.observer != nil {
+		r.observer.ObserveCache(cacheName, result)
+	}
+}
+
+func cacheKey(id uint) string {
+	return "personality:" + strconv.FormatUint(uint64(id), 10)
+}
@@ -0,0 +1,272 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"go-api-rest/internal/cache"
+	"go-api-rest/internal/config"
+	"go-api-rest/internal/repository"
+	"go-api-rest/internal/repository/repositorytest"
+	"go-api-rest/models"
+	"go-api-rest/pkg/reqctx"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// countingRepository conta as chamadas a FindByID e FindByIDs e pode segurar
+// as de FindByID até release ser fechado; afterFind roda após cada leitura
+type countingRepository struct {
+	repository.PersonalityRepository
+	finds     atomic.Int32
+	batches   atomic.Int32
+	requested atomic.Int32
+	release   chan struct{}
+	afterFind func()
+}
+
+func (r *countingRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Personality, error) {
+	r.batches.Add(1)
+	r.requested.Add(int32(len(ids)))
+	return r.PersonalityRepository.FindByIDs(ctx, ids)
+}
+
+func (r *countingRepository) FindByID(ctx context.Context, id uint) (*models.Personality, error) {
+	r.finds.Add(1)
+	if r.release != nil {
+		<-r.release
+	}
+	personality, err := r.PersonalityRepository.FindByID(ctx, id)
+	if r.afterFind != nil {
+		r.afterFind()
+	}
+	return personality, err
+}
+
+// recordingObserver guarda os resultados observados no cache
+type recordingObserver struct {
+	mu      sync.Mutex
+	results map[string]int
+}
+
+func (o *recordingObserver) ObserveCache(_, result string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.results == nil {
+		o.results = make(map[string]int)
+	}
+	o.results[result]++
+}
+
+func (o *recordingObserver) count(result string) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.results[result]
+}
+
+var testCacheConfig = config.CacheConfig{Enabled: true, TTL: time.Minute, NegativeTTL: time.Minute}
+
+func newCachedRepository(t *testing.T) (repository.PersonalityRepository, *countingRepository, *recordingObserver) {
+	t.Helper()
+	inner := &countingRepository{PersonalityRepository: repository.NewMemoryPersonalityRepository()}
+	observer := &recordingObserver{}
+	return repository.NewCachedPersonalityRepository(inner, cache.NewLRU(100), testCacheConfig, observer), inner, observer
+}
+
+func TestCachedPersonalityRepository_Conformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.PersonalityRepository {
+		repo, _, _ := newCachedRepository(t)
+		return repo
+	})
+}
+
+func TestCachedPersonalityRepository_ServesHitsFromCache(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, observer := newCachedRepository(t)
+	p := &models.Personality{Name: "Ada Lovelace", History: "Primeira programadora"}
+	repo.Create(ctx, p)
+
+	for i := 0; i < 3; i++ {
+		found, err := repo.FindByID(ctx, p.ID)
+		if err != nil || found.Name != "Ada Lovelace" {
+			t.Fatalf("Resultado inesperado: %+v (erro: %v)", found, err)
+		}
+	}
+
+	if got := inner.finds.Load(); got != 1 {
+		t.Errorf("Esperava 1 consulta ao repositório, obteve %d", got)
+	}
+	if observer.count("miss") != 1 || observer.count("hit") != 2 {
+		t.Errorf("Esperava 1 miss e 2 hits, obteve %v", observer.results)
+	}
+}
+
+func TestCachedPersonalityRepository_FindByIDsFetchesOnlyMisses(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, _ := newCachedRepository(t)
+	for _, name := range []string{"Ada Lovelace", "Alan Turing", "Grace Hopper"} {
+		repo.Create(ctx, &models.Personality{Name: name, History: "Pioneira"})
+	}
+	repo.FindByID(ctx, 1)
+
+	found, err := repo.FindByIDs(ctx, []uint{3, 1, 2, 4})
+	if err != nil || len(found) != 3 || found[0].ID != 1 || found[2].ID != 3 {
+		t.Fatalf("Resultado inesperado: %+v (erro: %v)", found, err)
+	}
+	if inner.batches.Load() != 1 || inner.requested.Load() != 3 {
+		t.Errorf("Esperava uma consulta com os IDs 2, 3 e 4, obteve %d consultas com %d IDs", inner.batches.Load(), inner.requested.Load())
+	}
+
+	// Todos os IDs, inclusive o inexistente, passam a vir do cache
+	if found, _ := repo.FindByIDs(ctx, []uint{1, 2, 3, 4}); len(found) != 3 || inner.batches.Load() != 1 {
+		t.Errorf("Esperava a segunda busca servida pelo cache, obteve %d registros e %d consultas", len(found), inner.batches.Load())
+	}
+}
+
+func TestCachedPersonalityRepository_InvalidatesOnWrite(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, _ := newCachedRepository(t)
+	p := &models.Personality{Name: "Ada Lovelace", History: "Primeira programadora"}
+	repo.Create(ctx, p)
+	repo.FindByID(ctx, p.ID)
+
+	p.History = "Nova história"
+	if err := repo.Update(ctx, p); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	found, _ := repo.FindByID(ctx, p.ID)
+	if found.History != "Nova história" {
+		t.Errorf("Esperava história atualizada, obteve %q", found.History)
+	}
+
+	if err := repo.Delete(ctx, p.ID); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, p.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Esperava gorm.ErrRecordNotFound após a remoção, obteve %v", err)
+	}
+	if got := inner.finds.Load(); got != 3 {
+		t.Errorf("Esperava 3 consultas ao repositório, obteve %d", got)
+	}
+}
+
+func TestCachedPersonalityRepository_NegativeCaching(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, observer := newCachedRepository(t)
+
+	for i := 0; i < 2; i++ {
+		if _, err := repo.FindByID(ctx, 1); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("Esperava gorm.ErrRecordNotFound, obteve %v", err)
+		}
+	}
+	if got := inner.finds.Load(); got != 1 {
+		t.Errorf("Esperava 1 consulta ao repositório, obteve %d", got)
+	}
+	if observer.count("negative_hit") != 1 {
+		t.Errorf("Esperava 1 negative_hit, obteve %v", observer.results)
+	}
+
+	// A criação do ID 1 precisa remover a entrada negativa
+	repo.Create(ctx, &models.Personality{Name: "Ada Lovelace", History: "Primeira programadora"})
+	if _, err := repo.FindByID(ctx, 1); err != nil {
+		t.Errorf("Esperava registro recém-criado, obteve %v", err)
+	}
+}
+
+func TestCachedPersonalityRepository_CollapsesConcurrentMisses(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, _ := newCachedRepository(t)
+	p := &models.Personality{Name: "Ada Lovelace", History: "Primeira programadora"}
+	repo.Create(ctx, p)
+	inner.release = make(chan struct{})
+
+	const callers = 10
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.FindByID(ctx, p.ID); err != nil {
+				t.Errorf("Erro inesperado: %v", err)
+			}
+		}()
+	}
+
+	// Aguarda a primeira consulta começar e dá tempo aos demais chamadores de aguardá-la
+	for inner.finds.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(inner.release)
+	wg.Wait()
+
+	if got := inner.finds.Load(); got != 1 {
+		t.Errorf("Esperava 1 consulta ao repositório, obteve %d", got)
+	}
+}
+
+// TestCachedPersonalityRepository_DiscardsLoadRacingWrite cobre a leitura que
+// termina depois de uma escrita no mesmo registro: o valor lido antes da
+// escrita não pode voltar ao cache após a invalidação
+func TestCachedPersonalityRepository_DiscardsLoadRacingWrite(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, _ := newCachedRepository(t)
+	p := &models.Personality{Name: "Ada Lovelace", History: "Primeira programadora"}
+	repo.Create(ctx, p)
+
+	read, resume := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	inner.afterFind = func() {
+		once.Do(func() {
+			close(read)
+			<-resume
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if found, err := repo.FindByID(ctx, p.ID); err != nil || found.History != "Primeira programadora" {
+			t.Errorf("Resultado inesperado: %+v (erro: %v)", found, err)
+		}
+	}()
+
+	// A leitura já viu o estado antigo quando a escrita acontece
+	<-read
+	updated := *p
+	updated.History = "Nova história"
+	if err := repo.Update(ctx, &updated); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	close(resume)
+	<-done
+
+	found, err := repo.FindByID(ctx, p.ID)
+	if err != nil || found.History != "Nova história" {
+		t.Errorf("Esperava história atualizada, obteve %+v (erro: %v)", found, err)
+	}
+}
+
+func TestCachedPersonalityRepository_BypassesCacheOnPrimaryRead(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, _ := newCachedRepository(t)
+	p := &models.Personality{Name: "Ada Lovelace", History: "Primeira programadora"}
+	repo.Create(ctx, p)
+	repo.FindByID(ctx, p.ID)
+
+	repo.FindByID(reqctx.WithPrimaryRead(ctx), p.ID)
+	if got := inner.finds.Load(); got != 2 {
+		t.Errorf("Esperava leitura do primário fora do cache, obteve %d consultas", got)
+	}
+}
+
+func TestWithCache_DisabledReturnsRepository(t *testing.T) {
+	inner := repository.NewMemoryPersonalityRepository()
+	repo, err := repository.WithCache(inner, config.CacheConfig{Enabled: false}, nil)
+	if err != nil || repo != inner {
+		t.Errorf("Esperava o repositório original, obteve %T (erro: %v)", repo, err)
+	}
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"go-api-rest/models"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryPersonalityRepository implementa PersonalityRepository em memória,
+// útil para demonstrações e para rodar a API sem banco de dados
+type memoryPersonalityRepository struct {
+	mu     sync.RWMutex
+	nextID uint
+	items  map[uint]models.Personality
+	now    func() time.Time
+}
+
+// NewMemoryPersonalityRepository cria um repositório em memória seguro para uso concorrente
+func NewMemoryPersonalityRepository() PersonalityRepository {
+	return &memoryPersonalityRepository{
+		items: make(map[uint]models.Personality),
+		now:   time.Now,
+	}
+}
+
+func (r *memoryPersonalityRepository) Create(ctx context.Context, personality *models.Personality) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(personality.Name, 0) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	r.nextID++
+	now := r.now()
+	personality.ID = r.nextID
+	personality.Version = 1
+	personality.CreatedAt = now
+	personality.UpdatedAt = now
+	r.items[personality.ID] = *personality
+	return nil
+}
+
+func (r *memoryPersonalityRepository) FindAll(ctx context.Context) ([]models.Personality, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	personalities := make([]models.Personality, 0, len(r.items))
+	for _, p := range r.items {
+		personalities = append(personalities, p)
+	}
+	sort.Slice(personalities, func(i, j int) bool { return personalities[i].ID < personalities[j].ID })
+	return personalities, nil
+}
+
+func (r *memoryPersonalityRepository) FindPage(ctx context.Context, filter PersonalityFilter, offset, limit int) ([]models.Personality, int64, error) {
+	all, err := r.FindAll(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	matched := all[:0]
+	for _, p := range all {
+		if filter.Matches(p) {
+			matched = append(matched, p)
+		}
+	}
+	total := int64(len(matched))
+	offset = max(offset, 0)
+	if offset >= len(matched) {
+		return []models.Personality{}, total, nil
+	}
+	end := min(offset+limit, len(matched))
+	return matched[offset:end], total, nil
+}
+
+func (r *memoryPersonalityRepository) FindByID(ctx context.Context, id uint) (*models.Personality, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.items[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &p, nil
+}
+
+func (r *memoryPersonalityRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Personality, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[uint]bool, len(ids))
+	personalities := make([]models.Personality, 0, len(ids))
+	for _, id := range ids {
+		if p, ok := r.items[id]; ok && !seen[id] {
+			seen[id] = true
+			personalities = append(personalities, p)
+		}
+	}
+	sort.Slice(personalities, func(i, j int) bool { return personalities[i].ID < personalities[j].ID })
+	return personalities, nil
+}
+
+func (r *memoryPersonalityRepository) Update(ctx context.Context, personality *models.Personality) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.items[personality.ID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	if r.nameTaken(personality.Name, personality.ID) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	current.Name = personality.Name
+	current.History = personality.History
+	current.Version++
+	current.UpdatedAt = r.now()
+	r.items[current.ID] = current
+
+	personality.Version = current.Version
+	personality.CreatedAt = current.CreatedAt
+	personality.UpdatedAt = current.UpdatedAt
+	return nil
+}
+
+func (r *memoryPersonalityRepository) Delete(ctx context.Context, id uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(r.items, id)
+	return nil
+}
+
+func (r *memoryPersonalityRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.nameTaken(name, 0), nil
+}
+
+// nameTaken informa se outro registro além de exceptID já usa o nome; exige o lock
+func (r *memoryPersonalityRepository) nameTaken(name string, exceptID uint) bool {
+	for id, p := range r.items {
+		if id != exceptID && p.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -1,19 +1,68 @@
 package repository
 
 import (
+	"context"
+	"fmt"
+	"go-api-rest/database"
 	"go-api-rest/models"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// PersonalityRepository define a interface para operações de dados
+// PersonalityRepository define a interface para operações de dados.
+// Todas as implementações sinalizam registro ausente com gorm.ErrRecordNotFound
+// e nome duplicado com gorm.ErrDuplicatedKey.
 type PersonalityRepository interface {
-	Create(personality *models.Personality) error
-	FindAll() ([]models.Personality, error)
-	FindByID(id uint) (*models.Personality, error)
-	Update(personality *models.Personality) error
-	Delete(id uint) error
-	ExistsByName(name string) (bool, error)
+	Create(ctx context.Context, personality *models.Personality) error
+	FindAll(ctx context.Context) ([]models.Personality, error)
+	FindPage(ctx context.Context, filter PersonalityFilter, offset, limit int) ([]models.Personality, int64, error)
+	FindByID(ctx context.Context, id uint) (*models.Personality, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]models.Personality, error)
+	Update(ctx context.Context, personality *models.Personality) error
+	Delete(ctx context.Context, id uint) error
+	ExistsByName(ctx context.Context, name string) (bool, error)
+}
+
+// PersonalityFilter restringe as consultas paginadas; campos vazios não filtram.
+// Os trechos de texto não diferenciam maiúsculas de minúsculas.
+type PersonalityFilter struct {
+	Name         string    // trecho do nome
+	Text         string    // trecho do nome ou da história
+	UpdatedSince time.Time // alteradas a partir desta data, inclusive
+}
+
+// Matches informa se a personalidade passa pelo filtro
+func (f PersonalityFilter) Matches(p models.Personality) bool {
+	contains := func(s, substr string) bool {
+		return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+	}
+	return (f.Name == "" || contains(p.Name, f.Name)) &&
+		(f.Text == "" || contains(p.Name, f.Text) || contains(p.History, f.Text)) &&
+		(f.UpdatedSince.IsZero() || !p.UpdatedAt.Before(f.UpdatedSince))
+}
+
+// apply traduz o filtro para condições SQL
+func (f PersonalityFilter) apply(db *gorm.DB) *gorm.DB {
+	if f.Name != "" {
+		db = db.Where(`LOWER(name) LIKE ? ESCAPE '\'`, likePattern(f.Name))
+	}
+	if f.Text != "" {
+		pattern := likePattern(f.Text)
+		db = db.Where(`(LOWER(name) LIKE ? ESCAPE '\' OR LOWER(history) LIKE ? ESCAPE '\')`, pattern, pattern)
+	}
+	if !f.UpdatedSince.IsZero() {
+		db = db.Where("updated_at >= ?", f.UpdatedSince)
+	}
+	return db
+}
+
+// likePattern monta o padrão de LIKE para um trecho, escapando os curingas
+func likePattern(substr string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(strings.ToLower(substr))
+	return "%" + escaped + "%"
 }
 
 // personalityRepository implementa PersonalityRepository
@@ -26,35 +75,102 @@ func NewPersonalityRepository(db *gorm.DB) PersonalityRepository {
 	return &personalityRepository{db: db}
 }
 
-func (r *personalityRepository) Create(personality *models.Personality) error {
-	return r.db.Create(personality).Error
+func (r *personalityRepository) Create(ctx context.Context, personality *models.Personality) error {
+	return r.db.WithContext(ctx).Create(personality).Error
 }
 
-func (r *personalityRepository) FindAll() ([]models.Personality, error) {
+func (r *personalityRepository) FindAll(ctx context.Context) ([]models.Personality, error) {
 	var personalities []models.Personality
-	err := r.db.Order("id ASC").Find(&personalities).Error
+	err := database.ReadDB(ctx, r.db).Order("id ASC").Find(&personalities).Error
 	return personalities, err
 }
 
-func (r *personalityRepository) FindByID(id uint) (*models.Personality, error) {
+// FindPage retorna até limit registros que passam pelo filtro a partir de
+// offset, em ordem de ID, e o total de registros filtrados; offset negativo
+// conta como zero
+func (r *personalityRepository) FindPage(ctx context.Context, filter PersonalityFilter, offset, limit int) ([]models.Personality, int64, error) {
+	db := database.ReadDB(ctx, r.db)
+	var total int64
+	if err := filter.apply(db.Model(&models.Personality{})).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var personalities []models.Personality
+	err := filter.apply(db).Order("id ASC").Offset(max(offset, 0)).Limit(limit).Find(&personalities).Error
+	return personalities, total, err
+}
+
+func (r *personalityRepository) FindByID(ctx context.Context, id uint) (*models.Personality, error) {
 	var personality models.Personality
-	err := r.db.First(&personality, id).Error
+	err := database.ReadDB(ctx, r.db).First(&personality, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &personality, nil
 }
 
-func (r *personalityRepository) Update(personality *models.Personality) error {
-	return r.db.Save(personality).Error
+// FindByIDs busca vários registros em uma consulta, em ordem de ID; IDs
+// inexistentes são ignorados
+func (r *personalityRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Personality, error) {
+	personalities := []models.Personality{}
+	if len(ids) == 0 {
+		return personalities, nil
+	}
+	err := database.ReadDB(ctx, r.db).Where("id IN ?", ids).Order("id ASC").Find(&personalities).Error
+	return personalities, err
 }
 
-func (r *personalityRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Personality{}, id).Error
+// Update altera apenas os campos editáveis e incrementa a versão no banco. A
+// versão gravada volta pelo RETURNING: escritas concorrentes no mesmo registro
+// fariam um incremento local divergir do banco. Retorna gorm.ErrRecordNotFound
+// se o registro não existir.
+func (r *personalityRepository) Update(ctx context.Context, personality *models.Personality) error {
+	result := r.db.WithContext(ctx).Model(personality).Clauses(clause.Returning{Columns: []clause.Column{
+		{Name: "version"},
+		{Name: "updated_at"},
+	}}).Updates(map[string]interface{}{
+		"name":    personality.Name,
+		"history": personality.History,
+		"version": gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
-func (r *personalityRepository) ExistsByName(name string) (bool, error) {
+func (r *personalityRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.Personality{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ExistsByName protege escritas contra nomes duplicados e por isso sempre consulta o primário
+func (r *personalityRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.Personality{}).Where("name = ?", name).Count(&count).Error
+	err := database.PrimaryDB(ctx, r.db).Model(&models.Personality{}).Where("name = ?", name).Count(&count).Error
 	return count > 0, err
 }
+
+// New cria o repositório correspondente ao driver configurado; db é ignorado
+// para o driver em memória
+func New(driver string, db *gorm.DB) (PersonalityRepository, error) {
+	switch driver {
+	case database.DriverMemory:
+		return NewMemoryPersonalityRepository(), nil
+	case "", database.DriverPostgres, database.DriverSQLite:
+		if db == nil {
+			return nil, fmt.Errorf("driver %q exige uma conexão com o banco de dados", driver)
+		}
+		return NewPersonalityRepository(db), nil
+	default:
+		return nil, fmt.Errorf("driver de banco de dados não suportado: %s", driver)
+	}
+}
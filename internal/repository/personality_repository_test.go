@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"fmt"
+	"go-api-rest/database"
+	"go-api-rest/internal/config"
+	"go-api-rest/internal/repository"
+	"go-api-rest/internal/repository/repositorytest"
+	"go-api-rest/models"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryPersonalityRepository_Conformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.PersonalityRepository {
+		return repository.NewMemoryPersonalityRepository()
+	})
+}
+
+func TestSQLitePersonalityRepository_Conformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.PersonalityRepository {
+		// Banco em memória compartilhado entre as conexões do pool e exclusivo do subteste
+		name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+		return newGormRepository(t, config.DatabaseConfig{
+			Driver:     database.DriverSQLite,
+			SQLitePath: fmt.Sprintf("file:%s?mode=memory&cache=shared", name),
+		})
+	})
+}
+
+// A suíte contra o Postgres só roda com TEST_POSTGRES=1 e usa as variáveis DB_*;
+// a tabela de personalidades é recriada a cada subteste
+func TestPostgresPersonalityRepository_Conformance(t *testing.T) {
+	if os.Getenv("TEST_POSTGRES") != "1" {
+		t.Skip("TEST_POSTGRES não definido")
+	}
+
+	cfg := config.Load().Database
+	cfg.ReplicaDSNs = nil
+	repositorytest.Run(t, func(t *testing.T) repository.PersonalityRepository {
+		return newGormRepository(t, cfg)
+	})
+}
+
+func newGormRepository(t *testing.T, cfg config.DatabaseConfig) repository.PersonalityRepository {
+	t.Helper()
+	cfg.LogLevel = "silent"
+	// Conexões ociosas mantêm vivo o banco SQLite em memória
+	cfg.MaxOpenConns = 4
+	cfg.MaxIdleConns = 4
+	cfg.ConnectTimeout = 5 * time.Second
+	cfg.ConnectRetryInitial = 100 * time.Millisecond
+	cfg.ConnectRetryMax = time.Second
+
+	db, err := database.NewDatabase(cfg)
+	if err != nil {
+		t.Fatalf("Erro ao conectar: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if cfg.Driver == database.DriverPostgres {
+		if err := db.DB.Migrator().DropTable(&models.Personality{}); err != nil {
+			t.Fatalf("Erro ao limpar tabela: %v", err)
+		}
+	}
+	if err := db.DB.AutoMigrate(&models.Personality{}); err != nil {
+		t.Fatalf("Erro ao migrar: %v", err)
+	}
+
+	repo, err := repository.New(cfg.Driver, db.DB)
+	if err != nil {
+		t.Fatalf("Erro ao criar repositório: %v", err)
+	}
+	return repo
+}
@@ -0,0 +1,390 @@
+// Package repositorytest contém a suíte de conformidade que toda
+// implementação de repository.PersonalityRepository deve satisfazer.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api-rest/internal/repository"
+	"go-api-rest/models"
+	"go-api-rest/pkg/reqctx"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Factory cria um repositório vazio e isolado para cada subteste
+type Factory func(t *testing.T) repository.PersonalityRepository
+
+// Run executa a suíte de conformidade contra o repositório criado por newRepo
+func Run(t *testing.T, newRepo Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo repository.PersonalityRepository)
+	}{
+		{"CreateAssignsIDAndTimestamps", testCreateAssignsIDAndTimestamps},
+		{"CreateDuplicateName", testCreateDuplicateName},
+		{"FindByID", testFindByID},
+		{"FindByIDNotFound", testFindByIDNotFound},
+		{"FindAllOrderedByID", testFindAllOrderedByID},
+		{"FindPage", testFindPage},
+		{"FindPageFiltered", testFindPageFiltered},
+		{"FindPageOnPrimaryRead", testFindPageOnPrimaryRead},
+		{"FindByIDs", testFindByIDs},
+		{"Update", testUpdate},
+		{"UpdateStaleVersion", testUpdateStaleVersion},
+		{"UpdateNotFound", testUpdateNotFound},
+		{"UpdateDuplicateName", testUpdateDuplicateName},
+		{"Delete", testDelete},
+		{"DeleteNotFound", testDeleteNotFound},
+		{"ExistsByName", testExistsByName},
+		{"ConcurrentCreates", testConcurrentCreates},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func mustCreate(t *testing.T, repo repository.PersonalityRepository, name string) *models.Personality {
+	t.Helper()
+	p := &models.Personality{Name: name, History: "História de " + name}
+	if err := repo.Create(context.Background(), p); err != nil {
+		t.Fatalf("Erro ao criar %q: %v", name, err)
+	}
+	return p
+}
+
+func testCreateAssignsIDAndTimestamps(t *testing.T, repo repository.PersonalityRepository) {
+	p := mustCreate(t, repo, "Ada Lovelace")
+
+	if p.ID == 0 {
+		t.Error("Esperava ID atribuído na criação")
+	}
+	if p.CreatedAt.IsZero() || p.UpdatedAt.IsZero() {
+		t.Error("Esperava CreatedAt e UpdatedAt preenchidos")
+	}
+
+	found, err := repo.FindByID(context.Background(), p.ID)
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if found.Version != 1 {
+		t.Errorf("Esperava versão 1 na criação, obteve %d", found.Version)
+	}
+}
+
+func testCreateDuplicateName(t *testing.T, repo repository.PersonalityRepository) {
+	mustCreate(t, repo, "Ada Lovelace")
+
+	err := repo.Create(context.Background(), &models.Personality{Name: "Ada Lovelace", History: "Outra"})
+	if !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Errorf("Esperava gorm.ErrDuplicatedKey, obteve %v", err)
+	}
+}
+
+func testFindByID(t *testing.T, repo repository.PersonalityRepository) {
+	created := mustCreate(t, repo, "Alan Turing")
+
+	found, err := repo.FindByID(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if found.Name != created.Name || found.History != created.History {
+		t.Errorf("Esperava %+v, obteve %+v", created, found)
+	}
+
+	// Alterar o resultado não pode afetar o armazenamento
+	found.Name = "Alterado"
+	again, err := repo.FindByID(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if again.Name != "Alan Turing" {
+		t.Errorf("Esperava nome preservado, obteve %q", again.Name)
+	}
+}
+
+func testFindByIDNotFound(t *testing.T, repo repository.PersonalityRepository) {
+	_, err := repo.FindByID(context.Background(), 999)
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Esperava gorm.ErrRecordNotFound, obteve %v", err)
+	}
+}
+
+func testFindAllOrderedByID(t *testing.T, repo repository.PersonalityRepository) {
+	all, err := repo.FindAll(context.Background())
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(all) != 0 {
+		t.Fatalf("Esperava repositório vazio, obteve %d registros", len(all))
+	}
+
+	names := []string{"Grace Hopper", "Ada Lovelace", "Alan Turing"}
+	for _, name := range names {
+		mustCreate(t, repo, name)
+	}
+
+	all, err = repo.FindAll(context.Background())
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(all) != len(names) {
+		t.Fatalf("Esperava %d registros, obteve %d", len(names), len(all))
+	}
+	for i, p := range all {
+		if p.Name != names[i] {
+			t.Errorf("Posição %d: esperava %q, obteve %q", i, names[i], p.Name)
+		}
+		if i > 0 && all[i-1].ID >= p.ID {
+			t.Errorf("Esperava IDs em ordem crescente, obteve %d antes de %d", all[i-1].ID, p.ID)
+		}
+	}
+}
+
+func testFindPage(t *testing.T, repo repository.PersonalityRepository) {
+	names := []string{"Grace Hopper", "Ada Lovelace", "Alan Turing", "Katherine Johnson", "Margaret Hamilton"}
+	for _, name := range names {
+		mustCreate(t, repo, name)
+	}
+
+	tests := []struct {
+		offset, limit int
+		want          []string
+	}{
+		{0, 2, names[0:2]},
+		{2, 2, names[2:4]},
+		{4, 2, names[4:]},
+		{10, 2, nil},
+		// Offset transbordado por uma página enorme não pode derrubar a consulta
+		{-5, 2, names[0:2]},
+	}
+	for _, tt := range tests {
+		page, total, err := repo.FindPage(context.Background(), repository.PersonalityFilter{}, tt.offset, tt.limit)
+		if err != nil {
+			t.Fatalf("Erro inesperado: %v", err)
+		}
+		if total != int64(len(names)) {
+			t.Errorf("offset %d: esperava total %d, obteve %d", tt.offset, len(names), total)
+		}
+		if len(page) != len(tt.want) {
+			t.Fatalf("offset %d: esperava %d registros, obteve %d", tt.offset, len(tt.want), len(page))
+		}
+		for i, p := range page {
+			if p.Name != tt.want[i] {
+				t.Errorf("offset %d, posição %d: esperava %q, obteve %q", tt.offset, i, tt.want[i], p.Name)
+			}
+		}
+	}
+}
+
+func testFindPageFiltered(t *testing.T, repo repository.PersonalityRepository) {
+	for _, name := range []string{"Grace Hopper", "Ada Lovelace", "Katherine Johnson", "Margaret Hamilton"} {
+		mustCreate(t, repo, name)
+	}
+
+	tests := []struct {
+		name   string
+		filter repository.PersonalityFilter
+		want   []string
+	}{
+		{"trecho do nome", repository.PersonalityFilter{Name: "LOVE"}, []string{"Ada Lovelace"}},
+		{"texto no nome ou na história", repository.PersonalityFilter{Text: "de margaret"}, []string{"Margaret Hamilton"}},
+		{"curinga é literal", repository.PersonalityFilter{Name: "%"}, nil},
+		{"alteradas desde", repository.PersonalityFilter{UpdatedSince: time.Now().Add(time.Hour)}, nil},
+		{"filtros combinados", repository.PersonalityFilter{Name: "a", Text: "hopper", UpdatedSince: time.Now().Add(-time.Hour)}, []string{"Grace Hopper"}},
+	}
+	for _, tt := range tests {
+		page, total, err := repo.FindPage(context.Background(), tt.filter, 0, 10)
+		if err != nil {
+			t.Fatalf("%s: erro inesperado: %v", tt.name, err)
+		}
+		if total != int64(len(tt.want)) || len(page) != len(tt.want) {
+			t.Fatalf("%s: esperava %v, obteve %d registros de um total de %d", tt.name, tt.want, len(page), total)
+		}
+		for i, p := range page {
+			if p.Name != tt.want[i] {
+				t.Errorf("%s, posição %d: esperava %q, obteve %q", tt.name, i, tt.want[i], p.Name)
+			}
+		}
+	}
+}
+
+// testFindPageOnPrimaryRead repete a consulta filtrada com leitura no primário
+// (read-your-writes), em que a contagem e a busca partem da mesma conexão
+func testFindPageOnPrimaryRead(t *testing.T, repo repository.PersonalityRepository) {
+	for _, name := range []string{"Grace Hopper", "Ada Lovelace", "Alan Turing", "Katherine Johnson"} {
+		mustCreate(t, repo, name)
+	}
+
+	ctx := reqctx.WithPrimaryRead(context.Background())
+	page, total, err := repo.FindPage(ctx, repository.PersonalityFilter{Name: "a"}, 1, 2)
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	want := []string{"Ada Lovelace", "Alan Turing"}
+	if total != 4 || len(page) != len(want) {
+		t.Fatalf("Esperava %v de um total de 4, obteve %d registros de um total de %d", want, len(page), total)
+	}
+	for i, p := range page {
+		if p.Name != want[i] {
+			t.Errorf("Posição %d: esperava %q, obteve %q", i, want[i], p.Name)
+		}
+	}
+}
+
+func testFindByIDs(t *testing.T, repo repository.PersonalityRepository) {
+	grace := mustCreate(t, repo, "Grace Hopper")
+	mustCreate(t, repo, "Ada Lovelace")
+	alan := mustCreate(t, repo, "Alan Turing")
+
+	found, err := repo.FindByIDs(context.Background(), []uint{alan.ID, 999, grace.ID, alan.ID})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(found) != 2 || found[0].ID != grace.ID || found[1].ID != alan.ID {
+		t.Errorf("Esperava Grace Hopper e Alan Turing em ordem de ID, obteve %+v", found)
+	}
+
+	empty, err := repo.FindByIDs(context.Background(), nil)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("Sem IDs esperava lista vazia, obteve %v (erro: %v)", empty, err)
+	}
+}
+
+func testUpdate(t *testing.T, repo repository.PersonalityRepository) {
+	p := mustCreate(t, repo, "Grace Hopper")
+
+	p.Name = "Grace Brewster Hopper"
+	p.History = "Nova história"
+	if err := repo.Update(context.Background(), p); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+
+	found, err := repo.FindByID(context.Background(), p.ID)
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if found.Name != "Grace Brewster Hopper" || found.History != "Nova história" {
+		t.Errorf("Atualização não persistida: %+v", found)
+	}
+	if found.Version != 2 || p.Version != 2 {
+		t.Errorf("Esperava versão 2 após a atualização, obteve %d (retornada %d)", found.Version, p.Version)
+	}
+	// O Postgres guarda microssegundos; a diferença de precisão não conta
+	if p.UpdatedAt.IsZero() || p.UpdatedAt.Sub(found.UpdatedAt).Abs() >= time.Microsecond {
+		t.Errorf("Esperava UpdatedAt retornado igual ao persistido: %v != %v", p.UpdatedAt, found.UpdatedAt)
+	}
+	if found.UpdatedAt.Before(found.CreatedAt) {
+		t.Errorf("UpdatedAt (%v) anterior a CreatedAt (%v)", found.UpdatedAt, found.CreatedAt)
+	}
+}
+
+// testUpdateStaleVersion atualiza uma cópia lida antes de outra escrita: a
+// versão devolvida deve ser a gravada, não a da cópia mais um
+func testUpdateStaleVersion(t *testing.T, repo repository.PersonalityRepository) {
+	p := mustCreate(t, repo, "Grace Hopper")
+	stale := *p
+
+	p.History = "Primeira atualização"
+	if err := repo.Update(context.Background(), p); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	stale.History = "Segunda atualização"
+	if err := repo.Update(context.Background(), &stale); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+
+	found, err := repo.FindByID(context.Background(), p.ID)
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if found.Version != 3 || stale.Version != 3 {
+		t.Errorf("Esperava versão 3 após duas atualizações, obteve %d (retornada %d)", found.Version, stale.Version)
+	}
+}
+
+func testUpdateNotFound(t *testing.T, repo repository.PersonalityRepository) {
+	err := repo.Update(context.Background(), &models.Personality{ID: 999, Name: "Ninguém", History: "Nada"})
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Esperava gorm.ErrRecordNotFound, obteve %v", err)
+	}
+}
+
+func testUpdateDuplicateName(t *testing.T, repo repository.PersonalityRepository) {
+	mustCreate(t, repo, "Ada Lovelace")
+	p := mustCreate(t, repo, "Alan Turing")
+
+	p.Name = "Ada Lovelace"
+	if err := repo.Update(context.Background(), p); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Errorf("Esperava gorm.ErrDuplicatedKey, obteve %v", err)
+	}
+}
+
+func testDelete(t *testing.T, repo repository.PersonalityRepository) {
+	p := mustCreate(t, repo, "Alan Turing")
+
+	if err := repo.Delete(context.Background(), p.ID); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if _, err := repo.FindByID(context.Background(), p.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Esperava registro removido, obteve %v", err)
+	}
+}
+
+func testDeleteNotFound(t *testing.T, repo repository.PersonalityRepository) {
+	if err := repo.Delete(context.Background(), 999); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Esperava gorm.ErrRecordNotFound, obteve %v", err)
+	}
+}
+
+func testExistsByName(t *testing.T, repo repository.PersonalityRepository) {
+	mustCreate(t, repo, "Ada Lovelace")
+
+	exists, err := repo.ExistsByName(context.Background(), "Ada Lovelace")
+	if err != nil || !exists {
+		t.Errorf("Esperava nome existente, obteve %v (erro: %v)", exists, err)
+	}
+
+	exists, err = repo.ExistsByName(context.Background(), "Charles Babbage")
+	if err != nil || exists {
+		t.Errorf("Esperava nome inexistente, obteve %v (erro: %v)", exists, err)
+	}
+}
+
+func testConcurrentCreates(t *testing.T, repo repository.PersonalityRepository) {
+	const workers = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p := &models.Personality{Name: fmt.Sprintf("Pessoa %d", i), History: "Concorrente"}
+			errs <- repo.Create(context.Background(), p)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Erro inesperado em criação concorrente: %v", err)
+		}
+	}
+
+	all, err := repo.FindAll(context.Background())
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(all) != workers {
+		t.Errorf("Esperava %d registros, obteve %d", workers, len(all))
+	}
+}
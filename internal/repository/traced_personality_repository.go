@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"go-api-rest/internal/tracing"
+	"go-api-rest/models"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedPersonalityRepository cria um span filho para cada chamada ao repositório
+type tracedPersonalityRepository struct {
+	next   PersonalityRepository
+	tracer trace.Tracer
+}
+
+// NewTracedPersonalityRepository envolve um PersonalityRepository com spans do OpenTelemetry
+func NewTracedPersonalityRepository(next PersonalityRepository, provider trace.TracerProvider) PersonalityRepository {
+	return &tracedPersonalityRepository{next: next, tracer: tracing.Tracer(provider)}
+}
+
+func (r *tracedPersonalityRepository) Create(ctx context.Context, personality *models.Personality) (err error) {
+	ctx, span := r.tracer.Start(ctx, "PersonalityRepository.Create")
+	defer func() { tracing.End(span, err) }()
+	return r.next.Create(ctx, personality)
+}
+
+func (r *tracedPersonalityRepository) FindAll(ctx context.Context) (result []models.Personality, err error) {
+	ctx, span := r.tracer.Start(ctx, "PersonalityRepository.FindAll")
+	defer func() {
+		span.SetAttributes(attribute.Int("personality.count", len(result)))
+		tracing.End(span, err)
+	}()
+	return r.next.FindAll(ctx)
+}
+
+func (r *tracedPersonalityRepository) FindPage(ctx context.Context, filter PersonalityFilter, offset, limit int) (result []models.Personality, total int64, err error) {
+	ctx, span := r.tracer.Start(ctx, "PersonalityRepository.FindPage", trace.WithAttributes(
+		attribute.Int("page.offset", offset),
+		attribute.Int("page.limit", limit),
+	))
+	defer func() {
+		span.SetAttributes(attribute.Int("personality.count", len(result)), attribute.Int64("personality.total", total))
+		tracing.End(span, err)
+	}()
+	return r.next.FindPage(ctx, filter, offset, limit)
+}
+
+func (r *tracedPersonalityRepository) FindByID(ctx context.Context, id uint) (result *models.Personality, err error) {
+	ctx, span := r.tracer.Start(ctx, "PersonalityRepository.FindByID", trace.WithAttributes(attribute.Int("personality.id", int(id))))
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *tracedPersonalityRepository) FindByIDs(ctx context.Context, ids []uint) (result []models.Personality, err error) {
+	ctx, span := r.tracer.Start(ctx, "PersonalityRepository.FindByIDs", trace.WithAttributes(attribute.Int("personality.requested", len(ids))))
+	defer func() {
+		span.SetAttributes(attribute.Int("personality.count", len(result)))
+		tracing.End(span, err)
+	}()
+	return r.next.FindByIDs(ctx, ids)
+}
+
+func (r *tracedPersonalityRepository) Update(ctx context.Context, personality *models.Personality) (err error) {
+	ctx, span := r.tracer.Start(ctx, "PersonalityRepository.Update", trace.WithAttributes(attribute.Int("personality.id", int(personality.ID))))
+	defer func() { tracing.End(span, err) }()
+	return r.next.Update(ctx, personality)
+}
+
+func (r *tracedPersonalityRepository) Delete(ctx context.Context, id uint) (err error) {
+	ctx, span := r.tracer.Start(ctx, "PersonalityRepository.Delete", trace.WithAttributes(attribute.Int("personality.id", int(id))))
+	defer func() { tracing.End(span, err) }()
+	return r.next.Delete(ctx, id)
+}
+
+func (r *tracedPersonalityRepository) ExistsByName(ctx context.Context, name string) (exists bool, err error) {
+	ctx, span := r.tracer.Start(ctx, "PersonalityRepository.ExistsByName")
+	defer func() {
+		span.SetAttributes(attribute.Bool("personality.exists", exists))
+		tracing.End(span, err)
+	}()
+	return r.next.ExistsByName(ctx, name)
+}
@@ -0,0 +1,435 @@
+package router
+
+import (
+	"fmt"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/graph"
+	"go-api-rest/internal/handler"
+	"go-api-rest/internal/health"
+	"go-api-rest/pkg/openapi"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/response"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Caminhos da documentação
+const (
+	openAPIPath = "/openapi.json"
+	docsPath    = "/docs"
+	graphQLPath = "/graphql"
+	eventsPath  = "/api/personalities/events"
+)
+
+// apiDocument descreve as rotas registradas em SetupRoutes. Rotas opcionais
+// só entram no documento quando habilitadas; TestOpenAPI_DocumentsAllRoutes
+// falha quando uma rota registrada não está descrita aqui.
+func apiDocument(deps Dependencies) *openapi.Document {
+	g := openapi.New(openapi.Info{
+		Title:   "API REST de Personalidades",
+		Version: "1.0.0",
+		Description: "Cadastro de personalidades históricas. Toda rota GET também atende HEAD. " +
+			"As rotas de personalidades existem em /api/v1, descontinuada, e /api/v2; em /api/personalities " +
+			"a versão é escolhida pelo Accept.",
+	})
+	g.AddTag("personalidades", "Cadastro de personalidades")
+	g.AddTag("operacional", "Saúde, métricas e documentação")
+	if deps.GraphQL != nil {
+		g.AddTag("graphql", "Consultas e mutations GraphQL sobre as personalidades")
+	}
+
+	d := docBuilder{g: g, problem: g.Schema(problem.Problem{})}
+
+	g.Add(http.MethodGet, "/", &openapi.Operation{
+		OperationID: "home",
+		Summary:     "Mensagem de boas-vindas",
+		Tags:        []string{"operacional"},
+		Parameters:  d.localized(),
+		Responses: d.responses(map[int]*openapi.Response{
+			http.StatusOK: d.negotiated("Mensagem de boas-vindas no idioma negociado", g.Schema(dto.SuccessResponse{}), dto.SuccessResponse{}),
+		}, http.StatusNotAcceptable),
+	})
+
+	g.Add(http.MethodGet, "/healthz", &openapi.Operation{
+		OperationID: "liveness",
+		Summary:     "Liveness: o processo está atendendo",
+		Tags:        []string{"operacional"},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "Processo vivo", Content: map[string]*openapi.MediaType{"application/json": {Schema: &openapi.Schema{
+				Type: "object", Properties: map[string]*openapi.Schema{"status": {Type: "string"}}, Required: []string{"status"},
+			}}}},
+		},
+	})
+	g.Add(http.MethodGet, "/readyz", d.healthOperation("readiness", "Readiness: checks críticos", g.Schema(health.Report{})))
+	g.Add(http.MethodGet, "/health", d.healthOperation("healthReport", "Relatório de todos os checks", g.Schema(health.Report{})))
+
+	for _, group := range apiGroups(deps.Config.Versioning.DefaultVersion) {
+		d.personalityOperations(group, deps.Config.Versioning.DefaultVersion)
+	}
+
+	if deps.Events != nil {
+		d.eventsOperation()
+	}
+
+	if deps.GraphQL != nil {
+		d.graphQLOperations(deps.GraphQL.Playground())
+	}
+
+	if deps.Metrics != nil {
+		g.Add(http.MethodGet, "/metrics", &openapi.Operation{
+			OperationID: "metrics",
+			Summary:     "Métricas no formato Prometheus",
+			Tags:        []string{"operacional"},
+			Responses: map[string]*openapi.Response{
+				"200": {Description: "Métricas em texto", Content: map[string]*openapi.MediaType{
+					"text/plain": {Schema: &openapi.Schema{Type: "string"}},
+				}},
+			},
+		})
+	}
+
+	if deps.Config.Server.AdminToken != "" {
+		g.AddSecurityScheme("adminToken", &openapi.SecurityScheme{Type: "http", Scheme: "bearer", Description: "ADMIN_TOKEN da aplicação"})
+		level := g.SchemaNamed("LogLevel", struct {
+			Level string `json:"level" validate:"required,oneof=debug info warn error"`
+		}{})
+		levelResponse := &openapi.Response{Description: "Nível de log atual", Content: map[string]*openapi.MediaType{
+			"application/json": {Schema: level},
+		}}
+		admin := []map[string][]string{{"adminToken": {}}}
+		g.Add(http.MethodGet, "/admin/log-level", &openapi.Operation{
+			OperationID: "getLogLevel",
+			Summary:     "Consulta o nível de log",
+			Tags:        []string{"operacional"},
+			Security:    admin,
+			Responses:   d.responses(map[int]*openapi.Response{http.StatusOK: levelResponse}, http.StatusUnauthorized),
+		})
+		g.Add(http.MethodPut, "/admin/log-level", &openapi.Operation{
+			OperationID: "setLogLevel",
+			Summary:     "Altera o nível de log em tempo de execução",
+			Tags:        []string{"operacional"},
+			Security:    admin,
+			RequestBody: &openapi.RequestBody{Required: true, Content: map[string]*openapi.MediaType{"application/json": {Schema: level}}},
+			Responses: d.responses(map[int]*openapi.Response{
+				http.StatusOK:         levelResponse,
+				http.StatusBadRequest: {Description: "Nível inválido", Content: map[string]*openapi.MediaType{"application/json": {Schema: &openapi.Schema{Type: "object"}}}},
+			}, http.StatusUnauthorized),
+		})
+	}
+
+	g.Add(http.MethodGet, openAPIPath, &openapi.Operation{
+		OperationID: "openapi",
+		Summary:     "Este documento OpenAPI",
+		Tags:        []string{"operacional"},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "Documento OpenAPI 3.1", Content: map[string]*openapi.MediaType{"application/json": {Schema: &openapi.Schema{Type: "object"}}}},
+		},
+	})
+	g.Add(http.MethodGet, docsPath, &openapi.Operation{
+		OperationID: "docs",
+		Summary:     "Documentação navegável",
+		Tags:        []string{"operacional"},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "Página HTML", Content: map[string]*openapi.MediaType{"text/html": {Schema: &openapi.Schema{Type: "string"}}}},
+		},
+	})
+
+	doc := g.Document()
+	if deps.Config.RateLimit.Enabled {
+		// O limitador é global e pode responder 429 em qualquer rota
+		for _, route := range doc.Routes() {
+			op := doc.Paths[route.Path].Operation(route.Method)
+			if _, ok := op.Responses["429"]; !ok {
+				op.Responses["429"] = d.problemResponse(http.StatusTooManyRequests)
+			}
+		}
+	}
+	return doc
+}
+
+// docBuilder reúne as partes repetidas das operações
+type docBuilder struct {
+	g       *openapi.Generator
+	problem *openapi.Schema
+}
+
+// errorDescriptions explica cada status de erro das rotas
+var errorDescriptions = map[int]string{
+	http.StatusBadRequest:            "ID, corpo ou dados inválidos",
+	http.StatusUnauthorized:          "Token ausente ou inválido",
+	http.StatusNotFound:              "Personalidade não encontrada",
+	http.StatusMethodNotAllowed:      "Mutation enviada pelo GET",
+	http.StatusNotAcceptable:         "Nenhum formato do Accept está disponível",
+	http.StatusConflict:              "Já existe uma personalidade com esse nome",
+	http.StatusRequestEntityTooLarge: "Corpo acima do limite configurado",
+	http.StatusUnsupportedMediaType:  "Content-Type ou Content-Encoding não suportado",
+	http.StatusTooManyRequests:       "Limite de requisições excedido",
+	http.StatusInternalServerError:   "Erro interno",
+}
+
+// responses completa as respostas de sucesso com os erros informados e o 500
+func (d docBuilder) responses(success map[int]*openapi.Response, errors ...int) map[string]*openapi.Response {
+	out := make(map[string]*openapi.Response)
+	for status, r := range success {
+		out[strconv.Itoa(status)] = r
+	}
+	for _, status := range append(errors, http.StatusInternalServerError) {
+		out[strconv.Itoa(status)] = d.problemResponse(status)
+	}
+	return out
+}
+
+func (d docBuilder) problemResponse(status int) *openapi.Response {
+	return &openapi.Response{
+		Description: errorDescriptions[status],
+		Content:     map[string]*openapi.MediaType{problem.ContentType: {Schema: d.problem}},
+	}
+}
+
+// negotiated lista os formatos do registro de codecs capazes de representar
+// ao menos um dos samples
+func (d docBuilder) negotiated(description string, schema *openapi.Schema, samples ...interface{}) *openapi.Response {
+	content := make(map[string]*openapi.MediaType)
+	for _, mediaType := range response.Registry().EncoderMediaTypes() {
+		for _, sample := range samples {
+			if _, err := response.Registry().Negotiate(mediaType, sample); err == nil {
+				content[mediaType] = &openapi.MediaType{Schema: schema}
+			}
+		}
+	}
+	return &openapi.Response{Description: description, Content: content}
+}
+
+// body aceita o DTO em todos os formatos de entrada registrados
+func (d docBuilder) body(schema *openapi.Schema) *openapi.RequestBody {
+	content := make(map[string]*openapi.MediaType)
+	for _, mediaType := range response.Registry().DecoderMediaTypes() {
+		content[mediaType] = &openapi.MediaType{Schema: schema}
+	}
+	return &openapi.RequestBody{Required: true, Content: content}
+}
+
+var responseHeaders = map[string]*openapi.Header{
+	"ETag":          {Description: "Validador da representação, para If-None-Match", Schema: &openapi.Schema{Type: "string"}},
+	"Last-Modified": {Description: "Data da última alteração, para If-Modified-Since", Schema: &openapi.Schema{Type: "string"}},
+	"API-Version":   {Description: "Versão da API que atendeu a requisição", Schema: &openapi.Schema{Type: "integer"}},
+	"Deprecation":   {Description: "Data em que a versão foi descontinuada (RFC 9745), ex: @1790812800", Schema: &openapi.Schema{Type: "string"}},
+	"Sunset":        {Description: "Data de remoção da versão (RFC 8594)", Schema: &openapi.Schema{Type: "string"}},
+	"Link":          {Description: "Links de paginação (first, prev, next, last) e, na v1, a rota equivalente da v2 (successor-version)", Schema: &openapi.Schema{Type: "string"}},
+}
+
+func (d docBuilder) withHeaders(r *openapi.Response, names ...string) *openapi.Response {
+	r.Headers = make(map[string]*openapi.Header)
+	for _, name := range names {
+		r.Headers[name] = responseHeaders[name]
+	}
+	return r
+}
+
+// localized declara o Accept-Language usado nas mensagens
+func (d docBuilder) localized() []*openapi.Parameter {
+	return []*openapi.Parameter{{
+		Name: "Accept-Language", In: "header", Description: "Idioma das mensagens: pt-BR (padrão), en ou es",
+		Schema: &openapi.Schema{Type: "string"},
+	}}
+}
+
+// conditional declara os cabeçalhos de GET condicional
+func (d docBuilder) conditional() []*openapi.Parameter {
+	return []*openapi.Parameter{
+		{Name: "If-None-Match", In: "header", Description: "ETags conhecidos; responde 304 se algum ainda vale", Schema: &openapi.Schema{Type: "string"}},
+		{Name: "If-Modified-Since", In: "header", Description: "Responde 304 se não houve alteração desde a data", Schema: &openapi.Schema{Type: "string"}},
+	}
+}
+
+// personalityOperations documenta as rotas de personalidades de um grupo de
+// versão. Nas rotas sem versão as respostas aceitam o formato de qualquer
+// versão, com oneOf, e defaultVersion é a usada quando o Accept não pede uma.
+func (d docBuilder) personalityOperations(group apiGroup, defaultVersion int) {
+	g := d.g
+	v1, v2 := g.Schema(dto.PersonalityResponse{}), g.Schema(dto.PersonalityEnvelope{})
+	v1List, v2List := &openapi.Schema{Type: "array", Items: v1}, g.Schema(dto.PersonalityListEnvelope{})
+	var item, list *openapi.Schema
+	var itemSamples, listSamples []interface{}
+	suffix := ""
+	params := d.localized()
+	headers := []string{"API-Version"}
+	switch group.number {
+	case 1:
+		item, list, suffix = v1, v1List, "V1"
+		itemSamples, listSamples = []interface{}{dto.PersonalityResponse{}}, []interface{}{[]dto.PersonalityResponse{}}
+		headers = append(headers, "Deprecation", "Sunset", "Link")
+	case 2:
+		item, list, suffix = v2, v2List, "V2"
+		itemSamples, listSamples = []interface{}{dto.PersonalityEnvelope{}}, []interface{}{dto.PersonalityListEnvelope{}}
+	default:
+		item, list = &openapi.Schema{OneOf: []*openapi.Schema{v1, v2}}, &openapi.Schema{OneOf: []*openapi.Schema{v1List, v2List}}
+		itemSamples = []interface{}{dto.PersonalityResponse{}, dto.PersonalityEnvelope{}}
+		listSamples = []interface{}{[]dto.PersonalityResponse{}, dto.PersonalityListEnvelope{}}
+		headers = append(headers, "Deprecation", "Sunset", "Link")
+		params = append(params, &openapi.Parameter{
+			Name: "Accept", In: "header",
+			Description: fmt.Sprintf("Formato e versão, ex: application/vnd.go-api-rest.v2+json ou application/json; version=2. "+
+				"Sem versão vale a v%d", defaultVersion),
+			Schema: &openapi.Schema{Type: "string"},
+		})
+	}
+	versionNote := map[int]string{
+		1: "Versão descontinuada; use a rota equivalente da v2.",
+		2: "Recursos são devolvidos em {\"data\": ...}.",
+		0: "Formato da versão negociada pelo Accept.",
+	}[group.number]
+	idParam := &openapi.Parameter{
+		Name: "id", In: "path", Required: true, Description: "ID da personalidade",
+		Schema: &openapi.Schema{Type: "integer", Minimum: float(1), Maximum: float(1<<32 - 1)},
+	}
+	withID := func(params []*openapi.Parameter) []*openapi.Parameter {
+		return append([]*openapi.Parameter{idParam}, params...)
+	}
+	add := func(method, path string, op *openapi.Operation) {
+		op.OperationID += suffix
+		op.Tags = []string{"personalidades"}
+		op.Description = strings.TrimSpace(op.Description + " " + versionNote)
+		op.Deprecated = group.number == 1
+		g.Add(method, group.prefix+path, op)
+	}
+
+	listParams := append(append([]*openapi.Parameter{}, params...), d.conditional()...)
+	listDescription := ""
+	if group.number != 1 {
+		listDescription = "Na v2 a lista é paginada, com metadados em meta e links em links e no cabeçalho Link."
+		listParams = append(listParams,
+			&openapi.Parameter{Name: "page", In: "query", Description: "Página, de 1 a 1000000 (v2)", Schema: &openapi.Schema{Type: "integer", Minimum: float(1), Maximum: float(1000000)}},
+			&openapi.Parameter{Name: "per_page", In: "query", Description: "Itens por página, até 100; padrão 20 (v2)", Schema: &openapi.Schema{Type: "integer", Minimum: float(1), Maximum: float(100)}},
+		)
+	}
+	add(http.MethodGet, "", &openapi.Operation{
+		OperationID: "listPersonalities",
+		Summary:     "Lista as personalidades",
+		Description: listDescription,
+		Parameters:  listParams,
+		Responses: d.responses(map[int]*openapi.Response{
+			http.StatusOK:          d.withHeaders(d.negotiated("Lista de personalidades", list, listSamples...), append(headers, "ETag")...),
+			http.StatusNotModified: {Description: "A lista não mudou desde o ETag informado"},
+		}, http.StatusBadRequest, http.StatusNotAcceptable),
+	})
+	add(http.MethodPost, "", &openapi.Operation{
+		OperationID: "createPersonality",
+		Summary:     "Cria uma personalidade",
+		Parameters:  params,
+		RequestBody: d.body(g.Schema(dto.CreatePersonalityRequest{})),
+		Responses: d.responses(map[int]*openapi.Response{
+			http.StatusCreated: d.withHeaders(d.negotiated("Personalidade criada", item, itemSamples...), append(headers, "ETag", "Last-Modified")...),
+		}, http.StatusBadRequest, http.StatusNotAcceptable, http.StatusConflict, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType),
+	})
+	add(http.MethodGet, "/{id:[0-9]+}", &openapi.Operation{
+		OperationID: "getPersonality",
+		Summary:     "Busca uma personalidade",
+		Parameters:  withID(append(append([]*openapi.Parameter{}, params...), d.conditional()...)),
+		Responses: d.responses(map[int]*openapi.Response{
+			http.StatusOK:          d.withHeaders(d.negotiated("Personalidade encontrada", item, itemSamples...), append(headers, "ETag", "Last-Modified")...),
+			http.StatusNotModified: {Description: "A personalidade não mudou desde o ETag ou a data informados"},
+		}, http.StatusBadRequest, http.StatusNotFound, http.StatusNotAcceptable),
+	})
+	add(http.MethodPut, "/{id:[0-9]+}", &openapi.Operation{
+		OperationID: "updatePersonality",
+		Summary:     "Atualiza uma personalidade",
+		Description: "Campos vazios mantêm o valor atual; ao menos um campo deve ser informado.",
+		Parameters:  withID(params),
+		RequestBody: d.body(g.Schema(dto.UpdatePersonalityRequest{})),
+		Responses: d.responses(map[int]*openapi.Response{
+			http.StatusOK: d.withHeaders(d.negotiated("Personalidade atualizada", item, itemSamples...), append(headers, "ETag", "Last-Modified")...),
+		}, http.StatusBadRequest, http.StatusNotFound, http.StatusNotAcceptable, http.StatusConflict, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType),
+	})
+	add(http.MethodDelete, "/{id:[0-9]+}", &openapi.Operation{
+		OperationID: "deletePersonality",
+		Summary:     "Remove uma personalidade",
+		Parameters:  withID(params),
+		Responses: d.responses(map[int]*openapi.Response{
+			http.StatusNoContent: d.withHeaders(&openapi.Response{Description: "Personalidade removida"}, headers...),
+		}, http.StatusBadRequest, http.StatusNotFound, http.StatusNotAcceptable),
+	})
+}
+
+// graphQLOperations documenta o endpoint GraphQL. Erros da consulta voltam
+// com status 200 no campo errors; os problemas cobrem requisições que não
+// chegam a ser executadas.
+func (d docBuilder) graphQLOperations(playground bool) {
+	g := d.g
+	result := &openapi.Response{Description: "Resultado da operação, com data e errors", Content: map[string]*openapi.MediaType{
+		"application/json": {Schema: g.SchemaNamed("GraphQLResponse", graph.Response{})},
+	}}
+
+	get := &openapi.Operation{
+		OperationID: "graphqlQuery",
+		Summary:     "Executa uma query GraphQL",
+		Description: "Mutations enviadas pelo GET recebem 405.",
+		Tags:        []string{"graphql"},
+		Parameters: append([]*openapi.Parameter{
+			{Name: "query", In: "query", Description: "Documento GraphQL", Schema: &openapi.Schema{Type: "string"}},
+			{Name: "operationName", In: "query", Description: "Operação a executar quando o documento tem mais de uma", Schema: &openapi.Schema{Type: "string"}},
+			{Name: "variables", In: "query", Description: "Variáveis em JSON", Schema: &openapi.Schema{Type: "string"}},
+		}, d.localized()...),
+		Responses: d.responses(map[int]*openapi.Response{http.StatusOK: result}, http.StatusBadRequest, http.StatusMethodNotAllowed),
+	}
+	if playground {
+		get.Description += " Sem query e com Accept text/html, responde o GraphiQL."
+		get.Responses["200"] = &openapi.Response{Description: result.Description + ", ou o GraphiQL", Content: map[string]*openapi.MediaType{
+			"application/json": result.Content["application/json"],
+			"text/html":        {Schema: &openapi.Schema{Type: "string"}},
+		}}
+	}
+	g.Add(http.MethodGet, graphQLPath, get)
+
+	g.Add(http.MethodPost, graphQLPath, &openapi.Operation{
+		OperationID: "graphqlExecute",
+		Summary:     "Executa uma query ou mutation GraphQL",
+		Tags:        []string{"graphql"},
+		Parameters:  d.localized(),
+		RequestBody: &openapi.RequestBody{Required: true, Content: map[string]*openapi.MediaType{
+			"application/json": {Schema: g.SchemaNamed("GraphQLRequest", graph.Request{})},
+		}},
+		Responses: d.responses(map[int]*openapi.Response{http.StatusOK: result},
+			http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType),
+	})
+}
+
+// eventsOperation documenta o feed de alterações. Cada evento traz id, o nome
+// do tipo em event e o PersonalityEvent em JSON em data.
+func (d docBuilder) eventsOperation() {
+	g := d.g
+	event := g.Schema(dto.PersonalityEvent{})
+	g.Add(http.MethodGet, eventsPath, &openapi.Operation{
+		OperationID: "streamPersonalityEvents",
+		Summary:     "Acompanha as alterações nas personalidades",
+		Description: "Stream Server-Sent Events com os eventos created, updated e deleted. Com Last-Event-ID os eventos " +
+			"perdidos são reenviados; quando já não estão disponíveis o stream começa com o evento reset, e o " +
+			"estado deve ser reconsultado. Comentários periódicos mantêm a conexão aberta.",
+		Tags: []string{"personalidades"},
+		Parameters: append([]*openapi.Parameter{
+			{Name: "id", In: "query", Description: "IDs acompanhados, repetidos ou separados por vírgula; sem IDs, todos", Schema: &openapi.Schema{Type: "string", Pattern: `^[0-9]+(,[0-9]+)*$`}},
+			{Name: "Last-Event-ID", In: "header", Description: "ID do último evento recebido, enviado pelo EventSource ao reconectar", Schema: &openapi.Schema{Type: "string"}},
+		}, d.localized()...),
+		Responses: d.responses(map[int]*openapi.Response{
+			http.StatusOK: {Description: "Stream de eventos; o campo data de cada evento segue o schema", Content: map[string]*openapi.MediaType{
+				handler.EventStreamContentType: {Schema: event},
+			}},
+		}, http.StatusBadRequest),
+	})
+}
+
+func (d docBuilder) healthOperation(id, summary string, schema *openapi.Schema) *openapi.Operation {
+	content := map[string]*openapi.MediaType{"application/json": {Schema: schema}}
+	return &openapi.Operation{
+		OperationID: id,
+		Summary:     summary,
+		Tags:        []string{"operacional"},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "Aplicação saudável", Content: content},
+			"503": {Description: "Algum check crítico falhou", Content: content},
+		},
+	}
+}
+
+func float(n float64) *float64 { return &n }
@@ -0,0 +1,127 @@
+package router
+
+import (
+	"encoding/json"
+	"go-api-rest/internal/config"
+	"go-api-rest/internal/events"
+	"go-api-rest/internal/handler"
+	"go-api-rest/internal/metrics"
+	"go-api-rest/pkg/openapi"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestOpenAPI_DocumentsAllRoutes falha quando uma rota é registrada sem
+// descrição em apiDocument, ou quando o documento descreve uma rota inexistente
+func TestOpenAPI_DocumentsAllRoutes(t *testing.T) {
+	deps := newTestDependencies()
+	deps.Metrics = metrics.New()
+	deps.Config.Server.AdminToken = "segredo"
+	deps.GraphQL = newTestGraphQL(t, config.GraphQLConfig{Playground: true})
+	deps.Events = handler.NewPersonalityEventsHandler(events.NewBroker(1, 0), deps.Config.Events)
+	deps.Gateway = http.NotFoundHandler()
+	r := SetupRoutes(deps)
+	doc := apiDocument(deps)
+
+	registered := make(map[openapi.Route]bool)
+	gateway := false
+	err := r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil // preflight de CORS, registrado sem caminho
+		}
+		if template == "/rpc/" {
+			// As rotas do gateway gRPC são descritas no gateway.yaml, fora deste documento
+			gateway = true
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil // prefixo de subrouter
+		}
+		for _, method := range methods {
+			registered[openapi.Route{Method: method, Path: openapi.PathTemplate(template)}] = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Erro ao percorrer as rotas: %v", err)
+	}
+	if !gateway {
+		t.Error("Esperava o prefixo /rpc/ do gateway registrado")
+	}
+
+	for route := range registered {
+		method := route.Method
+		if method == http.MethodHead {
+			// HEAD segue a descrição do GET da mesma rota
+			method = http.MethodGet
+		}
+		item, ok := doc.Paths[route.Path]
+		if !ok || item.Operation(method) == nil {
+			t.Errorf("Rota registrada sem documentação: %s %s", route.Method, route.Path)
+		}
+	}
+	for _, route := range doc.Routes() {
+		if !registered[route] {
+			t.Errorf("Rota documentada mas não registrada: %s %s", route.Method, route.Path)
+		}
+	}
+}
+
+func TestOpenAPI_OptionalRoutesFollowConfig(t *testing.T) {
+	doc := apiDocument(newTestDependencies())
+	for _, path := range []string{"/metrics", "/admin/log-level", "/graphql", eventsPath} {
+		if _, ok := doc.Paths[path]; ok {
+			t.Errorf("%s não deveria ser documentada quando desabilitada", path)
+		}
+	}
+}
+
+func TestOpenAPI_Endpoints(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Esperava status 200, mas obteve %d", rec.Code)
+	}
+	var doc openapi.Document
+	if err := json.NewDecoder(rec.Body).Decode(&doc); err != nil {
+		t.Fatalf("Documento inválido: %v", err)
+	}
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("Esperava OpenAPI 3.1.0, obteve %s", doc.OpenAPI)
+	}
+
+	create := doc.Components.Schemas["CreatePersonalityRequest"]
+	if create == nil {
+		t.Fatal("Schema CreatePersonalityRequest ausente")
+	}
+	if strings.Join(create.Required, ",") != "name,history" {
+		t.Errorf("Campos obrigatórios inesperados: %v", create.Required)
+	}
+	name := create.Properties["name"]
+	if name.MinLength == nil || *name.MinLength != 3 || name.MaxLength == nil || *name.MaxLength != 100 {
+		t.Errorf("Esperava minLength 3 e maxLength 100 em name: %+v", name)
+	}
+	if len(name.AllOf) != 2 {
+		t.Errorf("Esperava os padrões de trimmed e personname em name: %+v", name)
+	}
+	if update := doc.Components.Schemas["UpdatePersonalityRequest"]; update == nil || len(update.Required) != 0 {
+		t.Errorf("UpdatePersonalityRequest não deveria ter campos obrigatórios: %+v", update)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html") {
+		t.Fatalf("Esperava página HTML, obteve %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Body.String(), `data-spec="/openapi.json"`) {
+		t.Error("A página de documentação deveria apontar para /openapi.json")
+	}
+}
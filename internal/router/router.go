@@ -1,32 +1,231 @@
 package router
 
 import (
+	"go-api-rest/internal/config"
+	"go-api-rest/internal/graph"
 	"go-api-rest/internal/handler"
+	"go-api-rest/internal/health"
+	"go-api-rest/internal/metrics"
 	"go-api-rest/internal/middleware"
+	"go-api-rest/internal/tracing"
+	"go-api-rest/pkg/logger"
+	"go-api-rest/pkg/openapi"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/response"
+	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Dependencies agrupa os componentes necessários para montar as rotas
+type Dependencies struct {
+	Config             *config.Config
+	PersonalityHandler *handler.PersonalityHandler
+	// RateLimitStore é opcional; quando nil é criado a partir da configuração
+	RateLimitStore middleware.RateLimitStore
+	// Metrics é opcional; quando informado habilita o endpoint /metrics
+	Metrics *metrics.Metrics
+	// TracerProvider é opcional; quando informado cria spans de servidor por requisição
+	TracerProvider trace.TracerProvider
+	// Health é opcional; quando nil os probes respondem sem checks de dependências
+	Health *health.Registry
+	// GraphQL é opcional; quando informado habilita o endpoint /graphql
+	GraphQL *graph.Handler
+	// Events é opcional; quando informado habilita o feed /api/personalities/events
+	Events *handler.PersonalityEventsHandler
+	// Gateway é opcional; quando informado atende as rotas /rpc/ traduzidas para gRPC
+	Gateway http.Handler
+}
+
 // SetupRoutes configura todas as rotas da aplicação
-func SetupRoutes(personalityHandler *handler.PersonalityHandler) *mux.Router {
+func SetupRoutes(deps Dependencies) *mux.Router {
 	r := mux.NewRouter()
+	personalityHandler := deps.PersonalityHandler
+	proxies := middleware.ParseTrustedProxies(deps.Config.Server.TrustedProxies)
+	cors, err := middleware.NewCORS(deps.Config.CORS)
+	if err != nil {
+		// Sem uma política válida nenhuma origem externa é aceita
+		logger.Error("Configuração de CORS inválida, recusando outras origens", "error", err)
+		cors, _ = middleware.NewCORS(config.CORSConfig{})
+	}
 
 	// Middlewares globais
-	r.Use(middleware.Recovery)
-	r.Use(middleware.Logging)
-	r.Use(middleware.CORS)
-	r.Use(middleware.ContentTypeJSON)
-
-	// Rotas da API
-	r.HandleFunc("/", personalityHandler.Home).Methods("GET")
-
-	// Rotas de personalidades
-	api := r.PathPrefix("/api/personalities").Subrouter()
-	api.HandleFunc("", personalityHandler.Create).Methods("POST")
-	api.HandleFunc("", personalityHandler.GetAll).Methods("GET")
-	api.HandleFunc("/{id:[0-9]+}", personalityHandler.GetByID).Methods("GET")
-	api.HandleFunc("/{id:[0-9]+}", personalityHandler.Update).Methods("PUT")
-	api.HandleFunc("/{id:[0-9]+}", personalityHandler.Delete).Methods("DELETE")
+	var middlewares []mux.MiddlewareFunc
+	if deps.TracerProvider != nil {
+		middlewares = append(middlewares, otelmux.Middleware(
+			deps.Config.Tracing.ServiceName,
+			otelmux.WithTracerProvider(deps.TracerProvider),
+			otelmux.WithPropagators(tracing.Propagator()),
+		))
+	}
+	middlewares = append(middlewares,
+		middleware.RequestID,
+		middleware.Locale,
+		middleware.AccessLog(proxies),
+	)
+	if deps.Metrics != nil {
+		middlewares = append(middlewares, middleware.Metrics(deps.Metrics))
+	}
+	middlewares = append(middlewares,
+		middleware.Recovery,
+		cors.Middleware,
+	)
+	if deps.Config.Compression.Enabled {
+		middlewares = append(middlewares, middleware.NewCompression(deps.Config.Compression).Middleware)
+	}
+	if db := deps.Config.Database; len(db.ReplicaDSNs) > 0 && db.ReadYourWritesWindow > 0 {
+		middlewares = append(middlewares, middleware.ReadYourWrites(db.ReadYourWritesWindow))
+	}
+	if deps.Config.RateLimit.Enabled {
+		middlewares = append(middlewares, newRateLimiter(deps, proxies).Middleware)
+	}
+	// Validação contra o contrato, após a compressão para ver os corpos originais
+	doc := apiDocument(deps)
+	middlewares = append(middlewares, middleware.ValidateContract(openapi.NewValidator(doc), deps.Config.Contract, deps.Config.Server.MaxBodyBytes))
+	r.Use(middlewares...)
+
+	// Respostas 404 e 405 também passam pelos middlewares para serem registradas
+	r.NotFoundHandler = chain(http.HandlerFunc(notFound), middlewares)
+	r.MethodNotAllowedHandler = chain(http.HandlerFunc(methodNotAllowed), middlewares)
+
+	// Políticas de Cache-Control por rota; erros sempre recebem no-store
+	httpCache := deps.Config.HTTPCache
+	noStore := middleware.CacheControl("no-store")
+	resourceCache := middleware.CacheControl(httpCache.ResourcePolicy)
+	collectionCache := middleware.CacheControl(httpCache.CollectionPolicy)
+
+	// Rotas da API; toda rota GET também atende HEAD
+	r.Handle("/", middleware.CacheControl("public, max-age=300")(http.HandlerFunc(personalityHandler.Home))).Methods("GET", "HEAD")
+
+	// Probes de liveness e readiness
+	healthRegistry := deps.Health
+	if healthRegistry == nil {
+		healthRegistry = health.NewRegistry(deps.Config.Health.CheckTimeout)
+	}
+	r.Handle("/healthz", noStore(healthRegistry.LivenessHandler())).Methods("GET", "HEAD")
+	r.Handle("/readyz", noStore(healthRegistry.ReadinessHandler())).Methods("GET", "HEAD")
+	r.Handle("/health", noStore(healthRegistry.ReportHandler())).Methods("GET", "HEAD")
+
+	// Corpos de escrita têm tamanho limitado e podem chegar comprimidos com gzip;
+	// o limite vale para o corpo recebido e o descomprimido tem o seu próprio
+	limitBody := middleware.LimitBody(deps.Config.Server.MaxBodyBytes)
+	decompressRequest := middleware.DecompressRequest(deps.Config.Compression.MaxDecompressedSize)
+	writeBody := func(h http.Handler) http.Handler { return limitBody(decompressRequest(h)) }
+
+	// Feed de alterações em Server-Sent Events, sem versão nem negociação de formato
+	if deps.Events != nil {
+		r.Handle(eventsPath, noStore(deps.Events)).Methods("GET", "HEAD")
+	}
+
+	// Rotas de personalidades, com a versão na URL ou negociada pelo Accept nas
+	// rotas sem versão; respostas da v1 anunciam a descontinuação e a rota da v2
+	// O Accept é conferido contra o tipo respondido por cada rota, antes dos
+	// efeitos colaterais; o DELETE não tem corpo e aceita qualquer formato
+	versioning := deps.Config.Versioning
+	resource := middleware.NegotiateContent(response.Registry(), handler.ResourceSample)
+	collection := middleware.NegotiateContent(response.Registry(), handler.CollectionSample)
+	anyFormat := middleware.NegotiateContent(response.Registry(), nil)
+	for _, group := range apiGroups(versioning.DefaultVersion) {
+		api := r.PathPrefix(group.prefix).Subrouter()
+		successor := func(r *http.Request) string {
+			return latestPrefix + strings.TrimPrefix(r.URL.Path, group.prefix)
+		}
+		api.Use(
+			group.version,
+			middleware.Deprecation(1, versioning.V1DeprecatedAt, versioning.V1Sunset, successor),
+		)
+		api.Handle("", writeBody(resource(http.HandlerFunc(personalityHandler.Create)))).Methods("POST")
+		api.Handle("", collectionCache(collection(http.HandlerFunc(personalityHandler.GetAll)))).Methods("GET", "HEAD")
+		api.Handle("/{id:[0-9]+}", resourceCache(resource(http.HandlerFunc(personalityHandler.GetByID)))).Methods("GET", "HEAD")
+		api.Handle("/{id:[0-9]+}", writeBody(resource(http.HandlerFunc(personalityHandler.Update)))).Methods("PUT")
+		api.Handle("/{id:[0-9]+}", anyFormat(http.HandlerFunc(personalityHandler.Delete))).Methods("DELETE")
+	}
+
+	// Consultas GraphQL pelo GET ou POST; mutations só pelo POST
+	if deps.GraphQL != nil {
+		r.Handle(graphQLPath, noStore(writeBody(deps.GraphQL))).Methods("POST")
+		r.Handle(graphQLPath, noStore(deps.GraphQL)).Methods("GET", "HEAD")
+	}
+
+	// Rotas HTTP do serviço gRPC, declaradas no gateway.yaml
+	if deps.Gateway != nil {
+		r.PathPrefix("/rpc/").Handler(noStore(writeBody(deps.Gateway)))
+	}
+
+	// Métricas no formato Prometheus
+	if deps.Metrics != nil {
+		r.Handle("/metrics", noStore(deps.Metrics.Handler())).Methods("GET", "HEAD")
+	}
+
+	// Rotas administrativas, disponíveis apenas com ADMIN_TOKEN configurado
+	if token := deps.Config.Server.AdminToken; token != "" {
+		admin := r.PathPrefix("/admin").Subrouter()
+		admin.Use(middleware.RequireToken(token))
+		admin.Handle("/log-level", noStore(logger.LevelHandler())).Methods("GET", "HEAD", "PUT")
+	}
+
+	// Documento OpenAPI gerado a partir das rotas acima e página de documentação
+	docsCache := middleware.CacheControl("public, max-age=300")
+	r.Handle(openAPIPath, docsCache(openapi.Handler(doc))).Methods("GET", "HEAD")
+	r.Handle(docsPath, docsCache(openapi.DocsHandler(openAPIPath))).Methods("GET", "HEAD")
+
+	// Preflight de CORS, registrado por último para só atender rotas existentes
+	cors.RegisterPreflight(r)
 
 	return r
 }
+
+// Versões da API de personalidades
+var (
+	apiVersions  = []int{1, 2}
+	latestPrefix = "/api/v2/personalities"
+)
+
+// apiGroup é um prefixo das rotas de personalidades e o middleware que define
+// a versão atendida nele; number é zero quando a versão é negociada pelo Accept
+type apiGroup struct {
+	prefix  string
+	number  int
+	version mux.MiddlewareFunc
+}
+
+func apiGroups(defaultVersion int) []apiGroup {
+	return []apiGroup{
+		{"/api/v1/personalities", 1, middleware.PinVersion(1)},
+		{"/api/v2/personalities", 2, middleware.PinVersion(2)},
+		{"/api/personalities", 0, middleware.NegotiateVersion(defaultVersion, apiVersions...)},
+	}
+}
+
+// newRateLimiter cria o limitador de requisições usando o store informado ou o configurado
+func newRateLimiter(deps Dependencies, proxies middleware.TrustedProxies) *middleware.RateLimiter {
+	store := deps.RateLimitStore
+	if store == nil {
+		var err error
+		store, err = middleware.NewRateLimitStore(deps.Config.RateLimit)
+		if err != nil {
+			logger.Error("Erro ao criar store de rate limit, usando memória", "error", err)
+			store = middleware.NewMemoryRateLimitStore()
+		}
+	}
+	return middleware.NewRateLimiter(deps.Config.RateLimit, store, proxies)
+}
+
+// chain aplica os middlewares a um handler na mesma ordem usada por r.Use
+func chain(h http.Handler, middlewares []mux.MiddlewareFunc) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+func notFound(w http.ResponseWriter, r *http.Request) {
+	problem.Write(w, r, problem.RouteNotFound.New(""))
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	problem.Write(w, r, problem.MethodNotAllowed.New(""))
+}
@@ -0,0 +1,944 @@
+package router
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"go-api-rest/internal/config"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/events"
+	"go-api-rest/internal/graph"
+	"go-api-rest/internal/handler"
+	"go-api-rest/internal/metrics"
+	"go-api-rest/internal/middleware"
+	"go-api-rest/internal/service"
+	"go-api-rest/pkg/problem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// stubService implementa service.PersonalityService com dados fixos
+type stubService struct{}
+
+func (stubService) Create(_ context.Context, req *dto.CreatePersonalityRequest) (*dto.PersonalityResponse, error) {
+	return &dto.PersonalityResponse{ID: 1, Name: req.Name, History: req.History}, nil
+}
+
+func (stubService) GetAll(_ context.Context) ([]dto.PersonalityResponse, error) {
+	return []dto.PersonalityResponse{{ID: 1, Name: "Ada Lovelace", History: "Matemática inglesa"}}, nil
+}
+
+// List pagina três personalidades fixas
+func (stubService) List(_ context.Context, _ dto.PersonalityFilter, page dto.PageRequest) (*dto.PersonalityPage, error) {
+	all := []dto.PersonalityResponse{
+		{ID: 1, Name: "Ada Lovelace", History: "Matemática inglesa"},
+		{ID: 2, Name: "Alan Turing", History: "Matemático inglês"},
+		{ID: 3, Name: "Grace Hopper", History: "Cientista da computação"},
+	}
+	start := min(page.Offset(), len(all))
+	end := min(start+page.PerPage, len(all))
+	return &dto.PersonalityPage{Items: all[start:end], Total: int64(len(all))}, nil
+}
+
+// stubUpdatedAt é a data de modificação fixa da personalidade do stub
+var stubUpdatedAt = time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+func (stubService) GetByID(_ context.Context, id uint) (*dto.PersonalityResponse, error) {
+	if id != 1 {
+		return nil, service.ErrPersonalityNotFound
+	}
+	return &dto.PersonalityResponse{ID: 1, Name: "Ada Lovelace", History: "Matemática inglesa", Version: 3, UpdatedAt: stubUpdatedAt}, nil
+}
+
+func (s stubService) GetByIDs(ctx context.Context, ids []uint) ([]dto.PersonalityResponse, error) {
+	var found []dto.PersonalityResponse
+	for _, id := range ids {
+		if p, err := s.GetByID(ctx, id); err == nil {
+			found = append(found, *p)
+		}
+	}
+	return found, nil
+}
+
+func (stubService) Update(_ context.Context, id uint, req *dto.UpdatePersonalityRequest) (*dto.PersonalityResponse, error) {
+	return &dto.PersonalityResponse{ID: id, Name: req.Name, History: req.History}, nil
+}
+
+func (stubService) Delete(_ context.Context, id uint) error {
+	return nil
+}
+
+func newTestDependencies() Dependencies {
+	cfg := config.Load()
+	cfg.RateLimit.Enabled = false
+	return Dependencies{
+		Config:             cfg,
+		PersonalityHandler: handler.NewPersonalityHandler(stubService{}),
+	}
+}
+
+// newTestGraphQL cria o endpoint GraphQL sobre o stubService
+func newTestGraphQL(t *testing.T, cfg config.GraphQLConfig) *graph.Handler {
+	t.Helper()
+	h, err := graph.NewHandler(stubService{}, cfg)
+	if err != nil {
+		t.Fatalf("Erro ao criar o endpoint GraphQL: %v", err)
+	}
+	return h
+}
+
+func TestSetupRoutes_MetricsEndpoint(t *testing.T) {
+	deps := newTestDependencies()
+	deps.Metrics = metrics.New()
+	r := SetupRoutes(deps)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/personalities/1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Esperava status 200, mas obteve %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	expected := `go_api_rest_http_requests_total{method="GET",route="/api/personalities/{id:[0-9]+}",status="200"} 1`
+	if !strings.Contains(string(body), expected) {
+		t.Errorf("Métrica esperada não encontrada: %s", expected)
+	}
+	if !strings.Contains(string(body), "go_goroutines") {
+		t.Error("Métricas do runtime Go não encontradas")
+	}
+}
+
+func TestSetupRoutes_NotFoundIsJSON(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Esperava status 404, mas obteve %d", rec.Code)
+	}
+	if rec.Header().Get("X-Request-ID") == "" {
+		t.Error("Resposta 404 deveria conter X-Request-ID")
+	}
+}
+
+func TestSetupRoutes_TracingPropagation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	deps := newTestDependencies()
+	deps.TracerProvider = provider
+	deps.PersonalityHandler = handler.NewPersonalityHandler(service.NewTracedPersonalityService(stubService{}, provider))
+	r := SetupRoutes(deps)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/personalities/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Esperava 2 spans, mas obteve %d", len(spans))
+	}
+
+	serviceSpan, serverSpan := spans[0], spans[1]
+	if serverSpan.Name() != "/api/personalities/{id:[0-9]+}" {
+		t.Errorf("Nome do span de servidor inesperado: %s", serverSpan.Name())
+	}
+	if serverSpan.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("Span de servidor não herdou o traceparent: %s", serverSpan.Parent().SpanID())
+	}
+	if serviceSpan.Name() != "PersonalityService.GetByID" || serviceSpan.Parent().SpanID() != serverSpan.SpanContext().SpanID() {
+		t.Errorf("Span do serviço deveria ser filho do span de servidor: %s", serviceSpan.Name())
+	}
+	if serviceSpan.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Trace ID não foi propagado: %s", serviceSpan.SpanContext().TraceID())
+	}
+}
+
+func TestSetupRoutes_ConditionalGet(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/personalities/1", nil))
+	etag := rec.Header().Get("ETag")
+	if etag != `W/"1-3"` {
+		t.Fatalf("Esperava ETag W/\"1-3\", obteve %q", etag)
+	}
+	if got := rec.Header().Get("Last-Modified"); got != stubUpdatedAt.Format(http.TimeFormat) {
+		t.Errorf("Last-Modified inesperado: %q", got)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "private, no-cache" {
+		t.Errorf("Cache-Control inesperado: %q", got)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+	}{
+		{"If-None-Match", "If-None-Match", etag},
+		{"If-Modified-Since", "If-Modified-Since", stubUpdatedAt.Format(http.TimeFormat)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/personalities/1", nil)
+			req.Header.Set(tt.header, tt.value)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusNotModified {
+				t.Fatalf("Esperava status 304, mas obteve %d", rec.Code)
+			}
+			if rec.Body.Len() != 0 {
+				t.Errorf("Esperava corpo vazio, obteve %q", rec.Body.String())
+			}
+			if rec.Header().Get("ETag") != etag || rec.Header().Get("Cache-Control") == "" {
+				t.Errorf("Esperava ETag e Cache-Control no 304, obteve %v", rec.Header())
+			}
+		})
+	}
+}
+
+func TestSetupRoutes_CollectionETag(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/personalities", nil))
+	etag := rec.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("Esperava ETag fraco na coleção, obteve %q", etag)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/personalities", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("Esperava status 304, mas obteve %d", rec.Code)
+	}
+}
+
+func TestSetupRoutes_HeadOnGetRoutes(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	for _, path := range []string{"/", "/healthz", "/readyz", "/health", "/api/personalities", "/api/personalities/1"} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("HEAD %s: esperava status 200, mas obteve %d", path, rec.Code)
+		}
+	}
+}
+
+func TestSetupRoutes_ErrorsAreNotCached(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/personalities/2", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Esperava status 404, mas obteve %d", rec.Code)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("Esperava Cache-Control no-store, obteve %q", got)
+	}
+}
+
+func TestSetupRoutes_ContentNegotiation(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	tests := []struct {
+		name        string
+		path        string
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"JSON padrão", "/api/personalities/1", "", http.StatusOK, "application/json; charset=utf-8"},
+		{"XML", "/api/personalities/1", "application/xml", http.StatusOK, "application/xml; charset=utf-8"},
+		{"YAML", "/api/personalities", "application/yaml", http.StatusOK, "application/yaml; charset=utf-8"},
+		{"CSV da coleção", "/api/personalities", "text/csv", http.StatusOK, "text/csv; charset=utf-8; header=present"},
+		{"MessagePack", "/api/personalities/1", "application/msgpack", http.StatusOK, "application/msgpack"},
+		{"CSV de um recurso", "/api/personalities/1", "text/csv", http.StatusNotAcceptable, "application/problem+json"},
+		{"formato desconhecido", "/api/personalities", "image/png", http.StatusNotAcceptable, "application/problem+json"},
+		{"CSV da coleção paginada", "/api/v2/personalities", "text/csv", http.StatusNotAcceptable, "application/problem+json"},
+		{"erros usam problem+json", "/api/personalities/2", "application/xml", http.StatusNotFound, "application/problem+json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Esperava status %d, mas obteve %d", tt.status, rec.Code)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Esperava Content-Type %q, obteve %q", tt.contentType, got)
+			}
+			if !strings.Contains(strings.Join(rec.Header().Values("Vary"), ","), "Accept") {
+				t.Errorf("Esperava Vary com Accept, obteve %v", rec.Header().Values("Vary"))
+			}
+		})
+	}
+}
+
+// writeCounter conta as escritas que chegam ao serviço
+type writeCounter struct {
+	stubService
+	writes atomic.Int32
+}
+
+func (s *writeCounter) Create(ctx context.Context, req *dto.CreatePersonalityRequest) (*dto.PersonalityResponse, error) {
+	s.writes.Add(1)
+	return s.stubService.Create(ctx, req)
+}
+
+func (s *writeCounter) Update(ctx context.Context, id uint, req *dto.UpdatePersonalityRequest) (*dto.PersonalityResponse, error) {
+	s.writes.Add(1)
+	return s.stubService.Update(ctx, id, req)
+}
+
+func TestSetupRoutes_NotAcceptableBeforeWrite(t *testing.T) {
+	svc := &writeCounter{}
+	deps := newTestDependencies()
+	deps.PersonalityHandler = handler.NewPersonalityHandler(svc)
+	r := SetupRoutes(deps)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		accept string
+		status int
+		writes int32
+	}{
+		{"CSV na criação", http.MethodPost, "/api/v1/personalities", "text/csv", http.StatusNotAcceptable, 0},
+		{"CSV na atualização", http.MethodPut, "/api/v1/personalities/1", "text/csv", http.StatusNotAcceptable, 0},
+		{"CSV na criação da v2", http.MethodPost, "/api/v2/personalities", "text/csv", http.StatusNotAcceptable, 0},
+		{"CSV como alternativa", http.MethodPost, "/api/v1/personalities", "text/csv, application/xml;q=0.5", http.StatusCreated, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc.writes.Store(0)
+			body := `{"name":"Ada Lovelace","history":"Matemática inglesa"}`
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Esperava status %d, mas obteve %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if got := svc.writes.Load(); got != tt.writes {
+				t.Errorf("Esperava %d escritas no serviço, obteve %d", tt.writes, got)
+			}
+		})
+	}
+}
+
+func TestSetupRoutes_RequestContentType(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"JSON", "application/json", `{"name":"Ada Lovelace","history":"Primeira programadora"}`, http.StatusCreated},
+		{"XML", "application/xml", `<personality><name>Ada Lovelace</name><history>Primeira programadora</history></personality>`, http.StatusCreated},
+		{"YAML", "application/yaml", "name: Ada Lovelace\nhistory: Primeira programadora\n", http.StatusCreated},
+		{"não suportado", "text/plain", "Ada Lovelace", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/personalities", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Esperava status %d, mas obteve %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if tt.status == http.StatusUnsupportedMediaType && rec.Header().Get("Accept-Post") == "" {
+				t.Error("Esperava Accept-Post na resposta 415")
+			}
+		})
+	}
+}
+
+func TestSetupRoutes_ProblemDetails(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+	}{
+		{"recurso inexistente", http.MethodGet, "/api/personalities/2", "", http.StatusNotFound, "PERSONALITY_NOT_FOUND"},
+		{"rota inexistente", http.MethodGet, "/unknown", "", http.StatusNotFound, "ROUTE_NOT_FOUND"},
+		{"corpo malformado", http.MethodPost, "/api/personalities", "{", http.StatusBadRequest, "MALFORMED_BODY"},
+		{"validação", http.MethodPost, "/api/personalities", `{"name":"A"}`, http.StatusBadRequest, "VALIDATION_FAILED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Esperava status %d, mas obteve %d", tt.status, rec.Code)
+			}
+			var p problem.Problem
+			if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+				t.Fatalf("Corpo não é um problem+json: %v", err)
+			}
+			if p.Code != tt.code || p.Status != tt.status || p.Instance != tt.path {
+				t.Errorf("Problema inesperado: %+v", p)
+			}
+			if p.Type == "" || p.Title == "" {
+				t.Errorf("Esperava type e title preenchidos: %+v", p)
+			}
+			if p.RequestID == "" || p.RequestID != rec.Header().Get("X-Request-ID") {
+				t.Errorf("Esperava request_id igual ao X-Request-ID, obteve %q", p.RequestID)
+			}
+			if tt.code == "VALIDATION_FAILED" && len(p.Errors) != 2 {
+				t.Errorf("Esperava erros dos campos name e history, obteve %+v", p.Errors)
+			}
+		})
+	}
+}
+
+func TestSetupRoutes_Localization(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		locale         string
+		title          string
+		detail         string
+		field          string
+	}{
+		{"sem preferência", "", "pt-BR", "Dados inválidos", "Os dados fornecidos são inválidos", "O campo name deve ter no mínimo 3 caracteres"},
+		{"inglês regional", "en-US,en;q=0.9", "en", "Invalid data", "The provided data is invalid", "The name field must be at least 3 characters long"},
+		{"espanhol com q-value", "fr;q=1, es;q=0.8, en;q=0.5", "es", "Datos inválidos", "Los datos proporcionados no son válidos", "El campo name debe tener al menos 3 caracteres"},
+		{"idioma não suportado", "de", "pt-BR", "Dados inválidos", "Os dados fornecidos são inválidos", "O campo name deve ter no mínimo 3 caracteres"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/personalities", strings.NewReader(`{"name":"A","history":"Uma história longa o suficiente"}`))
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Language"); got != tt.locale {
+				t.Errorf("Esperava Content-Language %s, obteve %s", tt.locale, got)
+			}
+			if !strings.Contains(strings.Join(rec.Header().Values("Vary"), ","), "Accept-Language") {
+				t.Errorf("Esperava Vary com Accept-Language, obteve %v", rec.Header().Values("Vary"))
+			}
+			var p problem.Problem
+			if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+				t.Fatalf("Corpo não é um problem+json: %v", err)
+			}
+			if p.Title != tt.title || p.Detail != tt.detail {
+				t.Errorf("Esperava %q / %q, obteve %q / %q", tt.title, tt.detail, p.Title, p.Detail)
+			}
+			if len(p.Errors) != 1 || p.Errors[0].Message != tt.field {
+				t.Errorf("Esperava mensagem de campo %q, obteve %+v", tt.field, p.Errors)
+			}
+		})
+	}
+}
+
+func TestSetupRoutes_LocalizedServiceError(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/personalities/2", nil)
+	req.Header.Set("Accept-Language", "es")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	var p problem.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+		t.Fatalf("Corpo não é um problem+json: %v", err)
+	}
+	if p.Title != "Personalidad no encontrada" || p.Detail != "personalidad no encontrada" {
+		t.Errorf("Esperava problema em espanhol, obteve %+v", p)
+	}
+}
+
+func TestSetupRoutes_EmptyUpdateIsRejected(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/personalities/1", strings.NewReader(`{}`)))
+
+	var p problem.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+		t.Fatalf("Corpo não é um problem+json: %v", err)
+	}
+	if rec.Code != http.StatusBadRequest || len(p.Errors) != 1 || p.Errors[0].Field != "name" {
+		t.Errorf("Esperava erro de validação entre campos, obteve %d %+v", rec.Code, p)
+	}
+}
+
+func TestSetupRoutes_BodyLimit(t *testing.T) {
+	deps := newTestDependencies()
+	deps.Config.Server.MaxBodyBytes = 64
+	r := SetupRoutes(deps)
+
+	body := `{"name":"Ada Lovelace","history":"` + strings.Repeat("a", 100) + `"}`
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/personalities", strings.NewReader(body)))
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("Esperava status 413, mas obteve %d: %s", rec.Code, rec.Body.String())
+	}
+	var p problem.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil || p.Code != "PAYLOAD_TOO_LARGE" {
+		t.Errorf("Esperava PAYLOAD_TOO_LARGE, obteve %+v (erro: %v)", p, err)
+	}
+}
+
+func TestSetupRoutes_ContractValidation(t *testing.T) {
+	deps := newTestDependencies()
+	deps.Config.Contract = config.ContractConfig{Mode: "reject", Responses: true}
+	r := SetupRoutes(deps)
+
+	// Respostas dos handlers fora do contrato viram 500 no modo reject
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		accept string
+		status int
+	}{
+		{http.MethodGet, "/", "", "", http.StatusOK},
+		{http.MethodGet, "/healthz", "", "", http.StatusOK},
+		{http.MethodGet, "/readyz", "", "", http.StatusOK},
+		{http.MethodGet, "/api/personalities", "", "", http.StatusOK},
+		{http.MethodHead, "/api/personalities/1", "", "", http.StatusOK},
+		{http.MethodGet, "/api/personalities/1", "", "application/xml", http.StatusOK},
+		{http.MethodGet, "/api/personalities/2", "", "", http.StatusNotFound},
+		{http.MethodGet, "/api/personalities/1", "", "text/html", http.StatusNotAcceptable},
+		{http.MethodPost, "/api/personalities", `{"name":"Ada Lovelace","history":"Matemática inglesa"}`, "", http.StatusCreated},
+		{http.MethodPost, "/api/personalities", "{", "", http.StatusBadRequest},
+		{http.MethodPut, "/api/personalities/1", `{"history":"Primeira programadora"}`, "", http.StatusOK},
+		{http.MethodDelete, "/api/personalities/1", "", "", http.StatusNoContent},
+		{http.MethodGet, "/api/v1/personalities/1", "", "", http.StatusOK},
+		{http.MethodGet, "/api/v2/personalities?per_page=2", "", "", http.StatusOK},
+		{http.MethodGet, "/api/v2/personalities/1", "", "", http.StatusOK},
+		{http.MethodPost, "/api/v2/personalities", `{"name":"Ada Lovelace","history":"Matemática inglesa"}`, "", http.StatusCreated},
+		{http.MethodGet, "/api/personalities", "", "application/vnd.go-api-rest.v2+json", http.StatusOK},
+		{http.MethodGet, "/api/personalities/1", "", "application/json; version=2", http.StatusOK},
+		{http.MethodGet, openAPIPath, "", "", http.StatusOK},
+		{http.MethodGet, docsPath, "", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s %s: esperava status %d, obteve %d: %s", tt.method, tt.path, tt.status, rec.Code, rec.Body.String())
+		}
+	}
+
+	// Requisições fora do contrato são rejeitadas antes do handler
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/personalities", strings.NewReader(`{"name":"Ada Lovelace","history":"Matemática inglesa","age":36}`)))
+	var p problem.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+		t.Fatalf("Corpo não é um problem+json: %v", err)
+	}
+	if rec.Code != http.StatusBadRequest || len(p.Errors) != 1 || p.Errors[0].Field != "age" {
+		t.Errorf("Esperava violação do campo age, obteve %d %+v", rec.Code, p)
+	}
+}
+
+func TestSetupRoutes_Versioning(t *testing.T) {
+	deps := newTestDependencies()
+	deps.Config.Versioning.V1DeprecatedAt = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	deps.Config.Versioning.V1Sunset = time.Date(2027, 4, 1, 0, 0, 0, 0, time.UTC)
+	r := SetupRoutes(deps)
+
+	tests := []struct {
+		name       string
+		path       string
+		accept     string
+		status     int
+		version    string
+		deprecated bool
+		enveloped  bool
+	}{
+		{"v1 na URL", "/api/v1/personalities/1", "", http.StatusOK, "1", true, false},
+		{"v2 na URL", "/api/v2/personalities/1", "", http.StatusOK, "2", false, true},
+		{"URL prevalece sobre o Accept", "/api/v2/personalities/1", "application/vnd.go-api-rest.v1+json", http.StatusOK, "2", false, true},
+		{"sem versão usa a padrão", "/api/personalities/1", "", http.StatusOK, "1", true, false},
+		{"media type de versão", "/api/personalities/1", "application/vnd.go-api-rest.v2+json", http.StatusOK, "2", false, true},
+		{"parâmetro version", "/api/personalities/1", "application/json; version=2", http.StatusOK, "2", false, true},
+		{"versão inexistente", "/api/personalities/1", "application/vnd.go-api-rest.v9+json", http.StatusNotAcceptable, "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Esperava status %d, obteve %d: %s", tt.status, rec.Code, rec.Body)
+			}
+			if got := rec.Header().Get("API-Version"); got != tt.version {
+				t.Errorf("Esperava API-Version %q, obteve %q", tt.version, got)
+			}
+			if deprecated := rec.Header().Get("Deprecation") != ""; deprecated != tt.deprecated {
+				t.Errorf("Deprecation presente = %v, esperava %v", deprecated, tt.deprecated)
+			}
+			if tt.deprecated {
+				if rec.Header().Get("Sunset") == "" || !strings.Contains(rec.Header().Get("Link"), `</api/v2/personalities/1>; rel="successor-version"`) {
+					t.Errorf("Esperava Sunset e Link para a v2: %v", rec.Header())
+				}
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+				t.Errorf("Esperava application/json, obteve %s", ct)
+			}
+			var body map[string]interface{}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("Erro ao decodificar resposta: %v", err)
+			}
+			if _, enveloped := body["data"]; enveloped != tt.enveloped {
+				t.Errorf("Corpo com data = %v, esperava %v: %v", enveloped, tt.enveloped, body)
+			}
+		})
+	}
+}
+
+func TestSetupRoutes_V1WithoutDatesOmitsDeprecation(t *testing.T) {
+	// Por padrão as datas são zero e a v1 não anuncia descontinuação
+	r := SetupRoutes(newTestDependencies())
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/personalities/1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Esperava status 200, obteve %d: %s", rec.Code, rec.Body)
+	}
+	for _, header := range []string{"Deprecation", "Sunset", "Link"} {
+		if got := rec.Header().Get(header); got != "" {
+			t.Errorf("Não esperava o cabeçalho %s, obteve %q", header, got)
+		}
+	}
+}
+
+func TestSetupRoutes_V2Pagination(t *testing.T) {
+	r := SetupRoutes(newTestDependencies())
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v2/personalities?page=2&per_page=2", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Esperava status 200, obteve %d: %s", rec.Code, rec.Body)
+	}
+	var page dto.PersonalityListEnvelope
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatalf("Erro ao decodificar resposta: %v", err)
+	}
+	if len(page.Data) != 1 || page.Data[0].ID != 3 {
+		t.Errorf("Esperava só a personalidade 3 na página 2, obteve %+v", page.Data)
+	}
+	if page.Meta != (dto.PageMeta{Page: 2, PerPage: 2, Total: 3, TotalPages: 2}) {
+		t.Errorf("Metadados inesperados: %+v", page.Meta)
+	}
+	if page.Links.Prev != "/api/v2/personalities?page=1&per_page=2" || page.Links.Next != "" {
+		t.Errorf("Links inesperados: %+v", page.Links)
+	}
+	link := rec.Header().Get("Link")
+	for _, rel := range []string{`rel="first"`, `rel="prev"`, `rel="last"`} {
+		if !strings.Contains(link, rel) {
+			t.Errorf("Cabeçalho Link sem %s: %s", rel, link)
+		}
+	}
+
+	for _, query := range []string{"page=0", "per_page=101", "page=abc", "page=92233720368547760&per_page=100"} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v2/personalities?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: esperava status 400, obteve %d", query, rec.Code)
+		}
+	}
+}
+
+func TestSetupRoutes_GraphQL(t *testing.T) {
+	deps := newTestDependencies()
+	deps.Config.Contract = config.ContractConfig{Mode: "reject", Responses: true}
+	deps.GraphQL = newTestGraphQL(t, config.GraphQLConfig{MaxDepth: 6, MaxComplexity: 1000, Playground: true})
+	r := SetupRoutes(deps)
+
+	query := url.QueryEscape(`{ personality(id: "1") { name } }`)
+	mutation := url.QueryEscape(`mutation { deletePersonality(id: "1") }`)
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"query pelo POST", http.MethodPost, "/graphql", `{"query":"{ personality(id: \"1\") { name } }"}`, "", http.StatusOK, "application/json"},
+		{"mutation pelo POST", http.MethodPost, "/graphql", `{"query":"mutation { deletePersonality(id: \"1\") }"}`, "", http.StatusOK, "application/json"},
+		{"query pelo GET", http.MethodGet, "/graphql?query=" + query, "", "", http.StatusOK, "application/json"},
+		{"mutation pelo GET", http.MethodGet, "/graphql?query=" + mutation, "", "", http.StatusMethodNotAllowed, problem.ContentType},
+		{"campo desconhecido", http.MethodPost, "/graphql", `{"query":"{ __typename }","extra":1}`, "", http.StatusBadRequest, problem.ContentType},
+		{"sem consulta", http.MethodGet, "/graphql", "", "", http.StatusBadRequest, problem.ContentType},
+		{"GraphiQL", http.MethodGet, "/graphql", "", "text/html", http.StatusOK, "text/html"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Fatalf("Esperava status %d, obteve %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, tt.contentType) {
+				t.Errorf("Esperava Content-Type %q, obteve %q", tt.contentType, got)
+			}
+			if got := rec.Header().Get("Cache-Control"); rec.Code == http.StatusOK && got != "no-store" {
+				t.Errorf("Esperava Cache-Control no-store, obteve %q", got)
+			}
+		})
+	}
+}
+
+func TestSetupRoutes_Gateway(t *testing.T) {
+	deps := newTestDependencies()
+	var requestID string
+	deps.Gateway = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = r.Header.Get(middleware.RequestIDHeader)
+		w.WriteHeader(http.StatusOK)
+	})
+	r := SetupRoutes(deps)
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodDelete} {
+		req := httptest.NewRequest(method, "/rpc/v1/personalities/1", nil)
+		req.Header.Set(middleware.RequestIDHeader, "abc-123")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") != "no-store" {
+			t.Errorf("%s: esperava 200 com no-store, obteve %d %q", method, rec.Code, rec.Header().Get("Cache-Control"))
+		}
+		if requestID != "abc-123" {
+			t.Errorf("%s: esperava o X-Request-ID repassado ao gateway, obteve %q", method, requestID)
+		}
+	}
+}
+
+// sseEvent é um evento lido do stream; comentários são ignorados
+type sseEvent struct {
+	id, name, data string
+}
+
+// readEvents lê n eventos do stream
+func readEvents(t *testing.T, reader *bufio.Reader, n int) []sseEvent {
+	t.Helper()
+	var out []sseEvent
+	var current sseEvent
+	for len(out) < n {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Erro ao ler o stream: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if current != (sseEvent{}) {
+				out = append(out, current)
+			}
+			current = sseEvent{}
+		case strings.HasPrefix(line, "id: "):
+			current.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			current.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	return out
+}
+
+func TestSetupRoutes_PersonalityEvents(t *testing.T) {
+	deps := newTestDependencies()
+	deps.Config.Contract = config.ContractConfig{Mode: "reject", Responses: true}
+	broker := events.NewBroker(10, 2)
+	deps.Events = handler.NewPersonalityEventsHandler(broker, config.EventsConfig{HeartbeatInterval: time.Minute})
+	server := httptest.NewServer(SetupRoutes(deps))
+	// Registrado antes dos corpos, que são fechados primeiro e encerram os streams
+	t.Cleanup(server.Close)
+
+	open := func(query, lastEventID string) (*http.Response, *bufio.Reader) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/personalities/events"+query, nil)
+		req.Header.Set("Accept", "text/event-stream")
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Erro ao abrir o stream: %v", err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		reader := bufio.NewReader(resp.Body)
+		// O comentário inicial indica que a assinatura está ativa
+		if line, err := reader.ReadString('\n'); err != nil || !strings.HasPrefix(line, ":") {
+			t.Fatalf("Esperava o comentário inicial, obteve %q (%v)", line, err)
+		}
+		reader.ReadString('\n')
+		return resp, reader
+	}
+
+	resp, reader := open("?id=1", "")
+	if got := resp.Header.Get("Content-Type"); got != handler.EventStreamContentType {
+		t.Errorf("Esperava Content-Type %s, obteve %q", handler.EventStreamContentType, got)
+	}
+	if got := resp.Header.Get("Cache-Control"); got != "no-store" {
+		t.Errorf("Esperava Cache-Control no-store, obteve %q", got)
+	}
+
+	broker.Publish(events.Event{Type: events.Created, PersonalityID: 2, Personality: &dto.PersonalityResponse{ID: 2, Name: "Alan Turing"}})
+	broker.Publish(events.Event{Type: events.Updated, PersonalityID: 1, Personality: &dto.PersonalityResponse{ID: 1, Name: "Ada Lovelace"}})
+	broker.Publish(events.Event{Type: events.Deleted, PersonalityID: 1})
+
+	received := readEvents(t, reader, 2)
+	if received[0].id != broker.EventID(2) || received[0].name != "updated" || received[1].id != broker.EventID(3) || received[1].name != "deleted" {
+		t.Fatalf("Esperava updated e deleted da personalidade 1, obteve %+v", received)
+	}
+	var payload dto.PersonalityEvent
+	if err := json.Unmarshal([]byte(received[0].data), &payload); err != nil || payload.Personality == nil || payload.Personality.Name != "Ada Lovelace" {
+		t.Errorf("Dado inesperado: %s (%v)", received[0].data, err)
+	}
+
+	// A retomada reenvia os eventos guardados posteriores ao Last-Event-ID
+	_, reader = open("", broker.EventID(1))
+	if got := readEvents(t, reader, 2); got[0].id != broker.EventID(2) || got[1].id != broker.EventID(3) {
+		t.Errorf("Esperava os eventos 2 e 3 reenviados, obteve %+v", got)
+	}
+
+	// O evento 1 já saiu do histórico: o stream começa com reset
+	_, reader = open("", broker.EventID(0))
+	if got := readEvents(t, reader, 1); got[0].name != "reset" || got[0].id != broker.EventID(3) {
+		t.Errorf("Esperava reset com ID %s, obteve %+v", broker.EventID(3), got)
+	}
+
+	// IDs de antes de um reinício também começam com reset
+	_, reader = open("", events.NewBroker(10, 2).EventID(2))
+	if got := readEvents(t, reader, 1); got[0].name != "reset" {
+		t.Errorf("Esperava reset para ID de outra execução, obteve %+v", got)
+	}
+
+	for _, query := range []string{"?id=abc", "?id=0"} {
+		rec := httptest.NewRecorder()
+		SetupRoutes(deps).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/personalities/events"+query, nil))
+		if rec.Code != http.StatusBadRequest || rec.Header().Get("Content-Type") != problem.ContentType {
+			t.Errorf("%s: esperava 400 problem+json, obteve %d %q", query, rec.Code, rec.Header().Get("Content-Type"))
+		}
+	}
+}
+
+// TestSetupRoutes_CORSPreflight usa o roteador completo, cujos handlers de 404
+// e 405 fazem o Match do gorilla aceitar qualquer requisição
+func TestSetupRoutes_CORSPreflight(t *testing.T) {
+	deps := newTestDependencies()
+	deps.Config.CORS.AllowedOrigins = []string{"https://app.example.com"}
+	r := SetupRoutes(deps)
+
+	tests := []struct {
+		name    string
+		target  string
+		method  string
+		status  int
+		allowed string
+	}{
+		{"rota existente", "/api/v2/personalities/1", http.MethodPut, http.StatusNoContent, "GET, HEAD, PUT, DELETE"},
+		{"caminho inexistente", "/nao-existe", http.MethodGet, http.StatusNotFound, ""},
+		{"método sem rota", "/api/v2/personalities/1", http.MethodPost, http.StatusMethodNotAllowed, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, tt.target, nil)
+			req.Header.Set("Origin", "https://app.example.com")
+			req.Header.Set("Access-Control-Request-Method", tt.method)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Fatalf("Esperava status %d, obteve %d", tt.status, rec.Code)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Methods"); got != tt.allowed {
+				t.Errorf("Esperava Access-Control-Allow-Methods %q, obteve %q", tt.allowed, got)
+			}
+		})
+	}
+
+	// OPTIONS sem cabeçalhos de preflight também não anuncia caminhos inexistentes
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/nao-existe", nil))
+	if rec.Code != http.StatusNotFound || rec.Header().Get("Allow") != "" {
+		t.Errorf("Esperava 404 sem Allow, obteve %d %q", rec.Code, rec.Header().Get("Allow"))
+	}
+}
+
+// TestSetupRoutes_CORSWildcardWithCredentials garante que a combinação
+// recusada por NewCORS não reflete a origem com credenciais
+func TestSetupRoutes_CORSWildcardWithCredentials(t *testing.T) {
+	deps := newTestDependencies()
+	deps.Config.CORS.AllowedOrigins = []string{"*"}
+	deps.Config.CORS.AllowCredentials = true
+	r := SetupRoutes(deps)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/personalities/1", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Esperava origem recusada, obteve Access-Control-Allow-Origin %q", got)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Esperava sem Access-Control-Allow-Credentials, obteve %q", got)
+	}
+}
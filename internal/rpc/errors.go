@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"go-api-rest/internal/handler"
+	"go-api-rest/pkg/logger"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/reqctx"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain identifica a aplicação no google.rpc.ErrorInfo
+const errorDomain = "go-api-rest"
+
+// httpCodes traduz o status HTTP dos problemas para o código gRPC equivalente
+var httpCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.AlreadyExists,
+	http.StatusPreconditionFailed:    codes.FailedPrecondition,
+	http.StatusRequestEntityTooLarge: codes.ResourceExhausted,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusNotImplemented:        codes.Unimplemented,
+	http.StatusServiceUnavailable:    codes.Unavailable,
+}
+
+// toStatus converte um erro do serviço com o mesmo mapeamento das respostas
+// REST; erros que já são status passam adiante
+func toStatus(ctx context.Context, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	p := handler.MapError(err)
+	if p.Status >= http.StatusInternalServerError {
+		logger.ErrorContext(ctx, "Erro ao processar chamada gRPC", "error", err)
+	}
+	return problemStatus(ctx, p).Err()
+}
+
+// problemStatus descreve o problema como status gRPC, com a mensagem no
+// idioma da chamada, o código do problema em ErrorInfo e os campos inválidos
+// em BadRequest
+func problemStatus(ctx context.Context, p *problem.Problem) *status.Status {
+	code, ok := httpCodes[p.Status]
+	if !ok {
+		code = codes.Internal
+	}
+	localized := p.In(reqctx.Locale(ctx))
+	message := localized.Detail
+	if message == "" {
+		message = localized.Title
+	}
+
+	st := status.New(code, message)
+	info := &errdetails.ErrorInfo{Reason: p.Code, Domain: errorDomain}
+	if len(p.Errors) == 0 {
+		detailed, _ := st.WithDetails(info)
+		return detailed
+	}
+	badRequest := &errdetails.BadRequest{}
+	for _, field := range p.Errors {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field.Field,
+			Description: field.Message,
+		})
+	}
+	detailed, _ := st.WithDetails(info, badRequest)
+	return detailed
+}
@@ -0,0 +1,165 @@
+package rpc
+
+import (
+	"context"
+	"go-api-rest/internal/middleware"
+	"go-api-rest/pkg/logger"
+	pb "go-api-rest/pkg/pb/personality/v1"
+	"go-api-rest/pkg/problem"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// gatewayBufferSize é o buffer da conexão em memória entre gateway e servidor
+const gatewayBufferSize = 1 << 20
+
+// Gateway traduz as rotas HTTP /rpc/v1 declaradas em gateway.yaml para
+// chamadas ao servidor gRPC, pela mesma conexão em memória
+type Gateway struct {
+	mux      *runtime.ServeMux
+	conn     *grpc.ClientConn
+	listener net.Listener
+}
+
+// NewGateway passa a atender o servidor em uma conexão em memória e registra
+// o PersonalityService no gateway. As chamadas chegam com os interceptors do
+// servidor, exceto o rate limit, já aplicado pelo middleware HTTP.
+func NewGateway(ctx context.Context, server *grpc.Server) (*Gateway, error) {
+	listener := &gatewayListener{bufconn.Listen(gatewayBufferSize)}
+	go server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///gateway",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		// X-Request-ID e Content-Language já são definidos pelos middlewares HTTP
+		runtime.WithOutgoingHeaderMatcher(func(string) (string, bool) { return "", false }),
+		runtime.WithErrorHandler(gatewayError),
+		runtime.WithRoutingErrorHandler(gatewayRoutingError),
+	)
+	if err := pb.RegisterPersonalityServiceHandler(ctx, mux, conn); err != nil {
+		conn.Close()
+		listener.Close()
+		return nil, err
+	}
+	return &Gateway{mux: mux, conn: conn, listener: listener}, nil
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// Close encerra a conexão com o servidor e o listener em memória
+func (g *Gateway) Close() error {
+	err := g.conn.Close()
+	if lerr := g.listener.Close(); err == nil {
+		err = lerr
+	}
+	return err
+}
+
+// incomingHeader repassa ao servidor o ID de correlação e o idioma negociado
+func incomingHeader(key string) (string, bool) {
+	switch http.CanonicalHeaderKey(key) {
+	case middleware.RequestIDHeader:
+		return requestIDKey, true
+	case "Accept-Language":
+		return "accept-language", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// gatewayError responde os erros das chamadas como problem+json, com o código
+// do problema levado em ErrorInfo e os campos inválidos em BadRequest
+func gatewayError(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+
+	var p *problem.Problem
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == errorDomain {
+			httpStatus := runtime.HTTPStatusFromCode(st.Code())
+			p = problem.Type{Code: info.GetReason(), Status: httpStatus, Title: http.StatusText(httpStatus)}.New(st.Message())
+		}
+	}
+	switch {
+	case p != nil:
+		for _, detail := range st.Details() {
+			if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+				for _, field := range badRequest.GetFieldViolations() {
+					p.Errors = append(p.Errors, problem.FieldError{Field: field.GetField(), Message: field.GetDescription()})
+				}
+			}
+		}
+	case st.Code() == codes.InvalidArgument:
+		// Erros do próprio gateway ao ler o corpo ou os parâmetros
+		p = problem.MalformedBody.New(strings.TrimSpace(st.Message()))
+	default:
+		logger.ErrorContext(ctx, "Erro no gateway gRPC", "error", err)
+		p = problem.Internal.New("")
+	}
+	problem.Write(w, r, p)
+}
+
+func gatewayRoutingError(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+	switch httpStatus {
+	case http.StatusNotFound:
+		problem.Write(w, r, problem.RouteNotFound.New(""))
+	case http.StatusMethodNotAllowed:
+		problem.Write(w, r, problem.MethodNotAllowed.New(""))
+	default:
+		problem.Write(w, r, problem.MalformedBody.New(""))
+	}
+}
+
+// gatewayAddr identifica as conexões abertas pelo gateway
+type gatewayAddr struct{}
+
+func (gatewayAddr) Network() string { return "gateway" }
+func (gatewayAddr) String() string  { return "gateway" }
+
+type gatewayListener struct {
+	*bufconn.Listener
+}
+
+func (l *gatewayListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return gatewayConn{conn}, nil
+}
+
+type gatewayConn struct {
+	net.Conn
+}
+
+func (gatewayConn) RemoteAddr() net.Addr { return gatewayAddr{} }
+
+// fromGateway indica se a chamada chegou pelo gateway
+func fromGateway(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	_, ok = p.Addr.(gatewayAddr)
+	return ok
+}
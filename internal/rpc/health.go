@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+	"go-api-rest/internal/health"
+	pb "go-api-rest/pkg/pb/personality/v1"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// healthWatchInterval é o intervalo entre as verificações de um Watch
+const healthWatchInterval = 5 * time.Second
+
+// healthServer expõe o health.Registry pelo protocolo grpc.health.v1, com os
+// mesmos checks críticos do /readyz
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+	registry *health.Registry
+}
+
+// Check responde pelo servidor como um todo ("") ou pelo PersonalityService
+func (s *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if !knownService(req.GetService()) {
+		return nil, status.Errorf(codes.NotFound, "serviço desconhecido: %s", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: s.status(ctx)}, nil
+}
+
+// Watch envia o estado atual e depois cada mudança, verificando periodicamente
+func (s *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+	if !knownService(req.GetService()) {
+		return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVICE_UNKNOWN})
+	}
+
+	ticker := time.NewTicker(healthWatchInterval)
+	defer ticker.Stop()
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		if current := s.status(ctx); current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+		select {
+		case <-ctx.Done():
+			return toStatus(ctx, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *healthServer) status(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	if s.registry.Run(ctx, true).Status == health.StatusUp {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+func knownService(name string) bool {
+	return name == "" || name == pb.PersonalityService_ServiceDesc.ServiceName
+}
@@ -0,0 +1,210 @@
+package rpc
+
+import (
+	"context"
+	"go-api-rest/internal/metrics"
+	"go-api-rest/internal/middleware"
+	"go-api-rest/pkg/i18n"
+	"go-api-rest/pkg/logger"
+	pb "go-api-rest/pkg/pb/personality/v1"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/reqctx"
+	"math"
+	"net"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// interceptor envolve uma chamada, unária ou stream; call executa o restante
+// da cadeia com o contexto informado. Cada interceptor equivale a um
+// middleware HTTP de mesmo nome.
+type interceptor func(ctx context.Context, method string, call func(ctx context.Context) error) error
+
+// unary adapta o interceptor para chamadas unárias
+func unary(i interceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var resp any
+		err := i(ctx, info.FullMethod, func(ctx context.Context) error {
+			var err error
+			resp, err = handler(ctx, req)
+			return err
+		})
+		return resp, err
+	}
+}
+
+// stream adapta o interceptor para streams, trocando o contexto do stream
+func stream(i interceptor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return i(ss.Context(), info.FullMethod, func(ctx context.Context) error {
+			return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		})
+	}
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// firstMetadata retorna o primeiro valor da chave nos metadados recebidos
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// requestIDKey é o metadado do ID de correlação, o X-Request-ID do HTTP
+var requestIDKey = strings.ToLower(middleware.RequestIDHeader)
+
+// requestID aceita o x-request-id recebido ou gera um novo, devolvendo-o nos
+// cabeçalhos da resposta
+func requestID(ctx context.Context, _ string, call func(context.Context) error) error {
+	id := middleware.EnsureRequestID(firstMetadata(ctx, requestIDKey))
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+	return call(reqctx.WithRequestID(ctx, id))
+}
+
+// locale negocia o idioma das mensagens pelo metadado accept-language
+func locale(ctx context.Context, _ string, call func(context.Context) error) error {
+	negotiated := i18n.Negotiate(firstMetadata(ctx, "accept-language"))
+	_ = grpc.SetHeader(ctx, metadata.Pairs("content-language", negotiated))
+	return call(reqctx.WithLocale(ctx, negotiated))
+}
+
+// serverFault indica os códigos que equivalem a um 5xx do HTTP
+func serverFault(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.Unimplemented:
+		return true
+	}
+	return false
+}
+
+// accessLog registra uma linha estruturada por chamada com método, código,
+// latência e o endereço do cliente
+func accessLog(ctx context.Context, method string, call func(context.Context) error) error {
+	start := time.Now()
+	err := call(ctx)
+
+	code := status.Code(err)
+	log := logger.InfoContext
+	if serverFault(code) {
+		log = logger.ErrorContext
+	}
+	log(ctx, "grpc_request",
+		"method", method,
+		"code", code.String(),
+		"latency", time.Since(start),
+		"peer", peerAddr(ctx),
+		"user_agent", firstMetadata(ctx, "user-agent"),
+	)
+	return err
+}
+
+// instrument registra as métricas RED de cada chamada
+func instrument(m *metrics.Metrics) interceptor {
+	return func(ctx context.Context, method string, call func(context.Context) error) error {
+		done := m.TrackRPC(method)
+		err := call(ctx)
+		done(status.Code(err).String())
+		return err
+	}
+}
+
+// recovery recupera de panics e responde INTERNAL
+func recovery(ctx context.Context, _ string, call func(context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.ErrorContext(ctx, "Panic recuperado", "error", r, "stack", string(debug.Stack()))
+			err = problemStatus(ctx, problem.Internal.New("")).Err()
+		}
+	}()
+	return call(ctx)
+}
+
+// writeMethods consomem o limite de escrita; os demais, o de leitura
+var writeMethods = map[string]bool{
+	pb.PersonalityService_CreatePersonality_FullMethodName: true,
+	pb.PersonalityService_UpdatePersonality_FullMethodName: true,
+	pb.PersonalityService_DeletePersonality_FullMethodName: true,
+}
+
+// rateLimit aplica os limites do HTTP, identificando o cliente pela API key
+// configurada, pelo usuário ou pelo IP. Chamadas do gateway já passaram pelo limite HTTP e
+// não são contadas de novo.
+func rateLimit(l *middleware.RateLimiter, proxies middleware.TrustedProxies) interceptor {
+	return func(ctx context.Context, method string, call func(context.Context) error) error {
+		if fromGateway(ctx) {
+			return call(ctx)
+		}
+
+		var apiKey string
+		if header := l.APIKeyHeader(); header != "" {
+			apiKey = firstMetadata(ctx, strings.ToLower(header))
+		}
+		client := l.ClientKey(apiKey, reqctx.User(ctx), clientIP(ctx, proxies))
+		result, limit, err := l.Take(ctx, client, writeMethods[method])
+		if err != nil {
+			// Em caso de falha no store a chamada segue sem limitação
+			logger.ErrorContext(ctx, "Erro ao consultar rate limit", "error", err)
+			return call(ctx)
+		}
+
+		header := metadata.Pairs(
+			"ratelimit-limit", strconv.Itoa(limit.Burst),
+			"ratelimit-remaining", strconv.Itoa(result.Remaining),
+			"ratelimit-reset", strconv.Itoa(ceilSeconds(result.ResetAfter)),
+		)
+		if !result.Allowed {
+			header.Set("retry-after", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			_ = grpc.SetHeader(ctx, header)
+			return problemStatus(ctx, problem.RateLimited.Localized("request.rate_limited")).Err()
+		}
+		_ = grpc.SetHeader(ctx, header)
+		return call(ctx)
+	}
+}
+
+// ceilSeconds arredonda uma duração para cima em segundos
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// clientIP aplica aos metadados x-forwarded-for a mesma regra de proxies
+// confiáveis do HTTP
+func clientIP(ctx context.Context, proxies middleware.TrustedProxies) string {
+	remote := peerAddr(ctx)
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+	var hops []string
+	for _, value := range metadata.ValueFromIncomingContext(ctx, "x-forwarded-for") {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	return proxies.Resolve(remote, hops)
+}
@@ -0,0 +1,100 @@
+// Package rpc expõe o serviço de personalidades por gRPC, ao lado da API REST,
+// reutilizando o pacote service e o mesmo mapeamento de erros dos handlers.
+package rpc
+
+import (
+	"go-api-rest/internal/config"
+	"go-api-rest/internal/events"
+	"go-api-rest/internal/health"
+	"go-api-rest/internal/metrics"
+	"go-api-rest/internal/middleware"
+	"go-api-rest/internal/service"
+	"go-api-rest/internal/tracing"
+	"go-api-rest/pkg/logger"
+	pb "go-api-rest/pkg/pb/personality/v1"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Dependencies agrupa as dependências do servidor gRPC
+type Dependencies struct {
+	Config  *config.Config
+	Service service.PersonalityService
+	// Broker é opcional; quando nil WatchPersonalities responde UNIMPLEMENTED
+	Broker *events.Broker
+	// RateLimitStore é opcional; quando nil é criado a partir da configuração
+	RateLimitStore middleware.RateLimitStore
+	// Metrics é opcional; quando informado registra as métricas por método
+	Metrics *metrics.Metrics
+	// TracerProvider é opcional; quando informado cria spans de servidor por chamada
+	TracerProvider trace.TracerProvider
+	// Health é opcional; quando nil o serviço de health responde sem checks
+	Health *health.Registry
+}
+
+// NewServer cria o servidor gRPC com o PersonalityService, o serviço de health
+// e, quando habilitado, o de reflection. Os interceptors seguem a mesma ordem
+// dos middlewares HTTP.
+func NewServer(deps Dependencies) *grpc.Server {
+	cfg := deps.Config
+	proxies := middleware.ParseTrustedProxies(cfg.Server.TrustedProxies)
+
+	interceptors := []interceptor{requestID, locale, accessLog}
+	if deps.Metrics != nil {
+		interceptors = append(interceptors, instrument(deps.Metrics))
+	}
+	interceptors = append(interceptors, recovery)
+	if cfg.RateLimit.Enabled {
+		interceptors = append(interceptors, rateLimit(newRateLimiter(deps, proxies), proxies))
+	}
+
+	unaryInterceptors := make([]grpc.UnaryServerInterceptor, len(interceptors))
+	streamInterceptors := make([]grpc.StreamServerInterceptor, len(interceptors))
+	for i, in := range interceptors {
+		unaryInterceptors[i] = unary(in)
+		streamInterceptors[i] = stream(in)
+	}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+		grpc.MaxRecvMsgSize(int(cfg.Server.MaxBodyBytes)),
+	}
+	if deps.TracerProvider != nil {
+		opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler(
+			otelgrpc.WithTracerProvider(deps.TracerProvider),
+			otelgrpc.WithPropagators(tracing.Propagator()),
+		)))
+	}
+
+	server := grpc.NewServer(opts...)
+	pb.RegisterPersonalityServiceServer(server, &personalityServer{svc: deps.Service, broker: deps.Broker})
+
+	registry := deps.Health
+	if registry == nil {
+		registry = health.NewRegistry(cfg.Health.CheckTimeout)
+	}
+	healthpb.RegisterHealthServer(server, &healthServer{registry: registry})
+
+	if cfg.GRPC.Reflection {
+		reflection.Register(server)
+	}
+	return server
+}
+
+// newRateLimiter cria o limitador usando o store informado ou o configurado
+func newRateLimiter(deps Dependencies, proxies middleware.TrustedProxies) *middleware.RateLimiter {
+	store := deps.RateLimitStore
+	if store == nil {
+		var err error
+		store, err = middleware.NewRateLimitStore(deps.Config.RateLimit)
+		if err != nil {
+			logger.Error("Erro ao criar store de rate limit, usando memória", "error", err)
+			store = middleware.NewMemoryRateLimitStore()
+		}
+	}
+	return middleware.NewRateLimiter(deps.Config.RateLimit, store, proxies)
+}
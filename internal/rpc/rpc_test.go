@@ -0,0 +1,324 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-api-rest/internal/config"
+	"go-api-rest/internal/events"
+	"go-api-rest/internal/repository"
+	"go-api-rest/internal/service"
+	pb "go-api-rest/pkg/pb/personality/v1"
+	"go-api-rest/pkg/problem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testServer é um servidor gRPC sobre bufconn com um repositório em memória
+type testServer struct {
+	server *grpc.Server
+	conn   *grpc.ClientConn
+	client pb.PersonalityServiceClient
+}
+
+func newTestServer(t *testing.T, configure func(*config.Config)) *testServer {
+	t.Helper()
+	cfg := config.Load()
+	cfg.RateLimit.Enabled = false
+	cfg.GRPC.Reflection = true
+	if configure != nil {
+		configure(cfg)
+	}
+
+	broker := events.NewBroker(10, 0)
+	svc := service.NewPublishingPersonalityService(service.NewPersonalityService(repository.NewMemoryPersonalityRepository()), broker)
+	server := NewServer(Dependencies{Config: cfg, Service: svc, Broker: broker})
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Erro ao conectar ao servidor: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+	return &testServer{server: server, conn: conn, client: pb.NewPersonalityServiceClient(conn)}
+}
+
+func (s *testServer) create(t *testing.T, name string) *pb.Personality {
+	t.Helper()
+	p, err := s.client.CreatePersonality(context.Background(), &pb.CreatePersonalityRequest{Name: name, History: "Personalidade criada no teste"})
+	if err != nil {
+		t.Fatalf("Erro ao criar personalidade: %v", err)
+	}
+	return p
+}
+
+// errorReason retorna o código do problema levado no ErrorInfo
+func errorReason(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+func TestServer_CRUD(t *testing.T) {
+	s := newTestServer(t, nil)
+	ctx := context.Background()
+
+	created := s.create(t, "Ada Lovelace")
+	if created.GetId() == 0 || created.GetCreateTime() == nil {
+		t.Fatalf("Personalidade criada incompleta: %v", created)
+	}
+
+	got, err := s.client.GetPersonality(ctx, &pb.GetPersonalityRequest{Id: created.GetId()})
+	if err != nil || got.GetName() != "Ada Lovelace" {
+		t.Fatalf("Esperava a personalidade criada, obteve %v (%v)", got, err)
+	}
+
+	updated, err := s.client.UpdatePersonality(ctx, &pb.UpdatePersonalityRequest{Id: created.GetId(), History: "Primeira programadora da história"})
+	if err != nil || updated.GetHistory() != "Primeira programadora da história" || updated.GetName() != "Ada Lovelace" {
+		t.Fatalf("Atualização inesperada: %v (%v)", updated, err)
+	}
+
+	if _, err := s.client.DeletePersonality(ctx, &pb.DeletePersonalityRequest{Id: created.GetId()}); err != nil {
+		t.Fatalf("Erro ao remover: %v", err)
+	}
+	_, err = s.client.GetPersonality(ctx, &pb.GetPersonalityRequest{Id: created.GetId()})
+	if status.Code(err) != codes.NotFound || errorReason(err) != "PERSONALITY_NOT_FOUND" {
+		t.Errorf("Esperava NOT_FOUND com PERSONALITY_NOT_FOUND, obteve %v (%s)", err, errorReason(err))
+	}
+}
+
+func TestServer_ListStreamsAllPages(t *testing.T) {
+	s := newTestServer(t, nil)
+	total := listBatchSize + 5
+	for i := 0; i < total; i++ {
+		if _, err := s.client.CreatePersonality(context.Background(), &pb.CreatePersonalityRequest{
+			Name:    "Personalidade " + strings.Repeat("a", 1+i%50) + string(rune('a'+i%26)),
+			History: "Personalidade criada no teste",
+		}); err != nil {
+			t.Fatalf("Erro ao criar personalidade %d: %v", i, err)
+		}
+	}
+
+	stream, err := s.client.ListPersonalities(context.Background(), &pb.ListPersonalitiesRequest{})
+	if err != nil {
+		t.Fatalf("Erro ao listar: %v", err)
+	}
+	seen := make(map[uint32]bool)
+	for {
+		p, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Erro no stream: %v", err)
+		}
+		seen[p.GetId()] = true
+	}
+	if len(seen) != total {
+		t.Errorf("Esperava %d personalidades distintas, obteve %d", total, len(seen))
+	}
+}
+
+func TestServer_ValidationErrors(t *testing.T) {
+	s := newTestServer(t, nil)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "en")
+
+	_, err := s.client.CreatePersonality(ctx, &pb.CreatePersonalityRequest{Name: "A"})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument || errorReason(err) != problem.ValidationFailed.Code {
+		t.Fatalf("Esperava INVALID_ARGUMENT com VALIDATION_FAILED, obteve %v", err)
+	}
+	if st.Message() != "The provided data is invalid" {
+		t.Errorf("Esperava a mensagem em inglês, obteve %q", st.Message())
+	}
+
+	fields := make(map[string]bool)
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				fields[violation.GetField()] = true
+			}
+		}
+	}
+	if !fields["name"] || !fields["history"] {
+		t.Errorf("Esperava violações em name e history, obteve %v", fields)
+	}
+}
+
+func TestServer_WatchFiltersByID(t *testing.T) {
+	s := newTestServer(t, nil)
+	first := s.create(t, "Ada Lovelace")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := s.client.WatchPersonalities(ctx, &pb.WatchPersonalitiesRequest{Ids: []uint32{first.GetId()}})
+	if err != nil {
+		t.Fatalf("Erro ao assinar: %v", err)
+	}
+	// Os cabeçalhos chegam quando a assinatura está ativa
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("Erro ao receber cabeçalhos: %v", err)
+	}
+
+	s.create(t, "Alan Turing")
+	if _, err := s.client.UpdatePersonality(context.Background(), &pb.UpdatePersonalityRequest{Id: first.GetId(), Name: "Augusta Ada King"}); err != nil {
+		t.Fatalf("Erro ao atualizar: %v", err)
+	}
+
+	e, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Erro ao receber evento: %v", err)
+	}
+	if e.GetType() != pb.PersonalityEvent_TYPE_UPDATED || e.GetPersonalityId() != first.GetId() || e.GetPersonality().GetName() != "Augusta Ada King" {
+		t.Errorf("Evento inesperado: %v", e)
+	}
+}
+
+func TestServer_RequestIDAndLocaleHeaders(t *testing.T) {
+	s := newTestServer(t, nil)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "abc-123", "accept-language", "es")
+
+	var header metadata.MD
+	_, err := s.client.GetPersonality(ctx, &pb.GetPersonalityRequest{Id: 99}, grpc.Header(&header))
+	if got := header.Get("x-request-id"); len(got) != 1 || got[0] != "abc-123" {
+		t.Errorf("Esperava o x-request-id recebido, obteve %v", got)
+	}
+	if got := header.Get("content-language"); len(got) != 1 || got[0] != "es" {
+		t.Errorf("Esperava content-language es, obteve %v", got)
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Esperava NOT_FOUND, obteve %v", err)
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.RateLimit = config.RateLimitConfig{Enabled: true, ReadRate: 0.001, ReadBurst: 1, WriteRate: 0.001, WriteBurst: 1, Store: "memory"}
+	})
+	ctx := context.Background()
+
+	if _, err := s.client.GetPersonality(ctx, &pb.GetPersonalityRequest{Id: 1}); status.Code(err) != codes.NotFound {
+		t.Fatalf("Esperava que a primeira leitura passasse, obteve %v", err)
+	}
+	var trailer, header metadata.MD
+	_, err := s.client.GetPersonality(ctx, &pb.GetPersonalityRequest{Id: 1}, grpc.Header(&header), grpc.Trailer(&trailer))
+	if status.Code(err) != codes.ResourceExhausted || errorReason(err) != problem.RateLimited.Code {
+		t.Fatalf("Esperava RESOURCE_EXHAUSTED com RATE_LIMITED, obteve %v", err)
+	}
+	if len(header.Get("retry-after")) != 1 {
+		t.Errorf("Esperava retry-after nos cabeçalhos, obteve %v", header)
+	}
+
+	// A escrita tem o próprio limite
+	if _, err := s.client.CreatePersonality(ctx, &pb.CreatePersonalityRequest{Name: "Ada Lovelace", History: "Matemática inglesa"}); err != nil {
+		t.Errorf("Esperava que a escrita passasse, obteve %v", err)
+	}
+}
+
+func TestServer_HealthAndReflection(t *testing.T) {
+	s := newTestServer(t, nil)
+	ctx := context.Background()
+
+	healthClient := healthpb.NewHealthClient(s.conn)
+	for _, name := range []string{"", pb.PersonalityService_ServiceDesc.ServiceName} {
+		resp, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: name})
+		if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Esperava SERVING para %q, obteve %v (%v)", name, resp, err)
+		}
+	}
+	if _, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: "desconhecido"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Esperava NOT_FOUND para serviço desconhecido, obteve %v", err)
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(s.conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatalf("Erro ao abrir reflection: %v", err)
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}}); err != nil {
+		t.Fatalf("Erro ao consultar reflection: %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Erro na resposta do reflection: %v", err)
+	}
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	if !strings.Contains(strings.Join(services, ","), pb.PersonalityService_ServiceDesc.ServiceName) {
+		t.Errorf("Esperava o PersonalityService no reflection, obteve %v", services)
+	}
+}
+
+func TestGateway_TranslatesHTTP(t *testing.T) {
+	s := newTestServer(t, nil)
+	gateway, err := NewGateway(context.Background(), s.server)
+	if err != nil {
+		t.Fatalf("Erro ao criar o gateway: %v", err)
+	}
+	defer gateway.Close()
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/rpc/v1/personalities", "application/json", strings.NewReader(`{"name":"Ada Lovelace","history":"Matemática inglesa"}`))
+	if err != nil {
+		t.Fatalf("Erro ao criar pelo gateway: %v", err)
+	}
+	var created struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	json.NewDecoder(resp.Body).Decode(&created)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || created.Name != "Ada Lovelace" {
+		t.Fatalf("Esperava 200 com a personalidade criada, obteve %d %+v", resp.StatusCode, created)
+	}
+
+	resp, err = http.Get(server.URL + "/rpc/v1/personalities/99")
+	if err != nil {
+		t.Fatalf("Erro ao consultar pelo gateway: %v", err)
+	}
+	var body problem.Problem
+	json.NewDecoder(resp.Body).Decode(&body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || resp.Header.Get("Content-Type") != problem.ContentType || body.Code != "PERSONALITY_NOT_FOUND" {
+		t.Errorf("Esperava 404 problem+json com PERSONALITY_NOT_FOUND, obteve %d %s %+v", resp.StatusCode, resp.Header.Get("Content-Type"), body)
+	}
+
+	resp, err = http.Post(server.URL+"/rpc/v1/personalities", "application/json", strings.NewReader(`{"name":"A"}`))
+	if err != nil {
+		t.Fatalf("Erro ao criar pelo gateway: %v", err)
+	}
+	body = problem.Problem{}
+	json.NewDecoder(resp.Body).Decode(&body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest || body.Code != problem.ValidationFailed.Code || len(body.Errors) != 2 {
+		t.Errorf("Esperava 400 com os campos inválidos, obteve %d %+v", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,174 @@
+package rpc
+
+import (
+	"context"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/events"
+	"go-api-rest/internal/service"
+	"go-api-rest/pkg/i18n"
+	pb "go-api-rest/pkg/pb/personality/v1"
+	"go-api-rest/pkg/problem"
+	customValidator "go-api-rest/pkg/validator"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// listBatchSize é o tamanho das páginas lidas do serviço em ListPersonalities
+const listBatchSize = 100
+
+// personalityServer implementa pb.PersonalityServiceServer sobre o mesmo
+// serviço usado pelos handlers HTTP
+type personalityServer struct {
+	pb.UnimplementedPersonalityServiceServer
+	svc    service.PersonalityService
+	broker *events.Broker
+}
+
+func (s *personalityServer) GetPersonality(ctx context.Context, req *pb.GetPersonalityRequest) (*pb.Personality, error) {
+	p, err := s.svc.GetByID(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toProto(p), nil
+}
+
+// ListPersonalities lê o serviço em páginas e envia os itens conforme chegam.
+// Alterações concorrentes podem deslocar itens entre as páginas.
+func (s *personalityServer) ListPersonalities(req *pb.ListPersonalitiesRequest, stream grpc.ServerStreamingServer[pb.Personality]) error {
+	ctx := stream.Context()
+	filter := dto.PersonalityFilter{Name: req.GetName(), Text: req.GetText()}
+	if req.GetUpdatedSince() != nil {
+		filter.UpdatedSince = req.GetUpdatedSince().AsTime()
+	}
+	if err := validate(ctx, filter); err != nil {
+		return toStatus(ctx, err)
+	}
+
+	for page := 1; ; page++ {
+		result, err := s.svc.List(ctx, filter, dto.PageRequest{Page: page, PerPage: listBatchSize})
+		if err != nil {
+			return toStatus(ctx, err)
+		}
+		for i := range result.Items {
+			if err := stream.Send(toProto(&result.Items[i])); err != nil {
+				return err
+			}
+		}
+		if len(result.Items) < listBatchSize || int64(page*listBatchSize) >= result.Total {
+			return nil
+		}
+	}
+}
+
+func (s *personalityServer) CreatePersonality(ctx context.Context, req *pb.CreatePersonalityRequest) (*pb.Personality, error) {
+	create := &dto.CreatePersonalityRequest{Name: req.GetName(), History: req.GetHistory()}
+	if err := validate(ctx, create); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	p, err := s.svc.Create(ctx, create)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toProto(p), nil
+}
+
+func (s *personalityServer) UpdatePersonality(ctx context.Context, req *pb.UpdatePersonalityRequest) (*pb.Personality, error) {
+	update := &dto.UpdatePersonalityRequest{Name: req.GetName(), History: req.GetHistory()}
+	if err := validate(ctx, update); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	p, err := s.svc.Update(ctx, uint(req.GetId()), update)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toProto(p), nil
+}
+
+func (s *personalityServer) DeletePersonality(ctx context.Context, req *pb.DeletePersonalityRequest) (*emptypb.Empty, error) {
+	if err := s.svc.Delete(ctx, uint(req.GetId())); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// WatchPersonalities assina o broker e repassa os eventos dos IDs pedidos. Os
+// cabeçalhos da resposta são enviados assim que a assinatura está ativa, para
+// o cliente saber a partir de quando as alterações serão recebidas.
+func (s *personalityServer) WatchPersonalities(req *pb.WatchPersonalitiesRequest, stream grpc.ServerStreamingServer[pb.PersonalityEvent]) error {
+	ctx := stream.Context()
+	if s.broker == nil {
+		return status.Error(codes.Unimplemented, i18n.T(ctx, "events.unavailable"))
+	}
+	sub := s.broker.Subscribe()
+	defer sub.Close()
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	ids := make(map[uint]bool, len(req.GetIds()))
+	for _, id := range req.GetIds() {
+		ids[uint(id)] = true
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return toStatus(ctx, ctx.Err())
+		case e, ok := <-sub.Events():
+			if !ok {
+				return status.Error(codes.ResourceExhausted, i18n.T(ctx, "events.slow_consumer"))
+			}
+			if len(ids) > 0 && !ids[e.PersonalityID] {
+				continue
+			}
+			if err := stream.Send(eventToProto(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// validate aplica as regras de validação do DTO, como nos handlers HTTP
+func validate(ctx context.Context, v interface{}) error {
+	validationErrors, err := customValidator.ValidateStructContext(ctx, v)
+	if err != nil {
+		return err
+	}
+	if validationErrors != nil {
+		return problem.Validation(validationErrors)
+	}
+	return nil
+}
+
+func toProto(p *dto.PersonalityResponse) *pb.Personality {
+	return &pb.Personality{
+		Id:         uint32(p.ID),
+		Name:       p.Name,
+		History:    p.History,
+		Version:    uint32(p.Version),
+		CreateTime: timestamppb.New(p.CreatedAt),
+		UpdateTime: timestamppb.New(p.UpdatedAt),
+	}
+}
+
+var eventTypes = map[events.Type]pb.PersonalityEvent_Type{
+	events.Created: pb.PersonalityEvent_TYPE_CREATED,
+	events.Updated: pb.PersonalityEvent_TYPE_UPDATED,
+	events.Deleted: pb.PersonalityEvent_TYPE_DELETED,
+}
+
+func eventToProto(e events.Event) *pb.PersonalityEvent {
+	out := &pb.PersonalityEvent{
+		Sequence:      e.ID,
+		Type:          eventTypes[e.Type],
+		PersonalityId: uint32(e.PersonalityID),
+		Time:          timestamppb.New(e.Time),
+	}
+	if e.Personality != nil {
+		out.Personality = toProto(e.Personality)
+	}
+	return out
+}
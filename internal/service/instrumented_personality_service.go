@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/metrics"
+	"time"
+)
+
+// instrumentedPersonalityService registra métricas de cada operação do serviço
+type instrumentedPersonalityService struct {
+	next    PersonalityService
+	metrics *metrics.Metrics
+}
+
+// NewInstrumentedPersonalityService envolve um PersonalityService com métricas por operação
+func NewInstrumentedPersonalityService(next PersonalityService, m *metrics.Metrics) PersonalityService {
+	return &instrumentedPersonalityService{next: next, metrics: m}
+}
+
+func (s *instrumentedPersonalityService) Create(ctx context.Context, req *dto.CreatePersonalityRequest) (result *dto.PersonalityResponse, err error) {
+	defer s.observe("create", time.Now(), &err)
+	return s.next.Create(ctx, req)
+}
+
+func (s *instrumentedPersonalityService) GetAll(ctx context.Context) (result []dto.PersonalityResponse, err error) {
+	defer s.observe("get_all", time.Now(), &err)
+	return s.next.GetAll(ctx)
+}
+
+func (s *instrumentedPersonalityService) List(ctx context.Context, filter dto.PersonalityFilter, page dto.PageRequest) (result *dto.PersonalityPage, err error) {
+	defer s.observe("list", time.Now(), &err)
+	return s.next.List(ctx, filter, page)
+}
+
+func (s *instrumentedPersonalityService) GetByID(ctx context.Context, id uint) (result *dto.PersonalityResponse, err error) {
+	defer s.observe("get_by_id", time.Now(), &err)
+	return s.next.GetByID(ctx, id)
+}
+
+func (s *instrumentedPersonalityService) GetByIDs(ctx context.Context, ids []uint) (result []dto.PersonalityResponse, err error) {
+	defer s.observe("get_by_ids", time.Now(), &err)
+	return s.next.GetByIDs(ctx, ids)
+}
+
+func (s *instrumentedPersonalityService) Update(ctx context.Context, id uint, req *dto.UpdatePersonalityRequest) (result *dto.PersonalityResponse, err error) {
+	defer s.observe("update", time.Now(), &err)
+	return s.next.Update(ctx, id, req)
+}
+
+func (s *instrumentedPersonalityService) Delete(ctx context.Context, id uint) (err error) {
+	defer s.observe("delete", time.Now(), &err)
+	return s.next.Delete(ctx, id)
+}
+
+// observe registra a operação ao final da chamada
+func (s *instrumentedPersonalityService) observe(operation string, start time.Time, err *error) {
+	s.metrics.ObserveOperation(operation, operationResult(*err), time.Since(start))
+}
+
+// operationResult classifica o erro: erros de negócio não são falhas do serviço
+func operationResult(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case errors.Is(err, ErrPersonalityNotFound),
+		errors.Is(err, ErrPersonalityAlreadyExists),
+		errors.Is(err, ErrInvalidID):
+		return "rejected"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/metrics"
+	"go-api-rest/models"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// failingRepository simula uma falha de infraestrutura na listagem
+type failingRepository struct {
+	*mockPersonalityRepository
+}
+
+func (failingRepository) FindAll(context.Context) ([]models.Personality, error) {
+	return nil, errors.New("conexão recusada")
+}
+
+func TestInstrumentedService_CountsOperationsByResult(t *testing.T) {
+	m := metrics.New()
+	service := NewInstrumentedPersonalityService(NewPersonalityService(failingRepository{newMockRepository()}), m)
+	ctx := context.Background()
+
+	created, err := service.Create(ctx, &dto.CreatePersonalityRequest{Name: "Ada Lovelace", History: "Matemática inglesa"})
+	if err != nil {
+		t.Fatalf("Esperava sucesso, mas obteve erro: %v", err)
+	}
+	if _, err := service.GetByID(ctx, created.ID); err != nil {
+		t.Fatalf("Esperava sucesso, mas obteve erro: %v", err)
+	}
+	// Erros de negócio contam como rejected, falhas do repositório como error
+	if _, err := service.GetByID(ctx, 99); err == nil {
+		t.Fatal("Esperava erro ao buscar personalidade inexistente")
+	}
+	if _, err := service.Create(ctx, &dto.CreatePersonalityRequest{Name: "Ada Lovelace", History: "Duplicada"}); err == nil {
+		t.Fatal("Esperava erro ao criar personalidade duplicada")
+	}
+	if _, err := service.GetAll(ctx); err == nil {
+		t.Fatal("Esperava erro do repositório")
+	}
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	for _, expected := range []string{
+		`go_api_rest_service_operations_total{operation="create",result="success"} 1`,
+		`go_api_rest_service_operations_total{operation="create",result="rejected"} 1`,
+		`go_api_rest_service_operations_total{operation="get_by_id",result="success"} 1`,
+		`go_api_rest_service_operations_total{operation="get_by_id",result="rejected"} 1`,
+		`go_api_rest_service_operations_total{operation="get_all",result="error"} 1`,
+		`go_api_rest_service_operation_duration_seconds_count{operation="create"} 2`,
+		`go_api_rest_service_operation_duration_seconds_count{operation="get_all"} 1`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("Esperava a série %q", expected)
+		}
+	}
+}
@@ -1,27 +1,32 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"go-api-rest/internal/dto"
 	"go-api-rest/internal/repository"
 	"go-api-rest/models"
+	"go-api-rest/pkg/i18n"
+	"go-api-rest/pkg/reqctx"
 
 	"gorm.io/gorm"
 )
 
 var (
-	ErrPersonalityNotFound      = errors.New("personalidade não encontrada")
-	ErrPersonalityAlreadyExists = errors.New("já existe uma personalidade com esse nome")
-	ErrInvalidID                = errors.New("ID inválido")
+	ErrPersonalityNotFound      = i18n.NewError("personality.not_found")
+	ErrPersonalityAlreadyExists = i18n.NewError("personality.already_exists")
+	ErrInvalidID                = i18n.NewError("personality.invalid_id")
 )
 
 // PersonalityService define a interface para lógica de negócio
 type PersonalityService interface {
-	Create(req *dto.CreatePersonalityRequest) (*dto.PersonalityResponse, error)
-	GetAll() ([]dto.PersonalityResponse, error)
-	GetByID(id uint) (*dto.PersonalityResponse, error)
-	Update(id uint, req *dto.UpdatePersonalityRequest) (*dto.PersonalityResponse, error)
-	Delete(id uint) error
+	Create(ctx context.Context, req *dto.CreatePersonalityRequest) (*dto.PersonalityResponse, error)
+	GetAll(ctx context.Context) ([]dto.PersonalityResponse, error)
+	List(ctx context.Context, filter dto.PersonalityFilter, page dto.PageRequest) (*dto.PersonalityPage, error)
+	GetByID(ctx context.Context, id uint) (*dto.PersonalityResponse, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]dto.PersonalityResponse, error)
+	Update(ctx context.Context, id uint, req *dto.UpdatePersonalityRequest) (*dto.PersonalityResponse, error)
+	Delete(ctx context.Context, id uint) error
 }
 
 type personalityService struct {
@@ -33,9 +38,12 @@ func NewPersonalityService(repo repository.PersonalityRepository) PersonalitySer
 	return &personalityService{repo: repo}
 }
 
-func (s *personalityService) Create(req *dto.CreatePersonalityRequest) (*dto.PersonalityResponse, error) {
+func (s *personalityService) Create(ctx context.Context, req *dto.CreatePersonalityRequest) (*dto.PersonalityResponse, error) {
+	// Leituras feitas durante a escrita precisam ver o estado mais recente
+	ctx = reqctx.WithPrimaryRead(ctx)
+
 	// Verificar se já existe uma personalidade com esse nome
-	exists, err := s.repo.ExistsByName(req.Name)
+	exists, err := s.repo.ExistsByName(ctx, req.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -48,15 +56,19 @@ func (s *personalityService) Create(req *dto.CreatePersonalityRequest) (*dto.Per
 		History: req.History,
 	}
 
-	if err := s.repo.Create(personality); err != nil {
+	if err := s.repo.Create(ctx, personality); err != nil {
+		// Duas criações concorrentes podem passar pelo ExistsByName
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrPersonalityAlreadyExists
+		}
 		return nil, err
 	}
 
 	return s.toDTO(personality), nil
 }
 
-func (s *personalityService) GetAll() ([]dto.PersonalityResponse, error) {
-	personalities, err := s.repo.FindAll()
+func (s *personalityService) GetAll(ctx context.Context) ([]dto.PersonalityResponse, error) {
+	personalities, err := s.repo.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -69,12 +81,30 @@ func (s *personalityService) GetAll() ([]dto.PersonalityResponse, error) {
 	return response, nil
 }
 
-func (s *personalityService) GetByID(id uint) (*dto.PersonalityResponse, error) {
+func (s *personalityService) List(ctx context.Context, filter dto.PersonalityFilter, page dto.PageRequest) (*dto.PersonalityPage, error) {
+	personalities, total, err := s.repo.FindPage(ctx, repository.PersonalityFilter{
+		Name:         filter.Name,
+		Text:         filter.Text,
+		UpdatedSince: filter.UpdatedSince,
+	}, page.Offset(), page.PerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.PersonalityResponse, len(personalities))
+	for i, p := range personalities {
+		items[i] = *s.toDTO(&p)
+	}
+
+	return &dto.PersonalityPage{Items: items, Total: total}, nil
+}
+
+func (s *personalityService) GetByID(ctx context.Context, id uint) (*dto.PersonalityResponse, error) {
 	if id == 0 {
 		return nil, ErrInvalidID
 	}
 
-	personality, err := s.repo.FindByID(id)
+	personality, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrPersonalityNotFound
@@ -85,12 +115,30 @@ func (s *personalityService) GetByID(id uint) (*dto.PersonalityResponse, error)
 	return s.toDTO(personality), nil
 }
 
-func (s *personalityService) Update(id uint, req *dto.UpdatePersonalityRequest) (*dto.PersonalityResponse, error) {
+// GetByIDs busca várias personalidades de uma vez, em ordem de ID; IDs
+// inexistentes ficam de fora do resultado
+func (s *personalityService) GetByIDs(ctx context.Context, ids []uint) ([]dto.PersonalityResponse, error) {
+	personalities, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.PersonalityResponse, len(personalities))
+	for i, p := range personalities {
+		response[i] = *s.toDTO(&p)
+	}
+
+	return response, nil
+}
+
+func (s *personalityService) Update(ctx context.Context, id uint, req *dto.UpdatePersonalityRequest) (*dto.PersonalityResponse, error) {
+	ctx = reqctx.WithPrimaryRead(ctx)
+
 	if id == 0 {
 		return nil, ErrInvalidID
 	}
 
-	personality, err := s.repo.FindByID(id)
+	personality, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrPersonalityNotFound
@@ -101,7 +149,7 @@ func (s *personalityService) Update(id uint, req *dto.UpdatePersonalityRequest)
 	// Atualizar apenas campos não vazios
 	if req.Name != "" {
 		// Verificar se o novo nome já existe em outra personalidade
-		exists, err := s.repo.ExistsByName(req.Name)
+		exists, err := s.repo.ExistsByName(ctx, req.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -115,20 +163,28 @@ func (s *personalityService) Update(id uint, req *dto.UpdatePersonalityRequest)
 		personality.History = req.History
 	}
 
-	if err := s.repo.Update(personality); err != nil {
+	if err := s.repo.Update(ctx, personality); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrDuplicatedKey):
+			return nil, ErrPersonalityAlreadyExists
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, ErrPersonalityNotFound
+		}
 		return nil, err
 	}
 
 	return s.toDTO(personality), nil
 }
 
-func (s *personalityService) Delete(id uint) error {
+func (s *personalityService) Delete(ctx context.Context, id uint) error {
+	ctx = reqctx.WithPrimaryRead(ctx)
+
 	if id == 0 {
 		return ErrInvalidID
 	}
 
 	// Verificar se existe antes de deletar
-	_, err := s.repo.FindByID(id)
+	_, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrPersonalityNotFound
@@ -136,14 +192,23 @@ func (s *personalityService) Delete(id uint) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPersonalityNotFound
+		}
+		return err
+	}
+	return nil
 }
 
 // toDTO converte o modelo para DTO
 func (s *personalityService) toDTO(p *models.Personality) *dto.PersonalityResponse {
 	return &dto.PersonalityResponse{
-		ID:      p.ID,
-		Name:    p.Name,
-		History: p.History,
+		ID:        p.ID,
+		Name:      p.Name,
+		History:   p.History,
+		Version:   p.Version,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
 	}
 }
@@ -1,9 +1,12 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"go-api-rest/internal/dto"
+	"go-api-rest/internal/repository"
 	"go-api-rest/models"
+	"sort"
 	"testing"
 
 	"gorm.io/gorm"
@@ -22,14 +25,14 @@ func newMockRepository() *mockPersonalityRepository {
 	}
 }
 
-func (m *mockPersonalityRepository) Create(personality *models.Personality) error {
+func (m *mockPersonalityRepository) Create(_ context.Context, personality *models.Personality) error {
 	personality.ID = m.nextID
 	m.personalities[m.nextID] = personality
 	m.nextID++
 	return nil
 }
 
-func (m *mockPersonalityRepository) FindAll() ([]models.Personality, error) {
+func (m *mockPersonalityRepository) FindAll(_ context.Context) ([]models.Personality, error) {
 	personalities := make([]models.Personality, 0, len(m.personalities))
 	for _, p := range m.personalities {
 		personalities = append(personalities, *p)
@@ -37,7 +40,21 @@ func (m *mockPersonalityRepository) FindAll() ([]models.Personality, error) {
 	return personalities, nil
 }
 
-func (m *mockPersonalityRepository) FindByID(id uint) (*models.Personality, error) {
+func (m *mockPersonalityRepository) FindPage(ctx context.Context, filter repository.PersonalityFilter, offset, limit int) ([]models.Personality, int64, error) {
+	var all []models.Personality
+	for _, p := range m.personalities {
+		if filter.Matches(*p) {
+			all = append(all, *p)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	if offset >= len(all) {
+		return nil, int64(len(all)), nil
+	}
+	return all[offset:min(offset+limit, len(all))], int64(len(all)), nil
+}
+
+func (m *mockPersonalityRepository) FindByID(_ context.Context, id uint) (*models.Personality, error) {
 	p, exists := m.personalities[id]
 	if !exists {
 		return nil, gorm.ErrRecordNotFound
@@ -45,7 +62,17 @@ func (m *mockPersonalityRepository) FindByID(id uint) (*models.Personality, erro
 	return p, nil
 }
 
-func (m *mockPersonalityRepository) Update(personality *models.Personality) error {
+func (m *mockPersonalityRepository) FindByIDs(_ context.Context, ids []uint) ([]models.Personality, error) {
+	var found []models.Personality
+	for _, id := range ids {
+		if p, exists := m.personalities[id]; exists {
+			found = append(found, *p)
+		}
+	}
+	return found, nil
+}
+
+func (m *mockPersonalityRepository) Update(_ context.Context, personality *models.Personality) error {
 	if _, exists := m.personalities[personality.ID]; !exists {
 		return gorm.ErrRecordNotFound
 	}
@@ -53,7 +80,7 @@ func (m *mockPersonalityRepository) Update(personality *models.Personality) erro
 	return nil
 }
 
-func (m *mockPersonalityRepository) Delete(id uint) error {
+func (m *mockPersonalityRepository) Delete(_ context.Context, id uint) error {
 	if _, exists := m.personalities[id]; !exists {
 		return gorm.ErrRecordNotFound
 	}
@@ -61,7 +88,7 @@ func (m *mockPersonalityRepository) Delete(id uint) error {
 	return nil
 }
 
-func (m *mockPersonalityRepository) ExistsByName(name string) (bool, error) {
+func (m *mockPersonalityRepository) ExistsByName(_ context.Context, name string) (bool, error) {
 	for _, p := range m.personalities {
 		if p.Name == name {
 			return true, nil
@@ -80,7 +107,7 @@ func TestCreatePersonality_Success(t *testing.T) {
 		History: "Matemático e cientista da computação britânico",
 	}
 
-	result, err := service.Create(req)
+	result, err := service.Create(context.Background(), req)
 
 	if err != nil {
 		t.Errorf("Esperava sucesso, mas obteve erro: %v", err)
@@ -105,13 +132,13 @@ func TestCreatePersonality_DuplicateName(t *testing.T) {
 	}
 
 	// Primeira criação deve funcionar
-	_, err := service.Create(req)
+	_, err := service.Create(context.Background(), req)
 	if err != nil {
 		t.Fatalf("Primeira criação falhou: %v", err)
 	}
 
 	// Segunda criação com mesmo nome deve falhar
-	_, err = service.Create(req)
+	_, err = service.Create(context.Background(), req)
 	if err == nil {
 		t.Error("Esperava erro de nome duplicado, mas não obteve erro")
 	}
@@ -130,10 +157,10 @@ func TestGetByID_Success(t *testing.T) {
 		Name:    "Alan Turing",
 		History: "Matemático e cientista da computação britânico",
 	}
-	created, _ := service.Create(req)
+	created, _ := service.Create(context.Background(), req)
 
 	// Buscar por ID
-	result, err := service.GetByID(created.ID)
+	result, err := service.GetByID(context.Background(), created.ID)
 
 	if err != nil {
 		t.Errorf("Esperava sucesso, mas obteve erro: %v", err)
@@ -148,7 +175,7 @@ func TestGetByID_NotFound(t *testing.T) {
 	repo := newMockRepository()
 	service := NewPersonalityService(repo)
 
-	_, err := service.GetByID(999)
+	_, err := service.GetByID(context.Background(), 999)
 
 	if err == nil {
 		t.Error("Esperava erro, mas não obteve erro")
@@ -163,7 +190,7 @@ func TestGetByID_InvalidID(t *testing.T) {
 	repo := newMockRepository()
 	service := NewPersonalityService(repo)
 
-	_, err := service.GetByID(0)
+	_, err := service.GetByID(context.Background(), 0)
 
 	if err == nil {
 		t.Error("Esperava erro, mas não obteve erro")
@@ -183,14 +210,14 @@ func TestUpdate_Success(t *testing.T) {
 		Name:    "Alan Turing",
 		History: "Matemático e cientista da computação britânico",
 	}
-	created, _ := service.Create(createReq)
+	created, _ := service.Create(context.Background(), createReq)
 
 	// Atualizar
 	updateReq := &dto.UpdatePersonalityRequest{
 		Name:    "Alan Mathison Turing",
 		History: "Matemático, cientista da computação e criptoanalista britânico",
 	}
-	result, err := service.Update(created.ID, updateReq)
+	result, err := service.Update(context.Background(), created.ID, updateReq)
 
 	if err != nil {
 		t.Errorf("Esperava sucesso, mas obteve erro: %v", err)
@@ -210,17 +237,17 @@ func TestDelete_Success(t *testing.T) {
 		Name:    "Alan Turing",
 		History: "Matemático e cientista da computação britânico",
 	}
-	created, _ := service.Create(req)
+	created, _ := service.Create(context.Background(), req)
 
 	// Deletar
-	err := service.Delete(created.ID)
+	err := service.Delete(context.Background(), created.ID)
 
 	if err != nil {
 		t.Errorf("Esperava sucesso, mas obteve erro: %v", err)
 	}
 
 	// Verificar se foi deletado
-	_, err = service.GetByID(created.ID)
+	_, err = service.GetByID(context.Background(), created.ID)
 	if !errors.Is(err, ErrPersonalityNotFound) {
 		t.Error("Personalidade deveria ter sido deletada")
 	}
@@ -238,11 +265,11 @@ func TestGetAll_Success(t *testing.T) {
 	}
 
 	for _, p := range personalities {
-		service.Create(&p)
+		service.Create(context.Background(), &p)
 	}
 
 	// Buscar todas
-	result, err := service.GetAll()
+	result, err := service.GetAll(context.Background())
 
 	if err != nil {
 		t.Errorf("Esperava sucesso, mas obteve erro: %v", err)
@@ -252,3 +279,54 @@ func TestGetAll_Success(t *testing.T) {
 		t.Errorf("Esperava %d personalidades, mas obteve %d", len(personalities), len(result))
 	}
 }
+
+func TestList_Paginates(t *testing.T) {
+	repo := newMockRepository()
+	service := NewPersonalityService(repo)
+
+	for _, name := range []string{"Alan Turing", "Ada Lovelace", "Grace Hopper"} {
+		service.Create(context.Background(), &dto.CreatePersonalityRequest{Name: name, History: "Pioneira da computação"})
+	}
+
+	page, err := service.List(context.Background(), dto.PersonalityFilter{}, dto.PageRequest{Page: 2, PerPage: 2})
+	if err != nil {
+		t.Fatalf("Esperava sucesso, mas obteve erro: %v", err)
+	}
+	if page.Total != 3 {
+		t.Errorf("Esperava total 3, mas obteve %d", page.Total)
+	}
+	if len(page.Items) != 1 || page.Items[0].Name != "Grace Hopper" {
+		t.Errorf("Esperava só Grace Hopper na página 2, mas obteve %+v", page.Items)
+	}
+}
+
+func TestList_Filter(t *testing.T) {
+	repo := newMockRepository()
+	service := NewPersonalityService(repo)
+
+	service.Create(context.Background(), &dto.CreatePersonalityRequest{Name: "Alan Turing", History: "Quebrou a Enigma"})
+	service.Create(context.Background(), &dto.CreatePersonalityRequest{Name: "Ada Lovelace", History: "Primeira programadora"})
+
+	page, err := service.List(context.Background(), dto.PersonalityFilter{Text: "enigma"}, dto.PageRequest{Page: 1, PerPage: 10})
+	if err != nil {
+		t.Fatalf("Esperava sucesso, mas obteve erro: %v", err)
+	}
+	if page.Total != 1 || len(page.Items) != 1 || page.Items[0].Name != "Alan Turing" {
+		t.Errorf("Esperava só Alan Turing, mas obteve %+v", page)
+	}
+}
+
+func TestGetByIDs_SkipsMissing(t *testing.T) {
+	repo := newMockRepository()
+	service := NewPersonalityService(repo)
+
+	created, _ := service.Create(context.Background(), &dto.CreatePersonalityRequest{Name: "Ada Lovelace", History: "Primeira programadora"})
+
+	result, err := service.GetByIDs(context.Background(), []uint{created.ID, 99})
+	if err != nil {
+		t.Fatalf("Esperava sucesso, mas obteve erro: %v", err)
+	}
+	if len(result) != 1 || result[0].ID != created.ID {
+		t.Errorf("Esperava só a personalidade %d, mas obteve %+v", created.ID, result)
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/events"
+)
+
+// publishingPersonalityService publica no broker as alterações bem-sucedidas
+type publishingPersonalityService struct {
+	next   PersonalityService
+	broker *events.Broker
+}
+
+// NewPublishingPersonalityService envolve um PersonalityService publicando um
+// evento por criação, atualização e remoção
+func NewPublishingPersonalityService(next PersonalityService, broker *events.Broker) PersonalityService {
+	return &publishingPersonalityService{next: next, broker: broker}
+}
+
+func (s *publishingPersonalityService) Create(ctx context.Context, req *dto.CreatePersonalityRequest) (*dto.PersonalityResponse, error) {
+	result, err := s.next.Create(ctx, req)
+	if err == nil {
+		s.broker.Publish(events.Event{Type: events.Created, PersonalityID: result.ID, Personality: result})
+	}
+	return result, err
+}
+
+func (s *publishingPersonalityService) GetAll(ctx context.Context) ([]dto.PersonalityResponse, error) {
+	return s.next.GetAll(ctx)
+}
+
+func (s *publishingPersonalityService) List(ctx context.Context, filter dto.PersonalityFilter, page dto.PageRequest) (*dto.PersonalityPage, error) {
+	return s.next.List(ctx, filter, page)
+}
+
+func (s *publishingPersonalityService) GetByID(ctx context.Context, id uint) (*dto.PersonalityResponse, error) {
+	return s.next.GetByID(ctx, id)
+}
+
+func (s *publishingPersonalityService) GetByIDs(ctx context.Context, ids []uint) ([]dto.PersonalityResponse, error) {
+	return s.next.GetByIDs(ctx, ids)
+}
+
+func (s *publishingPersonalityService) Update(ctx context.Context, id uint, req *dto.UpdatePersonalityRequest) (*dto.PersonalityResponse, error) {
+	result, err := s.next.Update(ctx, id, req)
+	if err == nil {
+		s.broker.Publish(events.Event{Type: events.Updated, PersonalityID: result.ID, Personality: result})
+	}
+	return result, err
+}
+
+func (s *publishingPersonalityService) Delete(ctx context.Context, id uint) error {
+	err := s.next.Delete(ctx, id)
+	if err == nil {
+		s.broker.Publish(events.Event{Type: events.Deleted, PersonalityID: id})
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/events"
+	"testing"
+)
+
+func TestPublishingService_PublishesSuccessfulChanges(t *testing.T) {
+	broker := events.NewBroker(10, 0)
+	sub := broker.Subscribe()
+	defer sub.Close()
+	service := NewPublishingPersonalityService(NewPersonalityService(newMockRepository()), broker)
+	ctx := context.Background()
+
+	created, err := service.Create(ctx, &dto.CreatePersonalityRequest{Name: "Ada Lovelace", History: "Matemática inglesa"})
+	if err != nil {
+		t.Fatalf("Esperava sucesso, mas obteve erro: %v", err)
+	}
+	// Falhas não geram eventos
+	if _, err := service.Update(ctx, 99, &dto.UpdatePersonalityRequest{History: "Inexistente"}); err == nil {
+		t.Fatal("Esperava erro ao atualizar personalidade inexistente")
+	}
+	if _, err := service.Update(ctx, created.ID, &dto.UpdatePersonalityRequest{History: "Primeira programadora"}); err != nil {
+		t.Fatalf("Esperava sucesso, mas obteve erro: %v", err)
+	}
+	if err := service.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Esperava sucesso, mas obteve erro: %v", err)
+	}
+
+	for _, want := range []events.Type{events.Created, events.Updated, events.Deleted} {
+		e := <-sub.Events()
+		if e.Type != want || e.PersonalityID != created.ID {
+			t.Errorf("Esperava evento %s da personalidade %d, obteve %+v", want, created.ID, e)
+		}
+		if (e.Personality == nil) != (want == events.Deleted) {
+			t.Errorf("Evento %s com personalidade inesperada: %+v", want, e.Personality)
+		}
+	}
+	select {
+	case e := <-sub.Events():
+		t.Errorf("Não esperava mais eventos, obteve %+v", e)
+	default:
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedPersonalityService cria um span filho para cada operação do serviço
+type tracedPersonalityService struct {
+	next   PersonalityService
+	tracer trace.Tracer
+}
+
+// NewTracedPersonalityService envolve um PersonalityService com spans do OpenTelemetry
+func NewTracedPersonalityService(next PersonalityService, provider trace.TracerProvider) PersonalityService {
+	return &tracedPersonalityService{next: next, tracer: tracing.Tracer(provider)}
+}
+
+func (s *tracedPersonalityService) Create(ctx context.Context, req *dto.CreatePersonalityRequest) (result *dto.PersonalityResponse, err error) {
+	ctx, span := s.tracer.Start(ctx, "PersonalityService.Create")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Create(ctx, req)
+}
+
+func (s *tracedPersonalityService) GetAll(ctx context.Context) (result []dto.PersonalityResponse, err error) {
+	ctx, span := s.tracer.Start(ctx, "PersonalityService.GetAll")
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetAll(ctx)
+}
+
+func (s *tracedPersonalityService) List(ctx context.Context, filter dto.PersonalityFilter, page dto.PageRequest) (result *dto.PersonalityPage, err error) {
+	ctx, span := s.tracer.Start(ctx, "PersonalityService.List", trace.WithAttributes(
+		attribute.Int("page.number", page.Page),
+		attribute.Int("page.size", page.PerPage),
+		attribute.Bool("page.filtered", filter != dto.PersonalityFilter{}),
+	))
+	defer func() { tracing.End(span, err) }()
+	return s.next.List(ctx, filter, page)
+}
+
+func (s *tracedPersonalityService) GetByID(ctx context.Context, id uint) (result *dto.PersonalityResponse, err error) {
+	ctx, span := s.tracer.Start(ctx, "PersonalityService.GetByID", trace.WithAttributes(attribute.Int("personality.id", int(id))))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetByID(ctx, id)
+}
+
+func (s *tracedPersonalityService) GetByIDs(ctx context.Context, ids []uint) (result []dto.PersonalityResponse, err error) {
+	ctx, span := s.tracer.Start(ctx, "PersonalityService.GetByIDs", trace.WithAttributes(attribute.Int("personality.requested", len(ids))))
+	defer func() {
+		span.SetAttributes(attribute.Int("personality.count", len(result)))
+		tracing.End(span, err)
+	}()
+	return s.next.GetByIDs(ctx, ids)
+}
+
+func (s *tracedPersonalityService) Update(ctx context.Context, id uint, req *dto.UpdatePersonalityRequest) (result *dto.PersonalityResponse, err error) {
+	ctx, span := s.tracer.Start(ctx, "PersonalityService.Update", trace.WithAttributes(attribute.Int("personality.id", int(id))))
+	defer func() { tracing.End(span, err) }()
+	return s.next.Update(ctx, id, req)
+}
+
+func (s *tracedPersonalityService) Delete(ctx context.Context, id uint) (err error) {
+	ctx, span := s.tracer.Start(ctx, "PersonalityService.Delete", trace.WithAttributes(attribute.Int("personality.id", int(id))))
+	defer func() { tracing.End(span, err) }()
+	return s.next.Delete(ctx, id)
+}
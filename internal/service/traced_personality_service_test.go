@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"go-api-rest/internal/dto"
+	"go-api-rest/internal/repository"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracedService_CreatesRepositoryChildSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	repo := repository.NewTracedPersonalityRepository(newMockRepository(), provider)
+	service := NewTracedPersonalityService(NewPersonalityService(repo), provider)
+
+	_, err := service.Create(context.Background(), &dto.CreatePersonalityRequest{
+		Name:    "Grace Hopper",
+		History: "Cientista da computação e militar",
+	})
+	if err != nil {
+		t.Fatalf("Esperava sucesso, mas obteve erro: %v", err)
+	}
+
+	spans := recorder.Ended()
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = span.Name()
+	}
+	expected := []string{"PersonalityRepository.ExistsByName", "PersonalityRepository.Create", "PersonalityService.Create"}
+	if len(names) != len(expected) {
+		t.Fatalf("Esperava spans %v, mas obteve %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Esperava span %s na posição %d, mas obteve %s", expected[i], i, names[i])
+		}
+	}
+
+	parent := spans[2].SpanContext().SpanID()
+	for _, span := range spans[:2] {
+		if span.Parent().SpanID() != parent {
+			t.Errorf("Span %s deveria ser filho de PersonalityService.Create", span.Name())
+		}
+	}
+}
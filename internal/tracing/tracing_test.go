@@ -0,0 +1,41 @@
+package tracing
+
+import (
+	"context"
+	"go-api-rest/internal/config"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+func TestSetup_StdoutExporter(t *testing.T) {
+	provider, shutdown, err := Setup(context.Background(), config.TracingConfig{
+		Exporter:    "stdout",
+		ServiceName: "go-api-rest-test",
+		SampleRatio: 1,
+	})
+	if err != nil {
+		t.Fatalf("Esperava sucesso, mas obteve erro: %v", err)
+	}
+	if provider == nil {
+		t.Fatal("Esperava um TracerProvider")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("Erro ao encerrar o provider: %v", err)
+	}
+}
+
+// TestSemconvMatchesSDK falha quando uma atualização do SDK muda o schema do
+// resource padrão sem a troca correspondente do pacote semconv
+func TestSemconvMatchesSDK(t *testing.T) {
+	if got := resource.Default().SchemaURL(); got != semconv.SchemaURL {
+		t.Errorf("Schema do SDK %s difere do semconv importado %s", got, semconv.SchemaURL)
+	}
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	if _, _, err := Setup(context.Background(), config.TracingConfig{Exporter: "jaeger"}); err == nil {
+		t.Error("Esperava erro para exportador desconhecido")
+	}
+}
@@ -1,7 +1,8 @@
 CREATE TABLE IF NOT EXISTS personalities (
     id SERIAL PRIMARY KEY,
     name VARCHAR(100) UNIQUE NOT NULL,
-    history TEXT
+    history TEXT,
+    version INTEGER NOT NULL DEFAULT 1
 );
 
 INSERT INTO
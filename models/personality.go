@@ -7,6 +7,7 @@ type Personality struct {
 	ID        uint      `json:"id" gorm:"primaryKey"`
 	Name      string    `json:"name" gorm:"unique;not null;size:100"`
 	History   string    `json:"history" gorm:"type:text;not null"`
+	Version   uint      `json:"version" gorm:"not null;default:1"` // incrementada a cada atualização
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,69 @@
+package codec
+
+import (
+	"strconv"
+	"strings"
+)
+
+// mediaRange é um item do cabeçalho Accept, ex: "application/*;q=0.5"
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// parseAccept interpreta o cabeçalho Accept; itens malformados são ignorados
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		typ, subtype, ok := strings.Cut(strings.ToLower(strings.TrimSpace(mediaType)), "/")
+		if !ok || typ == "" || subtype == "" || (typ == "*" && subtype != "*") {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.ToLower(strings.TrimSpace(key)) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				parsed = 0
+			}
+			q = parsed
+		}
+		ranges = append(ranges, mediaRange{typ: typ, subtype: subtype, q: q})
+	}
+	return ranges
+}
+
+// quality retorna o q-value do tipo mais bem avaliado entre mediaTypes,
+// usando a faixa mais específica que casa com cada um (RFC 9110, seção 12.5.1)
+func quality(ranges []mediaRange, mediaTypes []string) float64 {
+	best := 0.0
+	for _, mt := range mediaTypes {
+		typ, subtype, _ := strings.Cut(mt, "/")
+		specificity, q := -1, 0.0
+		for _, r := range ranges {
+			var s int
+			switch {
+			case r.typ == typ && r.subtype == subtype:
+				s = 2
+			case r.typ == typ && r.subtype == "*":
+				s = 1
+			case r.typ == "*":
+				s = 0
+			default:
+				continue
+			}
+			if s > specificity {
+				specificity, q = s, r.q
+			}
+		}
+		if q > best {
+			best = q
+		}
+	}
+	return best
+}
@@ -0,0 +1,177 @@
+// Package codec reúne os formatos de representação da API e a negociação de
+// conteúdo por Accept (respostas) e Content-Type (corpos de requisição).
+package codec
+
+import (
+	"go-api-rest/pkg/i18n"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNotAcceptable        = i18n.NewError("codec.not_acceptable")
+	ErrUnsupportedMediaType = i18n.NewError("codec.unsupported_media_type")
+)
+
+// Encoder serializa respostas em um formato
+type Encoder interface {
+	// MediaTypes lista os tipos aceitos no Accept; o primeiro é o canônico
+	MediaTypes() []string
+	// ContentType é o valor enviado no cabeçalho Content-Type
+	ContentType() string
+	// CanEncode informa se o formato representa o valor (ex: CSV só para coleções)
+	CanEncode(v interface{}) bool
+	Encode(w io.Writer, v interface{}) error
+}
+
+// Decoder desserializa corpos de requisição em um formato
+type Decoder interface {
+	MediaTypes() []string
+	Decode(r io.Reader, v interface{}) error
+}
+
+// Registry guarda os formatos disponíveis; a ordem de registro desempata a negociação
+type Registry struct {
+	encoders []Encoder
+	decoders []Decoder
+}
+
+// NewRegistry cria um registro vazio
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Default cria um registro com JSON (padrão), XML, YAML, CSV (apenas respostas) e MessagePack
+func Default() *Registry {
+	reg := NewRegistry()
+	for _, c := range []interface{}{JSON{}, XML{}, YAML{}, CSV{}, MessagePack{}} {
+		if enc, ok := c.(Encoder); ok {
+			reg.RegisterEncoder(enc)
+		}
+		if dec, ok := c.(Decoder); ok {
+			reg.RegisterDecoder(dec)
+		}
+	}
+	return reg
+}
+
+// RegisterEncoder adiciona um formato de resposta
+func (reg *Registry) RegisterEncoder(enc Encoder) {
+	reg.encoders = append(reg.encoders, enc)
+}
+
+// RegisterDecoder adiciona um formato de corpo de requisição
+func (reg *Registry) RegisterDecoder(dec Decoder) {
+	reg.decoders = append(reg.decoders, dec)
+}
+
+// DefaultEncoder é o primeiro formato registrado, usado quando não há Accept
+func (reg *Registry) DefaultEncoder() Encoder {
+	if len(reg.encoders) == 0 {
+		return JSON{}
+	}
+	return reg.encoders[0]
+}
+
+// Negotiate escolhe o formato de maior q-value no Accept capaz de representar v
+func (reg *Registry) Negotiate(accept string, v interface{}) (Encoder, error) {
+	if strings.TrimSpace(accept) == "" {
+		for _, enc := range reg.encoders {
+			if enc.CanEncode(v) {
+				return enc, nil
+			}
+		}
+		return nil, ErrNotAcceptable
+	}
+
+	ranges := parseAccept(accept)
+	var best Encoder
+	bestQ := 0.0
+	for _, enc := range reg.encoders {
+		if !enc.CanEncode(v) {
+			continue
+		}
+		if q := quality(ranges, enc.MediaTypes()); q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	if best == nil {
+		return nil, ErrNotAcceptable
+	}
+	return best, nil
+}
+
+// Acceptable informa se algum formato registrado atende ao Accept, sem
+// considerar o valor; permite recusar a requisição antes de executá-la
+func (reg *Registry) Acceptable(accept string) bool {
+	if strings.TrimSpace(accept) == "" {
+		return true
+	}
+	ranges := parseAccept(accept)
+	for _, enc := range reg.encoders {
+		if quality(ranges, enc.MediaTypes()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// DecoderFor retorna o formato do Content-Type; sem Content-Type o corpo é JSON
+func (reg *Registry) DecoderFor(contentType string) (Decoder, error) {
+	if strings.TrimSpace(contentType) == "" {
+		return JSON{}, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupportedMediaType
+	}
+
+	for _, dec := range reg.decoders {
+		for _, mt := range dec.MediaTypes() {
+			if mt == mediaType {
+				return dec, nil
+			}
+		}
+	}
+	// Sufixos estruturados (RFC 6839), ex: application/merge-patch+json
+	if i := strings.LastIndex(mediaType, "+"); i >= 0 {
+		suffix := mediaType[i+1:]
+		for _, dec := range reg.decoders {
+			for _, mt := range dec.MediaTypes() {
+				if strings.HasSuffix(mt, "/"+suffix) {
+					return dec, nil
+				}
+			}
+		}
+	}
+	return nil, ErrUnsupportedMediaType
+}
+
+// DecodeRequest decodifica o corpo conforme o Content-Type da requisição
+func (reg *Registry) DecodeRequest(r *http.Request, v interface{}) error {
+	dec, err := reg.DecoderFor(r.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+	return dec.Decode(r.Body, v)
+}
+
+// EncoderMediaTypes lista os tipos canônicos das respostas, para mensagens de erro
+func (reg *Registry) EncoderMediaTypes() []string {
+	types := make([]string, len(reg.encoders))
+	for i, enc := range reg.encoders {
+		types[i] = enc.MediaTypes()[0]
+	}
+	return types
+}
+
+// DecoderMediaTypes lista os tipos canônicos aceitos nos corpos de requisição
+func (reg *Registry) DecoderMediaTypes() []string {
+	types := make([]string, len(reg.decoders))
+	for i, dec := range reg.decoders {
+		types[i] = dec.MediaTypes()[0]
+	}
+	return types
+}
@@ -0,0 +1,192 @@
+package codec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sample struct {
+	ID        uint              `json:"id"`
+	Name      string            `json:"name"`
+	Tags      []string          `json:"tags,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+var sampleTime = time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+func TestRegistry_Negotiate(t *testing.T) {
+	reg := Default()
+	single := sample{ID: 1}
+	list := []sample{{ID: 1}}
+
+	tests := []struct {
+		accept string
+		value  interface{}
+		want   string
+	}{
+		{"", single, "application/json"},
+		{"*/*", single, "application/json"},
+		{"application/xml", single, "application/xml"},
+		{"text/xml", single, "application/xml"},
+		{"application/x-yaml", single, "application/yaml"},
+		{"application/json;q=0.5, application/yaml", single, "application/yaml"},
+		{"application/*;q=0.2, application/msgpack", single, "application/msgpack"},
+		{"text/csv", list, "text/csv"},
+		{"text/csv, application/json;q=0.1", single, "application/json"},
+		{"text/*", list, "application/xml"}, // text/xml vem antes de text/csv no registro
+		{"application/json;q=0, */*", single, "application/xml"},
+		{"text/csv", single, ""},
+		{"image/png", single, ""},
+	}
+
+	for _, tt := range tests {
+		enc, err := reg.Negotiate(tt.accept, tt.value)
+		got := ""
+		if err == nil {
+			got = enc.MediaTypes()[0]
+		}
+		if got != tt.want {
+			t.Errorf("Accept %q (%T): esperava %q, obteve %q (erro: %v)", tt.accept, tt.value, tt.want, got, err)
+		}
+	}
+}
+
+func TestRegistry_DecoderFor(t *testing.T) {
+	reg := Default()
+	tests := []struct {
+		contentType string
+		want        string
+	}{
+		{"", "application/json"},
+		{"application/json; charset=utf-8", "application/json"},
+		{"application/merge-patch+json", "application/json"},
+		{"text/xml", "application/xml"},
+		{"application/yaml", "application/yaml"},
+		{"application/x-msgpack", "application/msgpack"},
+		{"text/csv", ""},
+		{"text/plain", ""},
+		{"inválido;;", ""},
+	}
+
+	for _, tt := range tests {
+		dec, err := reg.DecoderFor(tt.contentType)
+		got := ""
+		if err == nil {
+			got = dec.MediaTypes()[0]
+		} else if err != ErrUnsupportedMediaType {
+			t.Errorf("Content-Type %q: erro inesperado %v", tt.contentType, err)
+		}
+		if got != tt.want {
+			t.Errorf("Content-Type %q: esperava %q, obteve %q", tt.contentType, tt.want, got)
+		}
+	}
+}
+
+func TestXML_Encode(t *testing.T) {
+	var buf bytes.Buffer
+	v := sample{ID: 1, Name: "Ada & Babbage", Tags: []string{"a"}, Details: map[string]string{"1campo": "x"}, UpdatedAt: sampleTime}
+	if err := (XML{}).Encode(&buf, v); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+
+	expected := `<response><id>1</id><name>Ada &amp; Babbage</name><tags><item>a</item></tags>` +
+		`<details><entry key="1campo">x</entry></details><updated_at>2024-05-10T12:00:00Z</updated_at></response>`
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Errorf("XML inesperado:\n%s", buf.String())
+	}
+}
+
+func TestYAML_Encode(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (YAML{}).Encode(&buf, []sample{{ID: 1, Name: "Ada", UpdatedAt: sampleTime}}); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+
+	expected := "- id: 1\n  name: Ada\n  updated_at: \"2024-05-10T12:00:00Z\"\n"
+	if buf.String() != expected {
+		t.Errorf("YAML inesperado:\n%s", buf.String())
+	}
+}
+
+func TestCSV_Encode(t *testing.T) {
+	var buf bytes.Buffer
+	list := []sample{
+		{ID: 1, Name: "Ada, a condessa", UpdatedAt: sampleTime},
+		{ID: 2, Name: "Alan", Tags: []string{"x", "y"}, UpdatedAt: sampleTime},
+	}
+	if err := (CSV{}).Encode(&buf, list); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+
+	expected := "id,name,updated_at,tags\n" +
+		"1,\"Ada, a condessa\",2024-05-10T12:00:00Z,\n" +
+		"2,Alan,2024-05-10T12:00:00Z,\"[\"\"x\"\",\"\"y\"\"]\"\n"
+	if buf.String() != expected {
+		t.Errorf("CSV inesperado:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := (CSV{}).Encode(&buf, []sample{}); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if buf.String() != "id,name,tags,details,updated_at\n" {
+		t.Errorf("Esperava cabeçalho a partir do tipo, obteve %q", buf.String())
+	}
+}
+
+func TestCSV_NeutralizesFormulas(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{`=HYPERLINK("http://evil.example.com","Ada")`, `"'=HYPERLINK(""http://evil.example.com"",""Ada"")"`},
+		{"+1+1", "'+1+1"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"\r=1", "\"'\r=1\""},
+		{"Ada = condessa", "Ada = condessa"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		items := []map[string]interface{}{{"id": -1, "name": tt.name}}
+		if err := (CSV{}).Encode(&buf, items); err != nil {
+			t.Fatalf("Erro inesperado: %v", err)
+		}
+		// Números negativos continuam números
+		expected := "id,name\n-1," + tt.want + "\n"
+		if buf.String() != expected {
+			t.Errorf("%q: esperava %q, obteve %q", tt.name, expected, buf.String())
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	type input struct {
+		Name    string `json:"name" xml:"name" yaml:"name"`
+		History string `json:"history" xml:"history" yaml:"history"`
+	}
+	want := input{Name: "Ada", History: "Matemática"}
+
+	for _, c := range []interface {
+		Encoder
+		Decoder
+	}{JSON{}, XML{}, YAML{}, MessagePack{}} {
+		t.Run(c.MediaTypes()[0], func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := c.Encode(&buf, want); err != nil {
+				t.Fatalf("Erro ao codificar: %v", err)
+			}
+			var got input
+			if err := c.Decode(&buf, &got); err != nil {
+				t.Fatalf("Erro ao decodificar: %v", err)
+			}
+			if got != want {
+				t.Errorf("Esperava %+v, obteve %+v", want, got)
+			}
+		})
+	}
+}
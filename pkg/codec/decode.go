@@ -0,0 +1,248 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// DecodeErrorKind classifica o problema encontrado no corpo da requisição
+type DecodeErrorKind int
+
+const (
+	DecodeSyntax       DecodeErrorKind = iota // conteúdo malformado
+	DecodeType                                // valor com tipo errado para o campo
+	DecodeUnknownField                        // campo que o DTO não declara
+	DecodeTrailingData                        // mais de um valor no corpo
+	DecodeEmpty                               // corpo vazio
+)
+
+// DecodeError descreve um corpo rejeitado pelos decoders. Offset (em bytes)
+// e Line são preenchidos quando o formato informa a posição; zero significa
+// posição desconhecida.
+type DecodeError struct {
+	Kind     DecodeErrorKind
+	Field    string // caminho do campo, ex: "address.city"
+	Expected string // tipo esperado em DecodeType, ex: "string"
+	Offset   int64
+	Line     int
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	switch e.Kind {
+	case DecodeType:
+		return fmt.Sprintf("campo %q deve ser %s", e.Field, e.Expected)
+	case DecodeUnknownField:
+		return fmt.Sprintf("campo desconhecido %q", e.Field)
+	case DecodeTrailingData:
+		return "o corpo deve conter um único valor"
+	case DecodeEmpty:
+		return "corpo vazio"
+	}
+	switch {
+	case e.Offset > 0:
+		return fmt.Sprintf("sintaxe inválida no byte %d: %v", e.Offset, e.Err)
+	case e.Line > 0:
+		return fmt.Sprintf("sintaxe inválida na linha %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("sintaxe inválida: %v", e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// isReadError identifica o corpo interrompido por http.MaxBytesReader, que
+// segue sem conversão para ser respondido com 413
+func isReadError(err error) bool {
+	var maxBytes *http.MaxBytesError
+	return errors.As(err, &maxBytes)
+}
+
+// decodeJSON exige um único valor JSON e rejeita campos desconhecidos
+func decodeJSON(r io.Reader, v interface{}) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		return jsonDecodeError(err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err != nil && isReadError(err) {
+			return err
+		}
+		return &DecodeError{Kind: DecodeTrailingData, Offset: dec.InputOffset()}
+	}
+	return nil
+}
+
+func jsonDecodeError(err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return &DecodeError{Kind: DecodeEmpty, Err: err}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &DecodeError{Kind: DecodeSyntax, Err: err}
+	case errors.As(err, &syntaxErr):
+		return &DecodeError{Kind: DecodeSyntax, Offset: syntaxErr.Offset, Err: err}
+	case errors.As(err, &typeErr):
+		return &DecodeError{Kind: DecodeType, Field: typeErr.Field, Expected: jsonTypeName(typeErr.Type), Offset: typeErr.Offset, Err: err}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+		return &DecodeError{Kind: DecodeUnknownField, Field: field, Err: err}
+	}
+	return err
+}
+
+// jsonTypeName descreve um tipo Go com os nomes de tipo do JSON
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Pointer:
+		return jsonTypeName(t.Elem())
+	}
+	return "object"
+}
+
+// decodeXML exige um único elemento raiz. encoding/xml não informa campos
+// desconhecidos, que são ignorados neste formato.
+func decodeXML(r io.Reader, v interface{}) error {
+	dec := xml.NewDecoder(r)
+	if err := dec.Decode(v); err != nil {
+		return xmlDecodeError(err, dec.InputOffset())
+	}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return xmlDecodeError(err, dec.InputOffset())
+		}
+		switch t := tok.(type) {
+		case xml.Comment, xml.ProcInst:
+		case xml.CharData:
+			if len(bytes.TrimSpace(t)) > 0 {
+				return &DecodeError{Kind: DecodeTrailingData, Offset: dec.InputOffset()}
+			}
+		default:
+			return &DecodeError{Kind: DecodeTrailingData, Offset: dec.InputOffset()}
+		}
+	}
+}
+
+func xmlDecodeError(err error, offset int64) error {
+	var syntaxErr *xml.SyntaxError
+	switch {
+	case errors.Is(err, io.EOF):
+		return &DecodeError{Kind: DecodeEmpty, Err: err}
+	case errors.As(err, &syntaxErr):
+		return &DecodeError{Kind: DecodeSyntax, Line: syntaxErr.Line, Err: err}
+	case isReadError(err):
+		return err
+	}
+	return &DecodeError{Kind: DecodeSyntax, Offset: offset, Err: err}
+}
+
+// yaml.v3 só informa linha, campo e tipo no texto das mensagens, ex:
+// "line 3: field foo not found in type dto.X"
+var (
+	yamlLine         = regexp.MustCompile(`line (\d+):`)
+	yamlUnknownField = regexp.MustCompile(`field (\S+) not found in type`)
+	yamlTypeMismatch = regexp.MustCompile("cannot unmarshal !!\\w+ `.*` into (\\S+)")
+)
+
+// decodeYAML exige um único documento e rejeita campos desconhecidos
+func decodeYAML(r io.Reader, v interface{}) error {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	if err := dec.Decode(v); err != nil {
+		return yamlDecodeError(err)
+	}
+	var extra yaml.Node
+	if err := dec.Decode(&extra); err != io.EOF {
+		if err != nil && isReadError(err) {
+			return err
+		}
+		return &DecodeError{Kind: DecodeTrailingData, Err: err}
+	}
+	return nil
+}
+
+func yamlDecodeError(err error) error {
+	if errors.Is(err, io.EOF) {
+		return &DecodeError{Kind: DecodeEmpty, Err: err}
+	}
+	if isReadError(err) {
+		return err
+	}
+
+	message := err.Error()
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		message = typeErr.Errors[0]
+	}
+	decodeErr := &DecodeError{Kind: DecodeSyntax, Err: err}
+	if m := yamlLine.FindStringSubmatch(message); m != nil {
+		decodeErr.Line, _ = strconv.Atoi(m[1])
+	}
+	if m := yamlUnknownField.FindStringSubmatch(message); m != nil {
+		decodeErr.Kind, decodeErr.Field = DecodeUnknownField, m[1]
+	} else if m := yamlTypeMismatch.FindStringSubmatch(message); m != nil {
+		decodeErr.Kind, decodeErr.Expected = DecodeType, m[1]
+	}
+	return decodeErr
+}
+
+var msgpackUnknownField = regexp.MustCompile(`unknown field "([^"]*)"`)
+
+// decodeMessagePack exige um único valor e rejeita campos desconhecidos
+func decodeMessagePack(r io.Reader, v interface{}) error {
+	dec := msgpack.NewDecoder(r)
+	dec.SetCustomStructTag("json")
+	dec.DisallowUnknownFields(true)
+
+	if err := dec.Decode(v); err != nil {
+		switch {
+		case errors.Is(err, io.EOF):
+			return &DecodeError{Kind: DecodeEmpty, Err: err}
+		case isReadError(err):
+			return err
+		}
+		if m := msgpackUnknownField.FindStringSubmatch(err.Error()); m != nil {
+			return &DecodeError{Kind: DecodeUnknownField, Field: m[1], Err: err}
+		}
+		return &DecodeError{Kind: DecodeSyntax, Err: err}
+	}
+	if _, err := dec.PeekCode(); err != io.EOF {
+		if err != nil && isReadError(err) {
+			return err
+		}
+		return &DecodeError{Kind: DecodeTrailingData, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,326 @@
+package codec
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// JSON é o formato padrão da API
+type JSON struct{}
+
+func (JSON) MediaTypes() []string       { return []string{"application/json"} }
+func (JSON) ContentType() string        { return "application/json; charset=utf-8" }
+func (JSON) CanEncode(interface{}) bool { return true }
+func (JSON) Encode(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+func (JSON) Decode(r io.Reader, v interface{}) error {
+	return decodeJSON(r, v)
+}
+
+// XML representa objetos como elementos com os nomes das tags json; listas
+// usam elementos <item> e a raiz é sempre <response>
+type XML struct{}
+
+func (XML) MediaTypes() []string       { return []string{"application/xml", "text/xml"} }
+func (XML) ContentType() string        { return "application/xml; charset=utf-8" }
+func (XML) CanEncode(interface{}) bool { return true }
+
+func (XML) Encode(w io.Writer, v interface{}) error {
+	generic, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	if err := writeXMLElement(enc, "response", generic); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+// Decode usa encoding/xml; os DTOs de entrada declaram tags xml
+func (XML) Decode(r io.Reader, v interface{}) error {
+	return decodeXML(r, v)
+}
+
+func writeXMLElement(enc *xml.Encoder, name string, v interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if !isXMLName(name) {
+		// Chaves arbitrárias (ex: nomes de campos em detalhes) viram atributo
+		start = xml.StartElement{
+			Name: xml.Name{Local: "entry"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: name}},
+		}
+	}
+
+	switch value := v.(type) {
+	case object:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, m := range value {
+			if err := writeXMLElement(enc, m.key, m.value); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	case []interface{}:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, item := range value {
+			if err := writeXMLElement(enc, "item", item); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	default:
+		return enc.EncodeElement(scalarString(value), start)
+	}
+}
+
+// isXMLName aceita o subconjunto seguro de nomes XML usado pelas tags json
+func isXMLName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	first, _ := utf8.DecodeRuneInString(name)
+	if !unicode.IsLetter(first) && first != '_' {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// YAML usa os mesmos nomes e a mesma ordem de campos do JSON
+type YAML struct{}
+
+func (YAML) MediaTypes() []string {
+	return []string{"application/yaml", "application/x-yaml", "text/yaml"}
+}
+func (YAML) ContentType() string        { return "application/yaml; charset=utf-8" }
+func (YAML) CanEncode(interface{}) bool { return true }
+
+func (YAML) Encode(w io.Writer, v interface{}) error {
+	generic, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	node, err := yamlNode(generic)
+	if err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Decode usa yaml.v3; os DTOs de entrada declaram tags yaml
+func (YAML) Decode(r io.Reader, v interface{}) error {
+	return decodeYAML(r, v)
+}
+
+func yamlNode(v interface{}) (*yaml.Node, error) {
+	switch value := v.(type) {
+	case object:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, m := range value {
+			child, err := yamlNode(m.value)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: m.key}, child)
+		}
+		return node, nil
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range value {
+			child, err := yamlNode(item)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		return node, nil
+	default:
+		node := &yaml.Node{}
+		return node, node.Encode(value)
+	}
+}
+
+// CSV representa coleções de objetos, uma linha por item, com cabeçalho
+type CSV struct{}
+
+func (CSV) MediaTypes() []string { return []string{"text/csv"} }
+func (CSV) ContentType() string  { return "text/csv; charset=utf-8; header=present" }
+
+// CanEncode aceita apenas slices de structs ou mapas
+func (CSV) CanEncode(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return false
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return false
+	}
+	elem := t.Elem()
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	return elem.Kind() == reflect.Struct || elem.Kind() == reflect.Map
+}
+
+func (CSV) Encode(w io.Writer, v interface{}) error {
+	generic, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	items, _ := generic.([]interface{})
+
+	header := csvHeader(items, reflect.TypeOf(v))
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, item := range items {
+		obj, _ := item.(object)
+		values := make(map[string]interface{}, len(obj))
+		for _, m := range obj {
+			values[m.key] = m.value
+		}
+		row := make([]string, len(header))
+		for i, column := range header {
+			row[i] = csvValue(values[column])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvHeader usa as chaves dos itens na ordem em que aparecem; sem itens,
+// recorre às tags json do tipo do elemento
+func csvHeader(items []interface{}, t reflect.Type) []string {
+	var header []string
+	seen := make(map[string]bool)
+	for _, item := range items {
+		obj, _ := item.(object)
+		for _, m := range obj {
+			if !seen[m.key] {
+				seen[m.key] = true
+				header = append(header, m.key)
+			}
+		}
+	}
+	if len(header) > 0 || t == nil {
+		return header
+	}
+
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return header
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		header = append(header, name)
+	}
+	return header
+}
+
+// csvValue serializa valores aninhados como JSON na célula
+func csvValue(v interface{}) string {
+	switch value := v.(type) {
+	case object, []interface{}:
+		data, _ := json.Marshal(toJSONValue(v))
+		return string(data)
+	case string:
+		return neutralizeFormula(value)
+	default:
+		return scalarString(v)
+	}
+}
+
+// neutralizeFormula prefixa com aspas simples os textos que uma planilha
+// interpretaria como fórmula (CSV injection); números não passam por aqui
+func neutralizeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// toJSONValue converte object de volta para map, para serialização em JSON
+func toJSONValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case object:
+		m := make(map[string]interface{}, len(value))
+		for _, member := range value {
+			m[member.key] = toJSONValue(member.value)
+		}
+		return m
+	case []interface{}:
+		arr := make([]interface{}, len(value))
+		for i, item := range value {
+			arr[i] = toJSONValue(item)
+		}
+		return arr
+	default:
+		return value
+	}
+}
+
+// MessagePack é um formato binário compacto; os campos seguem as tags json
+type MessagePack struct{}
+
+func (MessagePack) MediaTypes() []string {
+	return []string{"application/msgpack", "application/x-msgpack", "application/vnd.msgpack"}
+}
+func (MessagePack) ContentType() string        { return "application/msgpack" }
+func (MessagePack) CanEncode(interface{}) bool { return true }
+
+func (MessagePack) Encode(w io.Writer, v interface{}) error {
+	enc := msgpack.NewEncoder(w)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	return enc.Encode(v)
+}
+
+func (MessagePack) Decode(r io.Reader, v interface{}) error {
+	return decodeMessagePack(r, v)
+}
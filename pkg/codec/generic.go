@@ -0,0 +1,87 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// member é um par chave/valor de objeto, na ordem em que foi serializado
+type member struct {
+	key   string
+	value interface{}
+}
+
+// object preserva a ordem dos campos, ao contrário de map
+type object []member
+
+// toGeneric converte v para object, []interface{} e escalares usando as tags
+// json, para que XML, YAML e CSV usem os mesmos nomes de campo da API
+func toGeneric(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return readGeneric(dec)
+}
+
+func readGeneric(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			obj := object{}
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := readGeneric(dec)
+				if err != nil {
+					return nil, err
+				}
+				obj = append(obj, member{key: key.(string), value: value})
+			}
+			_, err := dec.Token()
+			return obj, err
+		case '[':
+			arr := []interface{}{}
+			for dec.More() {
+				value, err := readGeneric(dec)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, value)
+			}
+			_, err := dec.Token()
+			return arr, err
+		}
+		return nil, fmt.Errorf("delimitador inesperado: %v", t)
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		return t.Float64()
+	default:
+		return t, nil
+	}
+}
+
+// scalarString formata escalares para XML e CSV; nil vira texto vazio
+func scalarString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	default:
+		return fmt.Sprint(s)
+	}
+}
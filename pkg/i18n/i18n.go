@@ -0,0 +1,129 @@
+// Package i18n concentra as mensagens da API por idioma e a negociação do
+// idioma da resposta pelo cabeçalho Accept-Language.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"go-api-rest/pkg/reqctx"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Idiomas suportados, no formato BCP 47 usado em Content-Language
+const (
+	PortugueseBR = "pt-BR"
+	English      = "en"
+	Spanish      = "es"
+
+	// DefaultLocale é usado quando o cliente não pede nenhum idioma suportado
+	DefaultLocale = PortugueseBR
+)
+
+// supported segue a ordem do matcher; o primeiro é o padrão
+var supported = []string{PortugueseBR, English, Spanish}
+
+//go:embed locales/*.json
+var files embed.FS
+
+var (
+	catalog = make(map[string]map[string]string)
+	matcher language.Matcher
+)
+
+func init() {
+	tags := make([]language.Tag, len(supported))
+	for i, locale := range supported {
+		data, err := files.ReadFile(path.Join("locales", locale+".json"))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: catálogo %s ausente: %v", locale, err))
+		}
+		messages := make(map[string]string)
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: catálogo %s inválido: %v", locale, err))
+		}
+		catalog[locale] = messages
+		tags[i] = language.MustParse(locale)
+	}
+	matcher = language.NewMatcher(tags)
+}
+
+// Locales retorna os idiomas suportados, começando pelo padrão
+func Locales() []string {
+	return append([]string(nil), supported...)
+}
+
+// Negotiate escolhe o idioma suportado mais próximo do Accept-Language,
+// respeitando os q-values. Variantes regionais casam com o idioma base
+// (en-US → en, pt-PT → pt-BR).
+func Negotiate(acceptLanguage string) string {
+	if strings.TrimSpace(acceptLanguage) == "" {
+		return DefaultLocale
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+	return supported[index]
+}
+
+// Lookup retorna a mensagem de key no idioma, sem fallback
+func Lookup(locale, key string) (string, bool) {
+	message, ok := catalog[locale][key]
+	return message, ok
+}
+
+// Translate formata a mensagem de key no idioma. Chaves ausentes caem para o
+// idioma padrão e, por último, para a própria chave.
+func Translate(locale, key string, args ...interface{}) string {
+	message, ok := Lookup(locale, key)
+	if !ok {
+		if message, ok = Lookup(DefaultLocale, key); !ok {
+			message = key
+		}
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// T traduz key para o idioma negociado na requisição
+func T(ctx context.Context, key string, args ...interface{}) string {
+	return Translate(reqctx.Locale(ctx), key, args...)
+}
+
+// Message é uma mensagem do catálogo com seus argumentos, traduzida só no
+// momento de montar a resposta
+type Message struct {
+	Key  string
+	Args []interface{}
+}
+
+// In traduz a mensagem para o idioma
+func (m Message) In(locale string) string {
+	return Translate(locale, m.Key, m.Args...)
+}
+
+// Error é um erro cuja mensagem vem do catálogo; Error() usa o idioma padrão,
+// para logs, e os handlers traduzem pela chave
+type Error struct {
+	Message
+}
+
+// NewError cria um erro traduzível
+func NewError(key string, args ...interface{}) *Error {
+	return &Error{Message{Key: key, Args: args}}
+}
+
+func (e *Error) Error() string {
+	return e.In(DefaultLocale)
+}
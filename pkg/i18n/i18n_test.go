@@ -0,0 +1,76 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", PortugueseBR},
+		{"en", English},
+		{"en-GB", English},
+		{"es-AR,es;q=0.9", Spanish},
+		{"pt-PT", PortugueseBR},
+		{"fr, en;q=0.3", English},
+		{"en;q=0.2, es;q=0.9", Spanish},
+		{"de, ja", PortugueseBR},
+		{"*", PortugueseBR},
+		{";;;", PortugueseBR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := Negotiate(tt.header); got != tt.want {
+				t.Errorf("Negotiate(%q) = %s, esperava %s", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	if got := Translate(English, "request.unsupported_encoding", "br"); got != "Unsupported Content-Encoding: br" {
+		t.Errorf("Tradução inesperada: %q", got)
+	}
+	if got := Translate("fr", "home.welcome"); got != catalog[DefaultLocale]["home.welcome"] {
+		t.Errorf("Esperava fallback para o idioma padrão, obteve %q", got)
+	}
+	if got := Translate(English, "chave.inexistente"); got != "chave.inexistente" {
+		t.Errorf("Esperava a própria chave, obteve %q", got)
+	}
+}
+
+func TestError(t *testing.T) {
+	err := NewError("personality.not_found")
+	if err.Error() != "personalidade não encontrada" {
+		t.Errorf("Error() deve usar o idioma padrão, obteve %q", err.Error())
+	}
+	if got := err.In(English); got != "personality not found" {
+		t.Errorf("Tradução inesperada: %q", got)
+	}
+}
+
+// TestCatalogsAreComplete garante que todos os idiomas têm as mesmas chaves
+// e os mesmos verbos de formatação
+func TestCatalogsAreComplete(t *testing.T) {
+	reference := catalog[DefaultLocale]
+	for _, locale := range Locales() {
+		messages := catalog[locale]
+		if len(messages) != len(reference) {
+			t.Errorf("%s tem %d mensagens, %s tem %d", locale, len(messages), DefaultLocale, len(reference))
+		}
+		for key, message := range reference {
+			translated, ok := messages[key]
+			if !ok {
+				t.Errorf("%s: chave %s ausente", locale, key)
+				continue
+			}
+			if strings.Count(translated, "%") != strings.Count(message, "%") {
+				t.Errorf("%s: %s tem argumentos diferentes do idioma padrão", locale, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"go-api-rest/pkg/i18n"
+	"go-api-rest/pkg/problem"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// levelPayload representa o corpo aceito e retornado pelo LevelHandler
+type levelPayload struct {
+	Level string `json:"level"`
+}
+
+// LevelHandler expõe o nível de log atual (GET/HEAD) e permite alterá-lo (PUT);
+// erros respondem como problem+json
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPut:
+			var payload levelPayload
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				problem.Write(w, r, problem.MalformedBody.Localized("request.malformed_body"))
+				return
+			}
+			if err := SetLevel(payload.Level); err != nil || payload.Level == "" {
+				problem.Write(w, r, problem.Validation(map[string]string{
+					"level": i18n.T(r.Context(), "validation.invalid", "level"),
+				}))
+				return
+			}
+			InfoContext(r.Context(), "Nível de log alterado", "level", Level().String())
+		default:
+			w.Header().Set("Allow", "GET, HEAD, PUT")
+			problem.Write(w, r, problem.MethodNotAllowed.New(""))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(levelPayload{Level: Level().String()})
+	})
+}
+
+// WatchSIGHUP reaplica o nível retornado por levelFn sempre que o processo
+// receber SIGHUP, até que o contexto seja cancelado
+func WatchSIGHUP(ctx context.Context, levelFn func() string) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				if err := SetLevel(levelFn()); err != nil {
+					Error("Erro ao recarregar nível de log", "error", err)
+					continue
+				}
+				Info("Nível de log recarregado via SIGHUP", "level", Level().String())
+			}
+		}
+	}()
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go-api-rest/pkg/problem"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestLevelHandler(t *testing.T) {
+	var buf bytes.Buffer
+	setupTestLogger(t, &buf)
+	h := LevelHandler()
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		status int
+		level  string // nível esperado após a requisição
+	}{
+		{"consulta", http.MethodGet, "", http.StatusOK, "INFO"},
+		{"altera o nível", http.MethodPut, `{"level":"debug"}`, http.StatusOK, "DEBUG"},
+		{"nível inválido", http.MethodPut, `{"level":"verbose"}`, http.StatusBadRequest, "DEBUG"},
+		{"nível vazio", http.MethodPut, `{}`, http.StatusBadRequest, "DEBUG"},
+		{"corpo inválido", http.MethodPut, `{"level":`, http.StatusBadRequest, "DEBUG"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, "/admin/log-level", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.status {
+				t.Fatalf("Esperava status %d, obteve %d: %s", tt.status, rec.Code, rec.Body)
+			}
+			if got := Level().String(); got != tt.level {
+				t.Errorf("Esperava nível %s, obteve %s", tt.level, got)
+			}
+			if tt.status != http.StatusOK {
+				if ct := rec.Header().Get("Content-Type"); ct != problem.ContentType {
+					t.Errorf("Esperava %s, obteve %s", problem.ContentType, ct)
+				}
+				return
+			}
+			var payload levelPayload
+			if err := json.NewDecoder(rec.Body).Decode(&payload); err != nil || payload.Level != tt.level {
+				t.Errorf("Esperava o nível %s no corpo, obteve %+v (%v)", tt.level, payload, err)
+			}
+		})
+	}
+}
+
+func TestLevelHandler_InvalidLevelListsField(t *testing.T) {
+	var buf bytes.Buffer
+	setupTestLogger(t, &buf)
+
+	rec := httptest.NewRecorder()
+	LevelHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(`{"level":"verbose"}`)))
+
+	var body problem.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Erro ao decodificar resposta: %v", err)
+	}
+	if body.Code != problem.ValidationFailed.Code || len(body.Errors) != 1 || body.Errors[0].Field != "level" {
+		t.Errorf("Esperava erro de validação no campo level, obteve %+v", body)
+	}
+}
+
+func TestWatchSIGHUP_ReappliesLevel(t *testing.T) {
+	var buf bytes.Buffer
+	setupTestLogger(t, &buf)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	WatchSIGHUP(ctx, func() string { return "debug" })
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("Erro ao enviar SIGHUP: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for Level() != slog.LevelDebug {
+		if time.Now().After(deadline) {
+			t.Fatalf("Esperava nível DEBUG após o SIGHUP, obteve %s", Level())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
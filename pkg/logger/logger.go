@@ -1,36 +1,203 @@
 package logger
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"go-api-rest/pkg/reqctx"
+	"io"
+	"log/slog"
 	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Options define como o logger padrão é construído
+type Options struct {
+	Level      string // debug, info, warn ou error
+	Format     string // json ou text
+	AddSource  bool
+	Output     io.Writer
+	RedactKeys []string // chaves adicionais cujos valores devem ser mascarados
+}
+
 var (
-	InfoLogger  *log.Logger
-	ErrorLogger *log.Logger
+	level         = new(slog.LevelVar)
+	defaultLogger atomic.Pointer[slog.Logger]
 )
 
 func init() {
-	InfoLogger = log.New(os.Stdout, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile)
-	ErrorLogger = log.New(os.Stderr, "ERROR: ", log.Ldate|log.Ltime|log.Lshortfile)
+	defaultLogger.Store(New(Options{}))
+}
+
+// Setup substitui o logger padrão usado pelas funções do pacote
+func Setup(opts Options) error {
+	if err := SetLevel(opts.Level); err != nil {
+		return err
+	}
+	logger := New(opts)
+	defaultLogger.Store(logger)
+	slog.SetDefault(logger)
+	return nil
+}
+
+// New cria um *slog.Logger com nível dinâmico, contexto de requisição e redação
+func New(opts Options) *slog.Logger {
+	output := opts.Output
+	if output == nil {
+		output = os.Stdout
+	}
+
+	handlerOpts := &slog.HandlerOptions{
+		Level:       level,
+		AddSource:   opts.AddSource,
+		ReplaceAttr: newRedactor(opts.RedactKeys),
+	}
+
+	var handler slog.Handler
+	if strings.EqualFold(opts.Format, "json") {
+		handler = slog.NewJSONHandler(output, handlerOpts)
+	} else {
+		handler = slog.NewTextHandler(output, handlerOpts)
+	}
+	return slog.New(&contextHandler{Handler: handler})
+}
+
+// Default retorna o logger padrão
+func Default() *slog.Logger {
+	return defaultLogger.Load()
 }
 
-// Info registra uma mensagem informativa
-func Info(message string) {
-	InfoLogger.Println(message)
+// SetLevel altera o nível mínimo de log em tempo de execução
+func SetLevel(name string) error {
+	if name == "" {
+		return nil
+	}
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(name)); err != nil {
+		return fmt.Errorf("nível de log inválido: %s", name)
+	}
+	level.Set(l)
+	return nil
 }
 
-// Error registra uma mensagem de erro
-func Error(message string) {
-	ErrorLogger.Println(message)
+// Level retorna o nível de log atual
+func Level() slog.Level {
+	return level.Level()
+}
+
+// Debug registra uma mensagem de depuração com atributos chave/valor
+func Debug(message string, args ...any) {
+	write(context.Background(), slog.LevelDebug, message, args...)
+}
+
+// Info registra uma mensagem informativa com atributos chave/valor
+func Info(message string, args ...any) {
+	write(context.Background(), slog.LevelInfo, message, args...)
+}
+
+// Warn registra um alerta com atributos chave/valor
+func Warn(message string, args ...any) {
+	write(context.Background(), slog.LevelWarn, message, args...)
+}
+
+// Error registra uma mensagem de erro com atributos chave/valor
+func Error(message string, args ...any) {
+	write(context.Background(), slog.LevelError, message, args...)
+}
+
+// DebugContext registra uma mensagem de depuração com os campos da requisição
+func DebugContext(ctx context.Context, message string, args ...any) {
+	write(ctx, slog.LevelDebug, message, args...)
+}
+
+// InfoContext registra uma mensagem informativa com os campos da requisição
+func InfoContext(ctx context.Context, message string, args ...any) {
+	write(ctx, slog.LevelInfo, message, args...)
+}
+
+// WarnContext registra um alerta com os campos da requisição
+func WarnContext(ctx context.Context, message string, args ...any) {
+	write(ctx, slog.LevelWarn, message, args...)
+}
+
+// ErrorContext registra uma mensagem de erro com os campos da requisição
+func ErrorContext(ctx context.Context, message string, args ...any) {
+	write(ctx, slog.LevelError, message, args...)
+}
+
+// Debugf registra uma mensagem de depuração formatada
+func Debugf(format string, v ...interface{}) {
+	writef(slog.LevelDebug, format, v...)
 }
 
 // Infof registra uma mensagem informativa formatada
 func Infof(format string, v ...interface{}) {
-	InfoLogger.Printf(format, v...)
+	writef(slog.LevelInfo, format, v...)
+}
+
+// Warnf registra um alerta formatado
+func Warnf(format string, v ...interface{}) {
+	writef(slog.LevelWarn, format, v...)
 }
 
 // Errorf registra uma mensagem de erro formatada
 func Errorf(format string, v ...interface{}) {
-	ErrorLogger.Printf(format, v...)
+	writef(slog.LevelError, format, v...)
+}
+
+// write emite o registro preservando o arquivo e a linha de quem chamou o pacote
+func write(ctx context.Context, l slog.Level, message string, args ...any) {
+	logger := Default()
+	if !logger.Enabled(ctx, l) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // ignora Callers, write e a função exportada
+	record := slog.NewRecord(time.Now(), l, message, pcs[0])
+	record.Add(args...)
+	_ = logger.Handler().Handle(ctx, record)
+}
+
+func writef(l slog.Level, format string, v ...interface{}) {
+	logger := Default()
+	if !logger.Enabled(context.Background(), l) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+	record := slog.NewRecord(time.Now(), l, fmt.Sprintf(format, v...), pcs[0])
+	_ = logger.Handler().Handle(context.Background(), record)
+}
+
+// contextHandler adiciona o ID da requisição, o usuário e o trace presentes no contexto
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := reqctx.RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if user := reqctx.User(ctx); user != "" {
+		record.AddAttrs(slog.String("user", user))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
 }
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go-api-rest/pkg/reqctx"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func setupTestLogger(t *testing.T, buf *bytes.Buffer) {
+	t.Helper()
+	previous, previousLevel := Default(), Level()
+	t.Cleanup(func() {
+		defaultLogger.Store(previous)
+		level.Set(previousLevel)
+	})
+	if err := Setup(Options{Level: "info", Format: "json", Output: buf}); err != nil {
+		t.Fatalf("Erro ao configurar logger: %v", err)
+	}
+}
+
+func TestInfoContext_AddsRequestFieldsAndRedacts(t *testing.T) {
+	var buf bytes.Buffer
+	setupTestLogger(t, &buf)
+
+	ctx := reqctx.WithUser(reqctx.WithRequestID(context.Background(), "req-1"), "alice")
+	InfoContext(ctx, "login", "password", "123456", "Authorization", "Bearer abc", "name", "Ada")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Log não é JSON válido: %v", err)
+	}
+	if entry["request_id"] != "req-1" || entry["user"] != "alice" {
+		t.Errorf("Campos do contexto ausentes: %v", entry)
+	}
+	if entry["password"] != redactedValue || entry["Authorization"] != redactedValue {
+		t.Errorf("Valores sensíveis não foram mascarados: %v", entry)
+	}
+	if entry["name"] != "Ada" {
+		t.Errorf("Esperava name Ada, mas obteve %v", entry["name"])
+	}
+}
+
+func TestSetLevel_ChangesAtRuntime(t *testing.T) {
+	var buf bytes.Buffer
+	setupTestLogger(t, &buf)
+
+	Debug("oculto")
+	if buf.Len() != 0 {
+		t.Fatalf("Debug não deveria ser registrado no nível info: %s", buf.String())
+	}
+
+	if err := SetLevel("debug"); err != nil {
+		t.Fatalf("Erro ao alterar nível: %v", err)
+	}
+	Debug("visível")
+	if !strings.Contains(buf.String(), "visível") || Level() != slog.LevelDebug {
+		t.Errorf("Debug deveria ser registrado após mudança de nível: %s", buf.String())
+	}
+
+	if err := SetLevel("verbose"); err == nil {
+		t.Error("Esperava erro para nível inválido")
+	}
+}
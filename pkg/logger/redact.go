@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// redactedValue substitui valores sensíveis nos logs
+const redactedValue = "[REDACTED]"
+
+// defaultRedactKeys lista as chaves de segredos e dados pessoais sempre mascaradas
+var defaultRedactKeys = []string{
+	"password", "passwd", "secret", "token", "access_token", "refresh_token",
+	"authorization", "cookie", "set-cookie", "api_key", "apikey", "x-api-key",
+	"dsn", "email", "cpf", "phone",
+}
+
+// newRedactor cria a função ReplaceAttr que mascara as chaves sensíveis
+func newRedactor(extra []string) func(groups []string, a slog.Attr) slog.Attr {
+	keys := make(map[string]struct{}, len(defaultRedactKeys)+len(extra))
+	for _, key := range append(defaultRedactKeys, extra...) {
+		keys[normalizeKey(key)] = struct{}{}
+	}
+
+	return func(_ []string, a slog.Attr) slog.Attr {
+		if _, sensitive := keys[normalizeKey(a.Key)]; sensitive {
+			return slog.String(a.Key, redactedValue)
+		}
+		return a
+	}
+}
+
+// normalizeKey torna a comparação insensível a caixa e a separadores
+func normalizeKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "-", "_")
+}
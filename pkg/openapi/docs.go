@@ -0,0 +1,31 @@
+package openapi
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"net/http"
+)
+
+//go:embed docs.html
+var docsPage string
+
+var docsTemplate = template.Must(template.New("docs").Parse(docsPage))
+
+// DocsHandler serve a página de documentação embutida, que lê o documento em
+// specURL e não depende de recursos externos
+func DocsHandler(specURL string) http.Handler {
+	var page bytes.Buffer
+	if err := docsTemplate.Execute(&page, struct{ SpecURL string }{specURL}); err != nil {
+		panic("openapi: página de documentação: " + err.Error())
+	}
+	body := page.Bytes()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if r.Method != http.MethodHead {
+			w.Write(body)
+		}
+	})
+}
@@ -0,0 +1,308 @@
+// Package openapi gera o documento OpenAPI 3.1 da API a partir das rotas
+// declaradas e dos tipos Go usados nas requisições e respostas.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Version é a versão da especificação OpenAPI gerada
+const Version = "3.1.0"
+
+// Document é a raiz do documento OpenAPI
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+	Tags       []Tag                `json:"tags,omitempty"`
+}
+
+// Info descreve a API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag agrupa operações na documentação
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem reúne as operações de um caminho, por método
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+	Patch  *Operation `json:"patch,omitempty"`
+	Head   *Operation `json:"head,omitempty"`
+}
+
+// Operation retorna a operação do método, ou nil
+func (p *PathItem) Operation(method string) *Operation {
+	if field := p.field(method); field != nil {
+		return *field
+	}
+	return nil
+}
+
+func (p *PathItem) field(method string) **Operation {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		return &p.Get
+	case http.MethodPut:
+		return &p.Put
+	case http.MethodPost:
+		return &p.Post
+	case http.MethodDelete:
+		return &p.Delete
+	case http.MethodPatch:
+		return &p.Patch
+	case http.MethodHead:
+		return &p.Head
+	}
+	return nil
+}
+
+// Operation descreve um método em um caminho
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+// Parameter descreve um parâmetro de caminho, query ou cabeçalho
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody descreve o corpo aceito, por media type
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// Response descreve uma resposta por status
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// Header descreve um cabeçalho de resposta
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType associa um formato ao schema do conteúdo
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components guarda os schemas nomeados e os esquemas de segurança
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme descreve uma forma de autenticação
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Schema é o subconjunto de JSON Schema usado pela API
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+// Generator monta o documento e registra os schemas dos tipos usados
+type Generator struct {
+	doc *Document
+}
+
+// New cria um gerador com as informações da API
+func New(info Info) *Generator {
+	return &Generator{doc: &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			Schemas:         make(map[string]*Schema),
+			SecuritySchemes: make(map[string]*SecurityScheme),
+		},
+	}}
+}
+
+// pathVariable é uma variável de rota do gorilla/mux, ex: {id:[0-9]+}
+type pathVariable struct {
+	name    string
+	pattern string
+}
+
+// parsePath separa as variáveis do template do gorilla/mux, cujas expressões
+// regulares podem conter chaves, como em {slug:[a-z]{1,20}}
+func parsePath(muxPath string) (string, []pathVariable) {
+	var out strings.Builder
+	var vars []pathVariable
+	for i := 0; i < len(muxPath); i++ {
+		if muxPath[i] != '{' {
+			out.WriteByte(muxPath[i])
+			continue
+		}
+		depth, end := 0, -1
+		for j := i; j < len(muxPath) && end < 0; j++ {
+			switch muxPath[j] {
+			case '{':
+				depth++
+			case '}':
+				if depth--; depth == 0 {
+					end = j
+				}
+			}
+		}
+		if end < 0 {
+			out.WriteString(muxPath[i:])
+			break
+		}
+		name, pattern, _ := strings.Cut(muxPath[i+1:end], ":")
+		vars = append(vars, pathVariable{name: name, pattern: pattern})
+		out.WriteString("{" + name + "}")
+		i = end
+	}
+	return out.String(), vars
+}
+
+// PathTemplate converte um template do gorilla/mux para o formato OpenAPI,
+// removendo as expressões regulares das variáveis
+func PathTemplate(muxPath string) string {
+	path, _ := parsePath(muxPath)
+	return path
+}
+
+// Add documenta a operação no caminho, que pode estar no formato do
+// gorilla/mux. Variáveis de caminho sem parâmetro declarado viram parâmetros
+// string obrigatórios com o padrão da rota.
+func (g *Generator) Add(method, muxPath string, op *Operation) {
+	path, vars := parsePath(muxPath)
+	for _, v := range vars {
+		if !hasParameter(op, v.name, "path") {
+			schema := &Schema{Type: "string"}
+			if v.pattern != "" {
+				schema.Pattern = "^" + v.pattern + "$"
+			}
+			op.Parameters = append(op.Parameters, &Parameter{Name: v.name, In: "path", Required: true, Schema: schema})
+		}
+	}
+
+	item, ok := g.doc.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		g.doc.Paths[path] = item
+	}
+	field := item.field(method)
+	if field == nil {
+		panic("openapi: método sem suporte: " + method)
+	}
+	*field = op
+}
+
+func hasParameter(op *Operation, name, in string) bool {
+	for _, p := range op.Parameters {
+		if p.Name == name && p.In == in {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag descreve uma tag usada pelas operações
+func (g *Generator) AddTag(name, description string) {
+	g.doc.Tags = append(g.doc.Tags, Tag{Name: name, Description: description})
+}
+
+// AddSecurityScheme registra um esquema de autenticação em components
+func (g *Generator) AddSecurityScheme(name string, scheme *SecurityScheme) {
+	g.doc.Components.SecuritySchemes[name] = scheme
+}
+
+// Document retorna o documento montado
+func (g *Generator) Document() *Document {
+	return g.doc
+}
+
+// Route identifica uma operação documentada
+type Route struct {
+	Method string
+	Path   string
+}
+
+// Routes lista as operações do documento em ordem de caminho e método
+func (d *Document) Routes() []Route {
+	var routes []Route
+	for path, item := range d.Paths {
+		for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			if item.Operation(method) != nil {
+				routes = append(routes, Route{Method: method, Path: path})
+			}
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// Handler serve o documento em JSON; a serialização é feita uma única vez
+func Handler(doc *Document) http.Handler {
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		panic("openapi: documento inválido: " + err.Error())
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if r.Method != http.MethodHead {
+			w.Write(body)
+		}
+	})
+}
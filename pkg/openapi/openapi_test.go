@@ -0,0 +1,68 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPathTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/api/personalities":                      "/api/personalities",
+		"/api/personalities/{id:[0-9]+}":          "/api/personalities/{id}",
+		"/users/{user}/posts/{slug:[a-z-]{1,20}}": "/users/{user}/posts/{slug}",
+	}
+	for in, want := range tests {
+		if got := PathTemplate(in); got != want {
+			t.Errorf("PathTemplate(%q) = %q, esperava %q", in, got, want)
+		}
+	}
+}
+
+type event struct {
+	Title    string    `json:"title" validate:"required,trimmed,max=80"`
+	Kind     string    `json:"kind" validate:"oneof=talk workshop"`
+	Seats    int       `json:"seats" validate:"min=1,max=500"`
+	Tags     []string  `json:"tags,omitempty" validate:"max=5,dive,min=2"`
+	Date     string    `json:"date" validate:"omitempty,daterange=2000-01-01~"`
+	StartsAt time.Time `json:"starts_at"`
+	Notes    *string   `json:"notes,omitempty"`
+	internal string
+}
+
+func TestGenerator_Schema(t *testing.T) {
+	g := New(Info{Title: "teste", Version: "1"})
+	ref := g.Schema(event{})
+	if ref.Ref != "#/components/schemas/event" {
+		t.Fatalf("Esperava referência ao schema, obteve %+v", ref)
+	}
+	s := g.Document().Components.Schemas["event"]
+
+	// seats tem regras mas não required; starts_at não tem validate nem omitempty
+	if got := s.Required; len(got) != 2 || got[0] != "title" || got[1] != "starts_at" {
+		t.Errorf("Campos obrigatórios inesperados: %v", got)
+	}
+	if s.AdditionalProperties != false {
+		t.Error("Esperava additionalProperties false")
+	}
+	if p := s.Properties["title"]; p.Pattern == "" || *p.MaxLength != 80 {
+		t.Errorf("title: %+v", p)
+	}
+	if p := s.Properties["kind"]; len(p.Enum) != 2 {
+		t.Errorf("kind: %+v", p)
+	}
+	if p := s.Properties["seats"]; *p.Minimum != 1 || *p.Maximum != 500 {
+		t.Errorf("seats: %+v", p)
+	}
+	if p := s.Properties["tags"]; *p.MaxItems != 5 || *p.Items.MinLength != 2 {
+		t.Errorf("tags: %+v", p)
+	}
+	if p := s.Properties["date"]; p.Format != "date" {
+		t.Errorf("date: %+v", p)
+	}
+	if p := s.Properties["starts_at"]; p.Type != "string" || p.Format != "date-time" {
+		t.Errorf("starts_at: %+v", p)
+	}
+	if _, ok := s.Properties["internal"]; ok {
+		t.Error("Campos não exportados não devem ser documentados")
+	}
+}
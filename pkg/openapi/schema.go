@@ -0,0 +1,237 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tagPatterns traduz as regras próprias de pkg/validator para expressões
+// regulares equivalentes
+var tagPatterns = map[string]string{
+	"trimmed":    `^(\S(.*\S)?)?$`,
+	"personname": `^[\p{L}\p{Mn} '’.-]*$`,
+	"nocontrol":  `^[^\x00-\x08\x0B\x0C\x0E-\x1F\x7F-\x9F]*$`,
+}
+
+// tagFormats traduz regras de validação para o campo format
+var tagFormats = map[string]string{
+	"email":     "email",
+	"url":       "uri",
+	"uri":       "uri",
+	"httpurl":   "uri",
+	"uuid":      "uuid",
+	"daterange": "date",
+	"datetime":  "date-time",
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Schema retorna o schema do tipo de v. Structs nomeadas são registradas em
+// components.schemas e referenciadas por $ref.
+func (g *Generator) Schema(v interface{}) *Schema {
+	return g.schemaOf(reflect.TypeOf(v))
+}
+
+// SchemaNamed registra o schema de v com outro nome, para tipos anônimos ou
+// não exportados
+func (g *Generator) SchemaNamed(name string, v interface{}) *Schema {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if _, ok := g.doc.Components.Schemas[name]; !ok {
+		g.doc.Components.Schemas[name] = &Schema{}
+		*g.doc.Components.Schemas[name] = *g.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (g *Generator) schemaOf(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return g.SchemaNamed(t.Name(), reflect.New(t).Elem().Interface())
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: float(0)}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	}
+	// interface{} aceita qualquer valor
+	return &Schema{}
+}
+
+// structSchema descreve os campos exportados pelo nome JSON. Um campo é
+// obrigatório com validate:"required" ou, sem tag validate, quando não tem
+// omitempty (é sempre serializado). Campos extras não são aceitos.
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema), AdditionalProperties: false}
+	g.addFields(schema, t)
+	return schema
+}
+
+func (g *Generator) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(schema, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schemaOf(field.Type)
+		validate, hasValidate := field.Tag.Lookup("validate")
+		if hasValidate {
+			property = applyValidateTag(property, field.Type, validate)
+		}
+		schema.Properties[name] = property
+
+		omitempty := strings.Contains(options, "omitempty")
+		if hasRule(validate, "required") || (!hasValidate && !omitempty) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// applyValidateTag converte as regras de go-playground/validator em
+// restrições do schema. Regras após dive valem para os itens de listas.
+func applyValidateTag(schema *Schema, t reflect.Type, tag string) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if schema.Ref != "" {
+		return schema
+	}
+
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "dive" {
+			if schema.Items != nil {
+				schema.Items = applyValidateTag(schema.Items, t.Elem(), strings.Join(rules[i+1:], ","))
+			}
+			break
+		}
+
+		if pattern, ok := tagPatterns[name]; ok {
+			addPattern(schema, pattern)
+			continue
+		}
+		if format, ok := tagFormats[name]; ok {
+			schema.Format = format
+			continue
+		}
+
+		switch name {
+		case "min", "gte":
+			setBound(schema, t, param, true)
+		case "max", "lte":
+			setBound(schema, t, param, false)
+		case "len":
+			setBound(schema, t, param, true)
+			setBound(schema, t, param, false)
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, value)
+			}
+		}
+	}
+	return schema
+}
+
+// setBound aplica min/max conforme o tipo: tamanho em strings, itens em
+// listas e valor em números
+func setBound(schema *Schema, t reflect.Type, param string, lower bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch t.Kind() {
+	case reflect.String:
+		if lower {
+			schema.MinLength = integer(int(n))
+		} else {
+			schema.MaxLength = integer(int(n))
+		}
+	case reflect.Slice, reflect.Array:
+		if lower {
+			schema.MinItems = integer(int(n))
+		} else {
+			schema.MaxItems = integer(int(n))
+		}
+	default:
+		if lower {
+			schema.Minimum = float(n)
+		} else {
+			schema.Maximum = float(n)
+		}
+	}
+}
+
+// addPattern usa allOf quando o campo acumula mais de um padrão
+func addPattern(schema *Schema, pattern string) {
+	switch {
+	case schema.Pattern == "" && len(schema.AllOf) == 0:
+		schema.Pattern = pattern
+	case schema.Pattern != "":
+		schema.AllOf = []*Schema{{Pattern: schema.Pattern}, {Pattern: pattern}}
+		schema.Pattern = ""
+	default:
+		schema.AllOf = append(schema.AllOf, &Schema{Pattern: pattern})
+	}
+}
+
+func hasRule(tag, rule string) bool {
+	for _, r := range strings.Split(tag, ",") {
+		if r == "dive" {
+			return false
+		}
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+func integer(n int) *int { return &n }
+
+func float(n float64) *float64 { return &n }
@@ -0,0 +1,468 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Violation descreve uma divergência entre a mensagem HTTP e o contrato
+type Violation struct {
+	In      string // path, query, header, body ou response
+	Field   string // parâmetro ou caminho no corpo, ex: "id" ou "errors[0].field"
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Field == "" {
+		return v.In + ": " + v.Message
+	}
+	return v.In + " " + v.Field + ": " + v.Message
+}
+
+// Match é a operação do documento que atende uma requisição
+type Match struct {
+	Path      string // caminho no formato OpenAPI, ex: /api/personalities/{id}
+	Operation *Operation
+	Params    map[string]string
+}
+
+// Validator confere requisições e respostas contra o documento. Cobre o
+// subconjunto de JSON Schema gerado por este pacote; corpos são validados
+// apenas em JSON, os demais formatos têm só o Content-Type conferido.
+type Validator struct {
+	doc      *Document
+	routes   []route
+	patterns sync.Map // string -> *regexp.Regexp
+}
+
+type route struct {
+	path     string
+	segments []string
+}
+
+// NewValidator prepara o documento para validação
+func NewValidator(doc *Document) *Validator {
+	v := &Validator{doc: doc}
+	for path := range doc.Paths {
+		v.routes = append(v.routes, route{path: path, segments: strings.Split(path, "/")})
+	}
+	// Caminhos com mais segmentos literais têm precedência, ex: /a/novo antes de /a/{id}
+	sort.Slice(v.routes, func(i, j int) bool {
+		li, lj := literals(v.routes[i].segments), literals(v.routes[j].segments)
+		if li != lj {
+			return li > lj
+		}
+		return v.routes[i].path < v.routes[j].path
+	})
+	return v
+}
+
+func literals(segments []string) int {
+	n := 0
+	for _, s := range segments {
+		if !isVariable(s) {
+			n++
+		}
+	}
+	return n
+}
+
+func isVariable(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// Match encontra a operação do método no caminho. HEAD usa a operação GET
+// quando não há uma própria. Variáveis só casam com valores do tipo e padrão
+// do parâmetro, como acontece com as expressões do gorilla/mux.
+func (v *Validator) Match(method, path string) (*Match, bool) {
+	segments := strings.Split(path, "/")
+	for _, rt := range v.routes {
+		if len(rt.segments) != len(segments) {
+			continue
+		}
+		op := v.doc.Paths[rt.path].Operation(method)
+		if op == nil && method == http.MethodHead {
+			op = v.doc.Paths[rt.path].Get
+		}
+		if op == nil {
+			continue
+		}
+		if params, ok := v.matchSegments(op, rt.segments, segments); ok {
+			return &Match{Path: rt.path, Operation: op, Params: params}, true
+		}
+	}
+	return nil, false
+}
+
+func (v *Validator) matchSegments(op *Operation, template, segments []string) (map[string]string, bool) {
+	params := make(map[string]string)
+	for i, segment := range template {
+		if !isVariable(segment) {
+			if segment != segments[i] {
+				return nil, false
+			}
+			continue
+		}
+		value, err := url.PathUnescape(segments[i])
+		if err != nil || value == "" {
+			return nil, false
+		}
+		name := segment[1 : len(segment)-1]
+		for _, p := range op.Parameters {
+			if p.In == "path" && p.Name == name && !v.matchesVariable(p, value) {
+				return nil, false
+			}
+		}
+		params[name] = value
+	}
+	return params, true
+}
+
+// matchesVariable confere só o tipo e o padrão do parâmetro; limites como
+// minimum viram violações em ValidateRequest
+func (v *Validator) matchesVariable(p *Parameter, raw string) bool {
+	if p.Schema == nil {
+		return true
+	}
+	schema := v.resolve(p.Schema)
+	if schema.Type != "" && !hasType(schema.Type, parameterValue(schema, raw)) {
+		return false
+	}
+	return schema.Pattern == "" || v.pattern(schema.Pattern).MatchString(raw)
+}
+
+// ValidateRequest confere parâmetros e corpo. body é o corpo já lido, ou nil
+// quando não foi lido; corpos comprimidos ou em formatos diferentes de JSON
+// não são inspecionados.
+func (v *Validator) ValidateRequest(m *Match, r *http.Request, body []byte) []Violation {
+	var out []Violation
+	query := r.URL.Query()
+	for _, p := range m.Operation.Parameters {
+		var raw string
+		var present bool
+		switch p.In {
+		case "path":
+			raw, present = m.Params[p.Name]
+		case "query":
+			if values, ok := query[p.Name]; ok && len(values) > 0 {
+				raw, present = values[0], true
+			}
+		case "header":
+			raw = r.Header.Get(p.Name)
+			present = raw != ""
+		default:
+			continue
+		}
+		if !present {
+			if p.Required {
+				out = append(out, Violation{In: p.In, Field: p.Name, Message: "parâmetro obrigatório ausente"})
+			}
+			continue
+		}
+		out = append(out, v.validateParameter(p, raw)...)
+	}
+
+	rb := m.Operation.RequestBody
+	if rb == nil || body == nil || r.Header.Get("Content-Encoding") != "" {
+		return out
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if rb.Required {
+			out = append(out, Violation{In: "body", Message: "corpo obrigatório ausente"})
+		}
+		return out
+	}
+	// Sem Content-Type o corpo é lido como JSON, como em pkg/codec
+	mediaType := "application/json"
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, _ = mime.ParseMediaType(contentType)
+	}
+	// JSON malformado não é questão de contrato: o handler responde MALFORMED_BODY
+	if media, ok := rb.Content[mediaType]; ok && isJSON(mediaType) && json.Valid(body) {
+		out = append(out, v.validateJSON("body", media.Schema, body)...)
+	}
+	return out
+}
+
+func (v *Validator) validateParameter(p *Parameter, raw string) []Violation {
+	if p.Schema == nil {
+		return nil
+	}
+	var out []Violation
+	v.validateValue(p.Schema, parameterValue(v.resolve(p.Schema), raw), p.Name, p.In, &out)
+	return out
+}
+
+// parameterValue converte o texto do parâmetro para o tipo do schema; valores
+// que não convertem seguem como string e falham na checagem de tipo
+func parameterValue(schema *Schema, raw string) interface{} {
+	switch schema.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			return json.Number(raw)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+	return raw
+}
+
+// ValidateResponse confere status, Content-Type e, para JSON, o corpo da
+// resposta. Respostas de HEAD e sem corpo só têm status e formato conferidos.
+func (v *Validator) ValidateResponse(m *Match, method string, status int, header http.Header, body []byte) []Violation {
+	response, ok := m.Operation.Responses[strconv.Itoa(status)]
+	if !ok {
+		if response, ok = m.Operation.Responses["default"]; !ok {
+			return []Violation{{In: "response", Message: fmt.Sprintf("status %d não documentado", status)}}
+		}
+	}
+	if len(response.Content) == 0 || status == http.StatusNotModified {
+		return nil
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" && (len(body) == 0 || method == http.MethodHead) {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	media, ok := response.Content[mediaType]
+	if !ok {
+		return []Violation{{In: "response", Message: fmt.Sprintf("Content-Type %q não documentado para o status %d", contentType, status)}}
+	}
+	if method == http.MethodHead || len(body) == 0 || !isJSON(mediaType) {
+		return nil
+	}
+	return v.validateJSON("response", media.Schema, body)
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// validateJSON decodifica o corpo preservando os números para comparar
+// inteiros e limites sem perda de precisão
+func (v *Validator) validateJSON(in string, schema *Schema, body []byte) []Violation {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return []Violation{{In: in, Message: "JSON inválido: " + err.Error()}}
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return []Violation{{In: in, Message: "o corpo deve conter um único valor JSON"}}
+	}
+	var out []Violation
+	v.validateValue(schema, value, "", in, &out)
+	return out
+}
+
+// resolve segue $ref até o schema em components
+func (v *Validator) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = v.doc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	if schema == nil {
+		return &Schema{}
+	}
+	return schema
+}
+
+func (v *Validator) validateValue(schema *Schema, value interface{}, field, in string, out *[]Violation) {
+	schema = v.resolve(schema)
+	report := func(format string, args ...interface{}) {
+		*out = append(*out, Violation{In: in, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, sub := range schema.AllOf {
+		v.validateValue(sub, value, field, in, out)
+	}
+	if len(schema.OneOf) > 0 {
+		v.validateOneOf(schema.OneOf, value, field, in, out)
+	}
+	if schema.Type != "" && !hasType(schema.Type, value) {
+		report("deve ser do tipo %s", schema.Type)
+		return
+	}
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		report("deve ser um dos valores %v", schema.Enum)
+	}
+
+	switch value := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(value)
+		if schema.MinLength != nil && length < *schema.MinLength {
+			report("deve ter no mínimo %d caracteres", *schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			report("deve ter no máximo %d caracteres", *schema.MaxLength)
+		}
+		if schema.Pattern != "" && !v.pattern(schema.Pattern).MatchString(value) {
+			report("não corresponde ao padrão %s", schema.Pattern)
+		}
+		if schema.Format != "" && !validFormat(schema.Format, value) {
+			report("formato %s inválido", schema.Format)
+		}
+	case json.Number:
+		n, _ := value.Float64()
+		if schema.Minimum != nil && n < *schema.Minimum {
+			report("deve ser no mínimo %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			report("deve ser no máximo %v", *schema.Maximum)
+		}
+	case []interface{}:
+		if schema.MinItems != nil && len(value) < *schema.MinItems {
+			report("deve ter no mínimo %d itens", *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(value) > *schema.MaxItems {
+			report("deve ter no máximo %d itens", *schema.MaxItems)
+		}
+		if schema.Items != nil {
+			for i, item := range value {
+				v.validateValue(schema.Items, item, fmt.Sprintf("%s[%d]", field, i), in, out)
+			}
+		}
+	case map[string]interface{}:
+		v.validateObject(schema, value, field, in, out)
+	}
+}
+
+// validateOneOf exige que exatamente um dos schemas aceite o valor. Sem
+// nenhum, reporta as violações da alternativa que chegou mais perto.
+func (v *Validator) validateOneOf(schemas []*Schema, value interface{}, field, in string, out *[]Violation) {
+	var closest []Violation
+	matches := 0
+	for _, sub := range schemas {
+		var violations []Violation
+		v.validateValue(sub, value, field, in, &violations)
+		if len(violations) == 0 {
+			matches++
+		} else if closest == nil || len(violations) < len(closest) {
+			closest = violations
+		}
+	}
+	switch {
+	case matches == 0:
+		*out = append(*out, closest...)
+	case matches > 1:
+		*out = append(*out, Violation{In: in, Field: field, Message: fmt.Sprintf("corresponde a %d alternativas de oneOf", matches)})
+	}
+}
+
+func (v *Validator) validateObject(schema *Schema, value map[string]interface{}, field, in string, out *[]Violation) {
+	for _, name := range schema.Required {
+		if _, ok := value[name]; !ok {
+			*out = append(*out, Violation{In: in, Field: join(field, name), Message: "campo obrigatório ausente"})
+		}
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if property, ok := schema.Properties[name]; ok {
+			v.validateValue(property, value[name], join(field, name), in, out)
+			continue
+		}
+		switch additional := schema.AdditionalProperties.(type) {
+		case bool:
+			if !additional {
+				*out = append(*out, Violation{In: in, Field: join(field, name), Message: "campo não previsto no contrato"})
+			}
+		case *Schema:
+			v.validateValue(additional, value[name], join(field, name), in, out)
+		}
+	}
+}
+
+func join(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func hasType(typ string, value interface{}) bool {
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		if _, err := n.Int64(); err == nil {
+			return true
+		}
+		f, err := n.Float64()
+		return err == nil && f == float64(int64(f))
+	}
+	return true
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func validFormat(format, value string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, value)
+		return err == nil
+	case "uri":
+		u, err := url.Parse(value)
+		return err == nil && u.IsAbs()
+	}
+	return true
+}
+
+// pattern compila cada expressão do documento uma única vez
+func (v *Validator) pattern(expr string) *regexp.Regexp {
+	if re, ok := v.patterns.Load(expr); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		// Padrões que o Go não compila não são conferidos
+		re = regexp.MustCompile("")
+	}
+	v.patterns.Store(expr, re)
+	return re
+}
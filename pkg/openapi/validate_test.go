@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type ticket struct {
+	Title string   `json:"title" validate:"required,trimmed,max=20"`
+	Seats int      `json:"seats" validate:"omitempty,min=1"`
+	Tags  []string `json:"tags,omitempty" validate:"max=2"`
+}
+
+func newTestValidator() *Validator {
+	g := New(Info{Title: "teste", Version: "1"})
+	schema := g.Schema(ticket{})
+	jsonContent := map[string]*MediaType{"application/json": {Schema: schema}}
+	g.Add(http.MethodGet, "/tickets/{id:[0-9]+}", &Operation{
+		OperationID: "getTicket",
+		Parameters: []*Parameter{{Name: "id", In: "path", Required: true,
+			Schema: &Schema{Type: "integer", Minimum: float(1)}}},
+		Responses: map[string]*Response{"200": {Description: "ok", Content: jsonContent}},
+	})
+	g.Add(http.MethodGet, "/tickets/new", &Operation{
+		OperationID: "newTicket",
+		Responses:   map[string]*Response{"204": {Description: "vazio"}},
+	})
+	g.Add(http.MethodPost, "/tickets", &Operation{
+		OperationID: "createTicket",
+		RequestBody: &RequestBody{Required: true, Content: jsonContent},
+		Responses:   map[string]*Response{"201": {Description: "criado", Content: jsonContent}},
+	})
+	return NewValidator(g.Document())
+}
+
+func TestValidator_Match(t *testing.T) {
+	v := newTestValidator()
+	tests := []struct {
+		method    string
+		path      string
+		operation string
+	}{
+		{http.MethodGet, "/tickets/7", "getTicket"},
+		{http.MethodHead, "/tickets/7", "getTicket"},
+		{http.MethodGet, "/tickets/new", "newTicket"},
+		{http.MethodGet, "/tickets/abc", ""},
+		{http.MethodDelete, "/tickets/7", ""},
+		{http.MethodGet, "/tickets/7/extra", ""},
+	}
+	for _, tt := range tests {
+		m, ok := v.Match(tt.method, tt.path)
+		if tt.operation == "" {
+			if ok {
+				t.Errorf("%s %s: não esperava operação, obteve %s", tt.method, tt.path, m.Operation.OperationID)
+			}
+			continue
+		}
+		if !ok || m.Operation.OperationID != tt.operation {
+			t.Errorf("%s %s: esperava %s, obteve %+v", tt.method, tt.path, tt.operation, m)
+		}
+	}
+}
+
+func TestValidator_ValidateRequest(t *testing.T) {
+	v := newTestValidator()
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		fields []string
+	}{
+		{"válida", http.MethodPost, "/tickets", `{"title":"Palestra","tags":["go"]}`, nil},
+		{"parâmetro abaixo do mínimo", http.MethodGet, "/tickets/0", "", []string{"id"}},
+		{"campo ausente e extra", http.MethodPost, "/tickets", `{"seats":2,"room":"A"}`, []string{"title", "room"}},
+		{"tipo e tamanho", http.MethodPost, "/tickets", `{"title":" x","seats":1.5,"tags":["a","b","c"]}`, []string{"seats", "tags", "title"}},
+		{"corpo vazio", http.MethodPost, "/tickets", "", []string{""}},
+		{"JSON malformado fica para o handler", http.MethodPost, "/tickets", `{"title":`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			m, ok := v.Match(tt.method, tt.path)
+			if !ok {
+				t.Fatal("Esperava encontrar a operação")
+			}
+			violations := v.ValidateRequest(m, req, []byte(tt.body))
+			if len(violations) != len(tt.fields) {
+				t.Fatalf("Esperava %d violações, obteve %v", len(tt.fields), violations)
+			}
+			for i, field := range tt.fields {
+				if violations[i].Field != field {
+					t.Errorf("Violação %d: esperava campo %q, obteve %v", i, field, violations[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateResponse(t *testing.T) {
+	v := newTestValidator()
+	m, _ := v.Match(http.MethodGet, "/tickets/7")
+	jsonHeader := http.Header{"Content-Type": {"application/json; charset=utf-8"}}
+
+	if got := v.ValidateResponse(m, http.MethodGet, http.StatusOK, jsonHeader, []byte(`{"title":"Palestra"}`)); len(got) != 0 {
+		t.Errorf("Resposta válida gerou violações: %v", got)
+	}
+	if got := v.ValidateResponse(m, http.MethodHead, http.StatusOK, jsonHeader, nil); len(got) != 0 {
+		t.Errorf("HEAD sem corpo gerou violações: %v", got)
+	}
+
+	tests := []struct {
+		name    string
+		status  int
+		header  http.Header
+		body    string
+		message string
+	}{
+		{"status não documentado", http.StatusTeapot, jsonHeader, `{}`, "status 418"},
+		{"formato não documentado", http.StatusOK, http.Header{"Content-Type": {"text/plain"}}, "x", "Content-Type"},
+		{"campo obrigatório", http.StatusOK, jsonHeader, `{"seats":1}`, "obrigatório"},
+		{"JSON inválido", http.StatusOK, jsonHeader, `{"title"`, "JSON inválido"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := v.ValidateResponse(m, http.MethodGet, tt.status, tt.header, []byte(tt.body))
+			if len(got) != 1 || !strings.Contains(got[0].Message, tt.message) {
+				t.Errorf("Esperava uma violação com %q, obteve %v", tt.message, got)
+			}
+		})
+	}
+}
+
+func TestValidator_OneOf(t *testing.T) {
+	v := NewValidator(New(Info{Title: "teste", Version: "1"}).Document())
+	schema := &Schema{OneOf: []*Schema{
+		{Type: "object", Properties: map[string]*Schema{"id": {Type: "integer"}}, Required: []string{"id"}, AdditionalProperties: false},
+		{Type: "object", Properties: map[string]*Schema{"data": {Type: "object"}}, Required: []string{"data"}, AdditionalProperties: false},
+	}}
+	tests := []struct {
+		body       string
+		violations int
+	}{
+		{`{"id":1}`, 0},
+		{`{"data":{}}`, 0},
+		{`{"id":"1"}`, 1},
+		{`[]`, 1},
+	}
+	for _, tt := range tests {
+		if got := v.validateJSON("response", schema, []byte(tt.body)); len(got) != tt.violations {
+			t.Errorf("%s: esperava %d violações, obteve %v", tt.body, tt.violations, got)
+		}
+	}
+
+	ambiguous := &Schema{OneOf: []*Schema{{Type: "integer"}, {Type: "number"}}}
+	if got := v.validateJSON("response", ambiguous, []byte(`1`)); len(got) != 1 {
+		t.Errorf("Valor aceito por duas alternativas deveria violar oneOf, obteve %v", got)
+	}
+}
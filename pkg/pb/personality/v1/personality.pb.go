@@ -0,0 +1,679 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: personality/v1/personality.proto
+
+package personalityv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PersonalityEvent_Type int32
+
+const (
+	PersonalityEvent_TYPE_UNSPECIFIED PersonalityEvent_Type = 0
+	PersonalityEvent_TYPE_CREATED     PersonalityEvent_Type = 1
+	PersonalityEvent_TYPE_UPDATED     PersonalityEvent_Type = 2
+	PersonalityEvent_TYPE_DELETED     PersonalityEvent_Type = 3
+)
+
+// Enum value maps for PersonalityEvent_Type.
+var (
+	PersonalityEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_CREATED",
+		2: "TYPE_UPDATED",
+		3: "TYPE_DELETED",
+	}
+	PersonalityEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_CREATED":     1,
+		"TYPE_UPDATED":     2,
+		"TYPE_DELETED":     3,
+	}
+)
+
+func (x PersonalityEvent_Type) Enum() *PersonalityEvent_Type {
+	p := new(PersonalityEvent_Type)
+	*p = x
+	return p
+}
+
+func (x PersonalityEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PersonalityEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_personality_v1_personality_proto_enumTypes[0].Descriptor()
+}
+
+func (PersonalityEvent_Type) Type() protoreflect.EnumType {
+	return &file_personality_v1_personality_proto_enumTypes[0]
+}
+
+func (x PersonalityEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PersonalityEvent_Type.Descriptor instead.
+func (PersonalityEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_personality_v1_personality_proto_rawDescGZIP(), []int{7, 0}
+}
+
+// Personality é uma personalidade histórica
+type Personality struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name    string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	History string                 `protobuf:"bytes,3,opt,name=history,proto3" json:"history,omitempty"`
+	// Incrementada a cada alteração
+	Version       uint32                 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Personality) Reset() {
+	*x = Personality{}
+	mi := &file_personality_v1_personality_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Personality) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Personality) ProtoMessage() {}
+
+func (x *Personality) ProtoReflect() protoreflect.Message {
+	mi := &file_personality_v1_personality_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Personality.ProtoReflect.Descriptor instead.
+func (*Personality) Descriptor() ([]byte, []int) {
+	return file_personality_v1_personality_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Personality) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Personality) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Personality) GetHistory() string {
+	if x != nil {
+		return x.History
+	}
+	return ""
+}
+
+func (x *Personality) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Personality) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Personality) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type GetPersonalityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPersonalityRequest) Reset() {
+	*x = GetPersonalityRequest{}
+	mi := &file_personality_v1_personality_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPersonalityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPersonalityRequest) ProtoMessage() {}
+
+func (x *GetPersonalityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_personality_v1_personality_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPersonalityRequest.ProtoReflect.Descriptor instead.
+func (*GetPersonalityRequest) Descriptor() ([]byte, []int) {
+	return file_personality_v1_personality_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPersonalityRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// ListPersonalitiesRequest filtra a listagem; campos vazios não filtram
+type ListPersonalitiesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Trecho do nome, sem diferenciar maiúsculas
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Trecho do nome ou da história, sem diferenciar maiúsculas
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// Alteradas a partir desta data
+	UpdatedSince  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPersonalitiesRequest) Reset() {
+	*x = ListPersonalitiesRequest{}
+	mi := &file_personality_v1_personality_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPersonalitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPersonalitiesRequest) ProtoMessage() {}
+
+func (x *ListPersonalitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_personality_v1_personality_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPersonalitiesRequest.ProtoReflect.Descriptor instead.
+func (*ListPersonalitiesRequest) Descriptor() ([]byte, []int) {
+	return file_personality_v1_personality_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPersonalitiesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListPersonalitiesRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ListPersonalitiesRequest) GetUpdatedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return nil
+}
+
+type CreatePersonalityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	History       string                 `protobuf:"bytes,2,opt,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePersonalityRequest) Reset() {
+	*x = CreatePersonalityRequest{}
+	mi := &file_personality_v1_personality_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePersonalityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePersonalityRequest) ProtoMessage() {}
+
+func (x *CreatePersonalityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_personality_v1_personality_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePersonalityRequest.ProtoReflect.Descriptor instead.
+func (*CreatePersonalityRequest) Descriptor() ([]byte, []int) {
+	return file_personality_v1_personality_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreatePersonalityRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePersonalityRequest) GetHistory() string {
+	if x != nil {
+		return x.History
+	}
+	return ""
+}
+
+type UpdatePersonalityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	History       string                 `protobuf:"bytes,3,opt,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePersonalityRequest) Reset() {
+	*x = UpdatePersonalityRequest{}
+	mi := &file_personality_v1_personality_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePersonalityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePersonalityRequest) ProtoMessage() {}
+
+func (x *UpdatePersonalityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_personality_v1_personality_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePersonalityRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePersonalityRequest) Descriptor() ([]byte, []int) {
+	return file_personality_v1_personality_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdatePersonalityRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdatePersonalityRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdatePersonalityRequest) GetHistory() string {
+	if x != nil {
+		return x.History
+	}
+	return ""
+}
+
+type DeletePersonalityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePersonalityRequest) Reset() {
+	*x = DeletePersonalityRequest{}
+	mi := &file_personality_v1_personality_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePersonalityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePersonalityRequest) ProtoMessage() {}
+
+func (x *DeletePersonalityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_personality_v1_personality_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePersonalityRequest.ProtoReflect.Descriptor instead.
+func (*DeletePersonalityRequest) Descriptor() ([]byte, []int) {
+	return file_personality_v1_personality_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeletePersonalityRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// WatchPersonalitiesRequest restringe as alterações enviadas
+type WatchPersonalitiesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IDs acompanhados; vazio acompanha todas as personalidades
+	Ids           []uint32 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPersonalitiesRequest) Reset() {
+	*x = WatchPersonalitiesRequest{}
+	mi := &file_personality_v1_personality_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPersonalitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPersonalitiesRequest) ProtoMessage() {}
+
+func (x *WatchPersonalitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_personality_v1_personality_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPersonalitiesRequest.ProtoReflect.Descriptor instead.
+func (*WatchPersonalitiesRequest) Descriptor() ([]byte, []int) {
+	return file_personality_v1_personality_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WatchPersonalitiesRequest) GetIds() []uint32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// PersonalityEvent é uma alteração no cadastro
+type PersonalityEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Posição crescente do evento no processo
+	Sequence      uint64                `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type          PersonalityEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=personality.v1.PersonalityEvent_Type" json:"type,omitempty"`
+	PersonalityId uint32                `protobuf:"varint,3,opt,name=personality_id,json=personalityId,proto3" json:"personality_id,omitempty"`
+	// Estado após a alteração; ausente em TYPE_DELETED
+	Personality   *Personality           `protobuf:"bytes,4,opt,name=personality,proto3" json:"personality,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PersonalityEvent) Reset() {
+	*x = PersonalityEvent{}
+	mi := &file_personality_v1_personality_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PersonalityEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersonalityEvent) ProtoMessage() {}
+
+func (x *PersonalityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_personality_v1_personality_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersonalityEvent.ProtoReflect.Descriptor instead.
+func (*PersonalityEvent) Descriptor() ([]byte, []int) {
+	return file_personality_v1_personality_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PersonalityEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *PersonalityEvent) GetType() PersonalityEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return PersonalityEvent_TYPE_UNSPECIFIED
+}
+
+func (x *PersonalityEvent) GetPersonalityId() uint32 {
+	if x != nil {
+		return x.PersonalityId
+	}
+	return 0
+}
+
+func (x *PersonalityEvent) GetPersonality() *Personality {
+	if x != nil {
+		return x.Personality
+	}
+	return nil
+}
+
+func (x *PersonalityEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+var File_personality_v1_personality_proto protoreflect.FileDescriptor
+
+const file_personality_v1_personality_proto_rawDesc = "" +
+	"\n" +
+	" personality/v1/personality.proto\x12\x0epersonality.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdf\x01\n" +
+	"\vPersonality\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\ahistory\x18\x03 \x01(\tR\ahistory\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\rR\aversion\x12;\n" +
+	"\vcreate_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"'\n" +
+	"\x15GetPersonalityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\x83\x01\n" +
+	"\x18ListPersonalitiesRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12?\n" +
+	"\rupdated_since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedSince\"H\n" +
+	"\x18CreatePersonalityRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\ahistory\x18\x02 \x01(\tR\ahistory\"X\n" +
+	"\x18UpdatePersonalityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\ahistory\x18\x03 \x01(\tR\ahistory\"*\n" +
+	"\x18DeletePersonalityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"-\n" +
+	"\x19WatchPersonalitiesRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\rR\x03ids\"\xd3\x02\n" +
+	"\x10PersonalityEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x129\n" +
+	"\x04type\x18\x02 \x01(\x0e2%.personality.v1.PersonalityEvent.TypeR\x04type\x12%\n" +
+	"\x0epersonality_id\x18\x03 \x01(\rR\rpersonalityId\x12=\n" +
+	"\vpersonality\x18\x04 \x01(\v2\x1b.personality.v1.PersonalityR\vpersonality\x12.\n" +
+	"\x04time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"R\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fTYPE_CREATED\x10\x01\x12\x10\n" +
+	"\fTYPE_UPDATED\x10\x02\x12\x10\n" +
+	"\fTYPE_DELETED\x10\x032\xbc\x04\n" +
+	"\x12PersonalityService\x12T\n" +
+	"\x0eGetPersonality\x12%.personality.v1.GetPersonalityRequest\x1a\x1b.personality.v1.Personality\x12\\\n" +
+	"\x11ListPersonalities\x12(.personality.v1.ListPersonalitiesRequest\x1a\x1b.personality.v1.Personality0\x01\x12Z\n" +
+	"\x11CreatePersonality\x12(.personality.v1.CreatePersonalityRequest\x1a\x1b.personality.v1.Personality\x12Z\n" +
+	"\x11UpdatePersonality\x12(.personality.v1.UpdatePersonalityRequest\x1a\x1b.personality.v1.Personality\x12U\n" +
+	"\x11DeletePersonality\x12(.personality.v1.DeletePersonalityRequest\x1a\x16.google.protobuf.Empty\x12c\n" +
+	"\x12WatchPersonalities\x12).personality.v1.WatchPersonalitiesRequest\x1a .personality.v1.PersonalityEvent0\x01B1Z/go-api-rest/pkg/pb/personality/v1;personalityv1b\x06proto3"
+
+var (
+	file_personality_v1_personality_proto_rawDescOnce sync.Once
+	file_personality_v1_personality_proto_rawDescData []byte
+)
+
+func file_personality_v1_personality_proto_rawDescGZIP() []byte {
+	file_personality_v1_personality_proto_rawDescOnce.Do(func() {
+		file_personality_v1_personality_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_personality_v1_personality_proto_rawDesc), len(file_personality_v1_personality_proto_rawDesc)))
+	})
+	return file_personality_v1_personality_proto_rawDescData
+}
+
+var file_personality_v1_personality_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_personality_v1_personality_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_personality_v1_personality_proto_goTypes = []any{
+	(PersonalityEvent_Type)(0),        // 0: personality.v1.PersonalityEvent.Type
+	(*Personality)(nil),               // 1: personality.v1.Personality
+	(*GetPersonalityRequest)(nil),     // 2: personality.v1.GetPersonalityRequest
+	(*ListPersonalitiesRequest)(nil),  // 3: personality.v1.ListPersonalitiesRequest
+	(*CreatePersonalityRequest)(nil),  // 4: personality.v1.CreatePersonalityRequest
+	(*UpdatePersonalityRequest)(nil),  // 5: personality.v1.UpdatePersonalityRequest
+	(*DeletePersonalityRequest)(nil),  // 6: personality.v1.DeletePersonalityRequest
+	(*WatchPersonalitiesRequest)(nil), // 7: personality.v1.WatchPersonalitiesRequest
+	(*PersonalityEvent)(nil),          // 8: personality.v1.PersonalityEvent
+	(*timestamppb.Timestamp)(nil),     // 9: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),             // 10: google.protobuf.Empty
+}
+var file_personality_v1_personality_proto_depIdxs = []int32{
+	9,  // 0: personality.v1.Personality.create_time:type_name -> google.protobuf.Timestamp
+	9,  // 1: personality.v1.Personality.update_time:type_name -> google.protobuf.Timestamp
+	9,  // 2: personality.v1.ListPersonalitiesRequest.updated_since:type_name -> google.protobuf.Timestamp
+	0,  // 3: personality.v1.PersonalityEvent.type:type_name -> personality.v1.PersonalityEvent.Type
+	1,  // 4: personality.v1.PersonalityEvent.personality:type_name -> personality.v1.Personality
+	9,  // 5: personality.v1.PersonalityEvent.time:type_name -> google.protobuf.Timestamp
+	2,  // 6: personality.v1.PersonalityService.GetPersonality:input_type -> personality.v1.GetPersonalityRequest
+	3,  // 7: personality.v1.PersonalityService.ListPersonalities:input_type -> personality.v1.ListPersonalitiesRequest
+	4,  // 8: personality.v1.PersonalityService.CreatePersonality:input_type -> personality.v1.CreatePersonalityRequest
+	5,  // 9: personality.v1.PersonalityService.UpdatePersonality:input_type -> personality.v1.UpdatePersonalityRequest
+	6,  // 10: personality.v1.PersonalityService.DeletePersonality:input_type -> personality.v1.DeletePersonalityRequest
+	7,  // 11: personality.v1.PersonalityService.WatchPersonalities:input_type -> personality.v1.WatchPersonalitiesRequest
+	1,  // 12: personality.v1.PersonalityService.GetPersonality:output_type -> personality.v1.Personality
+	1,  // 13: personality.v1.PersonalityService.ListPersonalities:output_type -> personality.v1.Personality
+	1,  // 14: personality.v1.PersonalityService.CreatePersonality:output_type -> personality.v1.Personality
+	1,  // 15: personality.v1.PersonalityService.UpdatePersonality:output_type -> personality.v1.Personality
+	10, // 16: personality.v1.PersonalityService.DeletePersonality:output_type -> google.protobuf.Empty
+	8,  // 17: personality.v1.PersonalityService.WatchPersonalities:output_type -> personality.v1.PersonalityEvent
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_personality_v1_personality_proto_init() }
+func file_personality_v1_personality_proto_init() {
+	if File_personality_v1_personality_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_personality_v1_personality_proto_rawDesc), len(file_personality_v1_personality_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_personality_v1_personality_proto_goTypes,
+		DependencyIndexes: file_personality_v1_personality_proto_depIdxs,
+		EnumInfos:         file_personality_v1_personality_proto_enumTypes,
+		MessageInfos:      file_personality_v1_personality_proto_msgTypes,
+	}.Build()
+	File_personality_v1_personality_proto = out.File
+	file_personality_v1_personality_proto_goTypes = nil
+	file_personality_v1_personality_proto_depIdxs = nil
+}
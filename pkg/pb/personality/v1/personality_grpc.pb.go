@@ -0,0 +1,349 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: personality/v1/personality.proto
+
+package personalityv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PersonalityService_GetPersonality_FullMethodName     = "/personality.v1.PersonalityService/GetPersonality"
+	PersonalityService_ListPersonalities_FullMethodName  = "/personality.v1.PersonalityService/ListPersonalities"
+	PersonalityService_CreatePersonality_FullMethodName  = "/personality.v1.PersonalityService/CreatePersonality"
+	PersonalityService_UpdatePersonality_FullMethodName  = "/personality.v1.PersonalityService/UpdatePersonality"
+	PersonalityService_DeletePersonality_FullMethodName  = "/personality.v1.PersonalityService/DeletePersonality"
+	PersonalityService_WatchPersonalities_FullMethodName = "/personality.v1.PersonalityService/WatchPersonalities"
+)
+
+// PersonalityServiceClient is the client API for PersonalityService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PersonalityService expõe o cadastro de personalidades históricas. Os erros
+// seguem os mesmos códigos das respostas REST, no detalhe
+// google.rpc.ErrorInfo (reason), e erros de validação trazem os campos em
+// google.rpc.BadRequest.
+type PersonalityServiceClient interface {
+	// GetPersonality busca uma personalidade pelo ID
+	GetPersonality(ctx context.Context, in *GetPersonalityRequest, opts ...grpc.CallOption) (*Personality, error)
+	// ListPersonalities envia as personalidades que casam com o filtro, em
+	// ordem de ID, uma por mensagem
+	ListPersonalities(ctx context.Context, in *ListPersonalitiesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Personality], error)
+	// CreatePersonality cadastra uma personalidade com nome único
+	CreatePersonality(ctx context.Context, in *CreatePersonalityRequest, opts ...grpc.CallOption) (*Personality, error)
+	// UpdatePersonality altera os campos informados; campos vazios mantêm o
+	// valor atual, mas ao menos um deve ser informado
+	UpdatePersonality(ctx context.Context, in *UpdatePersonalityRequest, opts ...grpc.CallOption) (*Personality, error)
+	// DeletePersonality remove uma personalidade
+	DeletePersonality(ctx context.Context, in *DeletePersonalityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// WatchPersonalities envia as alterações feitas a partir da chamada. O
+	// stream termina com RESOURCE_EXHAUSTED quando o cliente não acompanha o
+	// ritmo das alterações.
+	WatchPersonalities(ctx context.Context, in *WatchPersonalitiesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PersonalityEvent], error)
+}
+
+type personalityServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPersonalityServiceClient(cc grpc.ClientConnInterface) PersonalityServiceClient {
+	return &personalityServiceClient{cc}
+}
+
+func (c *personalityServiceClient) GetPersonality(ctx context.Context, in *GetPersonalityRequest, opts ...grpc.CallOption) (*Personality, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Personality)
+	err := c.cc.Invoke(ctx, PersonalityService_GetPersonality_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personalityServiceClient) ListPersonalities(ctx context.Context, in *ListPersonalitiesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Personality], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PersonalityService_ServiceDesc.Streams[0], PersonalityService_ListPersonalities_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListPersonalitiesRequest, Personality]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PersonalityService_ListPersonalitiesClient = grpc.ServerStreamingClient[Personality]
+
+func (c *personalityServiceClient) CreatePersonality(ctx context.Context, in *CreatePersonalityRequest, opts ...grpc.CallOption) (*Personality, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Personality)
+	err := c.cc.Invoke(ctx, PersonalityService_CreatePersonality_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personalityServiceClient) UpdatePersonality(ctx context.Context, in *UpdatePersonalityRequest, opts ...grpc.CallOption) (*Personality, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Personality)
+	err := c.cc.Invoke(ctx, PersonalityService_UpdatePersonality_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personalityServiceClient) DeletePersonality(ctx context.Context, in *DeletePersonalityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PersonalityService_DeletePersonality_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *personalityServiceClient) WatchPersonalities(ctx context.Context, in *WatchPersonalitiesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PersonalityEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PersonalityService_ServiceDesc.Streams[1], PersonalityService_WatchPersonalities_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPersonalitiesRequest, PersonalityEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PersonalityService_WatchPersonalitiesClient = grpc.ServerStreamingClient[PersonalityEvent]
+
+// PersonalityServiceServer is the server API for PersonalityService service.
+// All implementations must embed UnimplementedPersonalityServiceServer
+// for forward compatibility.
+//
+// PersonalityService expõe o cadastro de personalidades históricas. Os erros
+// seguem os mesmos códigos das respostas REST, no detalhe
+// google.rpc.ErrorInfo (reason), e erros de validação trazem os campos em
+// google.rpc.BadRequest.
+type PersonalityServiceServer interface {
+	// GetPersonality busca uma personalidade pelo ID
+	GetPersonality(context.Context, *GetPersonalityRequest) (*Personality, error)
+	// ListPersonalities envia as personalidades que casam com o filtro, em
+	// ordem de ID, uma por mensagem
+	ListPersonalities(*ListPersonalitiesRequest, grpc.ServerStreamingServer[Personality]) error
+	// CreatePersonality cadastra uma personalidade com nome único
+	CreatePersonality(context.Context, *CreatePersonalityRequest) (*Personality, error)
+	// UpdatePersonality altera os campos informados; campos vazios mantêm o
+	// valor atual, mas ao menos um deve ser informado
+	UpdatePersonality(context.Context, *UpdatePersonalityRequest) (*Personality, error)
+	// DeletePersonality remove uma personalidade
+	DeletePersonality(context.Context, *DeletePersonalityRequest) (*emptypb.Empty, error)
+	// WatchPersonalities envia as alterações feitas a partir da chamada. O
+	// stream termina com RESOURCE_EXHAUSTED quando o cliente não acompanha o
+	// ritmo das alterações.
+	WatchPersonalities(*WatchPersonalitiesRequest, grpc.ServerStreamingServer[PersonalityEvent]) error
+	mustEmbedUnimplementedPersonalityServiceServer()
+}
+
+// UnimplementedPersonalityServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPersonalityServiceServer struct{}
+
+func (UnimplementedPersonalityServiceServer) GetPersonality(context.Context, *GetPersonalityRequest) (*Personality, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPersonality not implemented")
+}
+func (UnimplementedPersonalityServiceServer) ListPersonalities(*ListPersonalitiesRequest, grpc.ServerStreamingServer[Personality]) error {
+	return status.Errorf(codes.Unimplemented, "method ListPersonalities not implemented")
+}
+func (UnimplementedPersonalityServiceServer) CreatePersonality(context.Context, *CreatePersonalityRequest) (*Personality, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePersonality not implemented")
+}
+func (UnimplementedPersonalityServiceServer) UpdatePersonality(context.Context, *UpdatePersonalityRequest) (*Personality, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePersonality not implemented")
+}
+func (UnimplementedPersonalityServiceServer) DeletePersonality(context.Context, *DeletePersonalityRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePersonality not implemented")
+}
+func (UnimplementedPersonalityServiceServer) WatchPersonalities(*WatchPersonalitiesRequest, grpc.ServerStreamingServer[PersonalityEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPersonalities not implemented")
+}
+func (UnimplementedPersonalityServiceServer) mustEmbedUnimplementedPersonalityServiceServer() {}
+func (UnimplementedPersonalityServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafePersonalityServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PersonalityServiceServer will
+// result in compilation errors.
+type UnsafePersonalityServiceServer interface {
+	mustEmbedUnimplementedPersonalityServiceServer()
+}
+
+func RegisterPersonalityServiceServer(s grpc.ServiceRegistrar, srv PersonalityServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPersonalityServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PersonalityService_ServiceDesc, srv)
+}
+
+func _PersonalityService_GetPersonality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPersonalityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonalityServiceServer).GetPersonality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonalityService_GetPersonality_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonalityServiceServer).GetPersonality(ctx, req.(*GetPersonalityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonalityService_ListPersonalities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListPersonalitiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PersonalityServiceServer).ListPersonalities(m, &grpc.GenericServerStream[ListPersonalitiesRequest, Personality]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PersonalityService_ListPersonalitiesServer = grpc.ServerStreamingServer[Personality]
+
+func _PersonalityService_CreatePersonality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePersonalityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonalityServiceServer).CreatePersonality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonalityService_CreatePersonality_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonalityServiceServer).CreatePersonality(ctx, req.(*CreatePersonalityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonalityService_UpdatePersonality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePersonalityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonalityServiceServer).UpdatePersonality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonalityService_UpdatePersonality_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonalityServiceServer).UpdatePersonality(ctx, req.(*UpdatePersonalityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonalityService_DeletePersonality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePersonalityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersonalityServiceServer).DeletePersonality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersonalityService_DeletePersonality_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersonalityServiceServer).DeletePersonality(ctx, req.(*DeletePersonalityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersonalityService_WatchPersonalities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPersonalitiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PersonalityServiceServer).WatchPersonalities(m, &grpc.GenericServerStream[WatchPersonalitiesRequest, PersonalityEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PersonalityService_WatchPersonalitiesServer = grpc.ServerStreamingServer[PersonalityEvent]
+
+// PersonalityService_ServiceDesc is the grpc.ServiceDesc for PersonalityService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PersonalityService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "personality.v1.PersonalityService",
+	HandlerType: (*PersonalityServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPersonality",
+			Handler:    _PersonalityService_GetPersonality_Handler,
+		},
+		{
+			MethodName: "CreatePersonality",
+			Handler:    _PersonalityService_CreatePersonality_Handler,
+		},
+		{
+			MethodName: "UpdatePersonality",
+			Handler:    _PersonalityService_UpdatePersonality_Handler,
+		},
+		{
+			MethodName: "DeletePersonality",
+			Handler:    _PersonalityService_DeletePersonality_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListPersonalities",
+			Handler:       _PersonalityService_ListPersonalities_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchPersonalities",
+			Handler:       _PersonalityService_WatchPersonalities_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "personality/v1/personality.proto",
+}
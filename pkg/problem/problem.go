@@ -0,0 +1,178 @@
+// Package problem implementa respostas de erro no formato Problem Details
+// (RFC 9457) com códigos estáveis para consumo por máquinas.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"go-api-rest/pkg/i18n"
+	"go-api-rest/pkg/reqctx"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ContentType é o media type das respostas de erro
+const ContentType = "application/problem+json"
+
+// typeBase prefixa o código do problema no campo type; é um identificador
+// estável e não precisa ser resolvível (RFC 9457, seção 3.1.1)
+const typeBase = "urn:go-api-rest:problem:"
+
+// Type descreve uma categoria de problema: código, status e título fixos
+type Type struct {
+	Code   string
+	Status int
+	Title  string
+}
+
+// URI retorna o identificador do tipo, ex: urn:go-api-rest:problem:name-conflict
+func (t Type) URI() string {
+	return typeBase + strings.ToLower(strings.ReplaceAll(t.Code, "_", "-"))
+}
+
+// New cria um problema deste tipo com o detalhe da ocorrência
+func (t Type) New(detail string) *Problem {
+	return &Problem{
+		Type:   t.URI(),
+		Title:  t.Title,
+		Status: t.Status,
+		Detail: detail,
+		Code:   t.Code,
+	}
+}
+
+// Localized cria um problema cujo detalhe vem do catálogo de mensagens e é
+// traduzido para o idioma da requisição em Write
+func (t Type) Localized(key string, args ...interface{}) *Problem {
+	msg := i18n.Message{Key: key, Args: args}
+	p := t.New(msg.In(i18n.DefaultLocale))
+	p.message = &msg
+	return p
+}
+
+// Tipos genéricos da API; os tipos de domínio ficam junto dos handlers
+var (
+	ValidationFailed     = Type{"VALIDATION_FAILED", http.StatusBadRequest, "Dados inválidos"}
+	MalformedBody        = Type{"MALFORMED_BODY", http.StatusBadRequest, "Corpo da requisição malformado"}
+	PayloadTooLarge      = Type{"PAYLOAD_TOO_LARGE", http.StatusRequestEntityTooLarge, "Corpo da requisição muito grande"}
+	Unauthorized         = Type{"UNAUTHORIZED", http.StatusUnauthorized, "Não autorizado"}
+	OriginNotAllowed     = Type{"ORIGIN_NOT_ALLOWED", http.StatusForbidden, "Origem não permitida"}
+	HeadersNotAllowed    = Type{"HEADERS_NOT_ALLOWED", http.StatusForbidden, "Cabeçalhos não permitidos"}
+	RouteNotFound        = Type{"ROUTE_NOT_FOUND", http.StatusNotFound, "Rota não encontrada"}
+	MethodNotAllowed     = Type{"METHOD_NOT_ALLOWED", http.StatusMethodNotAllowed, "Método não permitido"}
+	NotAcceptable        = Type{"NOT_ACCEPTABLE", http.StatusNotAcceptable, "Formato de resposta não disponível"}
+	UnsupportedMediaType = Type{"UNSUPPORTED_MEDIA_TYPE", http.StatusUnsupportedMediaType, "Formato do corpo não suportado"}
+	UnsupportedEncoding  = Type{"UNSUPPORTED_CONTENT_ENCODING", http.StatusUnsupportedMediaType, "Codificação do corpo não suportada"}
+	UnsupportedVersion   = Type{"UNSUPPORTED_API_VERSION", http.StatusNotAcceptable, "Versão da API não suportada"}
+	RateLimited          = Type{"RATE_LIMITED", http.StatusTooManyRequests, "Limite de requisições excedido"}
+	Internal             = Type{"INTERNAL_ERROR", http.StatusInternalServerError, "Erro interno"}
+)
+
+// Problem é o corpo de uma resposta de erro (RFC 9457) com as extensões
+// code, request_id e errors
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+
+	message *i18n.Message
+}
+
+// FieldError descreve um campo inválido em erros de validação
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error permite retornar um Problem como erro comum
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Code + ": " + p.Detail
+	}
+	return p.Code
+}
+
+// Validation cria um problema de validação a partir das mensagens por campo
+func Validation(fields map[string]string) *Problem {
+	p := ValidationFailed.Localized("validation.invalid_data")
+	for field, message := range fields {
+		p.Errors = append(p.Errors, FieldError{Field: field, Message: message})
+	}
+	sort.Slice(p.Errors, func(i, j int) bool { return p.Errors[i].Field < p.Errors[j].Field })
+	return p
+}
+
+// In retorna uma cópia com título e detalhe traduzidos para locale quando o
+// catálogo os conhece; a chave do título é "problem." seguido do código em
+// minúsculas
+func (p *Problem) In(locale string) Problem {
+	body := *p
+	if title, ok := i18n.Lookup(locale, "problem."+strings.ToLower(body.Code)); ok {
+		body.Title = title
+	}
+	if body.message != nil {
+		body.Detail = body.message.In(locale)
+	}
+	return body
+}
+
+// Write envia o problema com o caminho e o ID da requisição, traduzido para o
+// idioma negociado
+func Write(w http.ResponseWriter, r *http.Request, p *Problem) {
+	body := p.In(reqctx.Locale(r.Context()))
+	if body.Instance == "" {
+		body.Instance = r.URL.Path
+	}
+	body.RequestID = reqctx.RequestID(r.Context())
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(body.Status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// Mapper traduz erros da aplicação para problemas
+type Mapper struct {
+	rules []rule
+}
+
+type rule struct {
+	target error
+	typ    Type
+}
+
+// NewMapper cria um Mapper vazio
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// Register associa um erro sentinela a um tipo; a mensagem do erro vira o
+// detalhe, traduzida quando o erro é um *i18n.Error
+func (m *Mapper) Register(target error, typ Type) *Mapper {
+	m.rules = append(m.rules, rule{target: target, typ: typ})
+	return m
+}
+
+// Map retorna o problema correspondente a err. Erros desconhecidos viram
+// INTERNAL_ERROR sem detalhe, para não expor informações internas.
+func (m *Mapper) Map(err error) *Problem {
+	var p *Problem
+	if errors.As(err, &p) {
+		return p
+	}
+	for _, rule := range m.rules {
+		if errors.Is(err, rule.target) {
+			var localized *i18n.Error
+			if errors.As(rule.target, &localized) {
+				return rule.typ.Localized(localized.Key, localized.Args...)
+			}
+			return rule.typ.New(rule.target.Error())
+		}
+	}
+	return Internal.New("")
+}
@@ -0,0 +1,64 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+var errNotFound = errors.New("registro não encontrado")
+
+func TestMapper_Map(t *testing.T) {
+	notFound := Type{Code: "RECORD_NOT_FOUND", Status: http.StatusNotFound, Title: "Registro não encontrado"}
+	m := NewMapper().Register(errNotFound, notFound)
+
+	tests := []struct {
+		name   string
+		err    error
+		code   string
+		detail string
+	}{
+		{"erro registrado", errNotFound, "RECORD_NOT_FOUND", "registro não encontrado"},
+		{"erro encapsulado", fmt.Errorf("buscar: %w", errNotFound), "RECORD_NOT_FOUND", "registro não encontrado"},
+		{"problema retornado como erro", fmt.Errorf("validar: %w", MalformedBody.New("JSON inválido")), "MALFORMED_BODY", "JSON inválido"},
+		{"erro desconhecido", errors.New("conexão recusada"), "INTERNAL_ERROR", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := m.Map(tt.err)
+			if p.Code != tt.code || p.Detail != tt.detail {
+				t.Errorf("Esperava %s %q, obteve %s %q", tt.code, tt.detail, p.Code, p.Detail)
+			}
+		})
+	}
+}
+
+func TestType_URI(t *testing.T) {
+	if got := RateLimited.URI(); got != "urn:go-api-rest:problem:rate-limited" {
+		t.Errorf("URI inesperada: %s", got)
+	}
+}
+
+func TestValidation_SortsFields(t *testing.T) {
+	p := Validation(map[string]string{"name": "obrigatório", "history": "muito curto"})
+	if len(p.Errors) != 2 || p.Errors[0].Field != "history" || p.Errors[1].Field != "name" {
+		t.Errorf("Esperava erros ordenados por campo, obteve %+v", p.Errors)
+	}
+	if p.Status != http.StatusBadRequest || p.Code != "VALIDATION_FAILED" {
+		t.Errorf("Problema inesperado: %+v", p)
+	}
+}
+
+func TestProblem_InTranslatesTitleAndDetail(t *testing.T) {
+	p := MethodNotAllowed.Localized("graphql.mutation_requires_post")
+
+	en := p.In("en")
+	if en.Title != "Method not allowed" || en.Detail != "Mutations can only be sent with POST" {
+		t.Errorf("Tradução inesperada: %q / %q", en.Title, en.Detail)
+	}
+	if p.Detail != "Mutations só podem ser enviadas com POST" {
+		t.Errorf("In não deveria alterar o original, obteve %q", p.Detail)
+	}
+}
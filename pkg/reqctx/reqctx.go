@@ -0,0 +1,71 @@
+package reqctx
+
+import "context"
+
+type contextKey int
+
+const (
+	userKey contextKey = iota
+	requestIDKey
+	primaryReadKey
+	localeKey
+	apiVersionKey
+)
+
+// WithPrimaryRead marca o contexto para que as leituras sejam feitas no banco
+// primário, garantindo read-your-writes após uma escrita
+func WithPrimaryRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadKey, true)
+}
+
+// PrimaryRead indica se as leituras devem ignorar as réplicas
+func PrimaryRead(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryReadKey).(bool)
+	return primary
+}
+
+// WithRequestID retorna um contexto contendo o ID de correlação da requisição
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID retorna o ID de correlação presente no contexto
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithUser retorna um contexto contendo o identificador do usuário autenticado
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userKey, user)
+}
+
+// User retorna o identificador do usuário presente no contexto
+func User(ctx context.Context) string {
+	user, _ := ctx.Value(userKey).(string)
+	return user
+}
+
+// WithLocale retorna um contexto contendo o idioma negociado para a resposta
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey, locale)
+}
+
+// Locale retorna o idioma presente no contexto
+func Locale(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey).(string)
+	return locale
+}
+
+// WithAPIVersion retorna um contexto contendo a versão da API pedida
+func WithAPIVersion(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, apiVersionKey, version)
+}
+
+// APIVersion retorna a versão da API presente no contexto, ou 1 quando ausente
+func APIVersion(ctx context.Context) int {
+	if version, ok := ctx.Value(apiVersionKey).(int); ok {
+		return version
+	}
+	return 1
+}
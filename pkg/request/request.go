@@ -0,0 +1,62 @@
+// Package request lê os corpos das requisições no formato do Content-Type,
+// com erros precisos para o cliente corrigir a requisição.
+package request
+
+import (
+	"errors"
+	"go-api-rest/pkg/codec"
+	"go-api-rest/pkg/problem"
+	"go-api-rest/pkg/response"
+	"io"
+	"net/http"
+)
+
+// Decode lê um único valor do corpo em v, rejeitando campos desconhecidos.
+// Erros do cliente voltam como *problem.Problem: 413 quando o corpo passa do
+// limite de http.MaxBytesReader e 400 com a posição ou o campo nos demais.
+// Content-Type não suportado volta como codec.ErrUnsupportedMediaType.
+func Decode(r *http.Request, v interface{}) error {
+	err := response.Registry().DecodeRequest(r, v)
+	if err == nil {
+		return nil
+	}
+
+	var tooLarge *http.MaxBytesError
+	var decodeErr *codec.DecodeError
+	switch {
+	case errors.Is(err, codec.ErrUnsupportedMediaType):
+		return err
+	case errors.As(err, &tooLarge):
+		return problem.PayloadTooLarge.Localized("request.body_too_large", tooLarge.Limit)
+	case errors.As(err, &decodeErr):
+		return decodeProblem(decodeErr)
+	}
+	return problem.MalformedBody.Localized("request.malformed_body")
+}
+
+// decodeProblem descreve o erro de decodificação com a informação mais precisa
+// que o formato forneceu
+func decodeProblem(err *codec.DecodeError) *problem.Problem {
+	switch err.Kind {
+	case codec.DecodeEmpty:
+		return problem.MalformedBody.Localized("request.empty_body")
+	case codec.DecodeTrailingData:
+		return problem.MalformedBody.Localized("request.trailing_data")
+	case codec.DecodeUnknownField:
+		return problem.MalformedBody.Localized("request.unknown_field", err.Field)
+	case codec.DecodeType:
+		if err.Field == "" {
+			return problem.MalformedBody.Localized("request.wrong_body_type", err.Expected)
+		}
+		return problem.MalformedBody.Localized("request.wrong_type", err.Field, err.Expected)
+	}
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return problem.MalformedBody.Localized("request.unexpected_end")
+	case err.Offset > 0:
+		return problem.MalformedBody.Localized("request.syntax_error_offset", err.Offset)
+	case err.Line > 0:
+		return problem.MalformedBody.Localized("request.syntax_error_line", err.Line)
+	}
+	return problem.MalformedBody.Localized("request.malformed_body")
+}
@@ -0,0 +1,84 @@
+package request
+
+import (
+	"bytes"
+	"errors"
+	"go-api-rest/pkg/codec"
+	"go-api-rest/pkg/problem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type payload struct {
+	Name  string `json:"name" xml:"name" yaml:"name"`
+	Count int    `json:"count" xml:"count" yaml:"count"`
+}
+
+func decode(contentType string, body []byte, limit int64) error {
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	if limit > 0 {
+		req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, limit)
+	}
+	var v payload
+	return Decode(req, &v)
+}
+
+func TestDecode(t *testing.T) {
+	unknownMsgpack, _ := msgpack.Marshal(map[string]interface{}{"name": "Ada", "extra": true})
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		limit       int64
+		status      int
+		detail      string
+	}{
+		{"JSON válido", "application/json", []byte(`{"name":"Ada","count":1}` + "\n"), 0, 0, ""},
+		{"JSON vazio", "application/json", nil, 0, http.StatusBadRequest, "O corpo da requisição está vazio"},
+		{"JSON com erro de sintaxe", "application/json", []byte(`{"name":"Ada",}`), 0, http.StatusBadRequest, "Sintaxe inválida no byte 15 do corpo"},
+		{"JSON truncado", "application/json", []byte(`{"name":"Ada"`), 0, http.StatusBadRequest, "O corpo termina no meio de um valor"},
+		{"JSON com tipo errado", "application/json", []byte(`{"count":"um"}`), 0, http.StatusBadRequest, "O campo count deve ser do tipo integer"},
+		{"JSON que não é objeto", "application/json", []byte(`[1]`), 0, http.StatusBadRequest, "O corpo deve ser do tipo object"},
+		{"JSON com campo desconhecido", "application/json", []byte(`{"name":"Ada","admin":true}`), 0, http.StatusBadRequest, "Campo desconhecido: admin"},
+		{"JSON com dois valores", "application/json", []byte(`{"name":"Ada"}{"name":"Bob"}`), 0, http.StatusBadRequest, "O corpo deve conter um único valor"},
+		{"JSON com lixo no fim", "application/json", []byte(`{"name":"Ada"} x`), 0, http.StatusBadRequest, "O corpo deve conter um único valor"},
+		{"JSON acima do limite", "application/json", []byte(`{"name":"` + strings.Repeat("a", 100) + `"}`), 32, http.StatusRequestEntityTooLarge, "O corpo da requisição excede o limite de 32 bytes"},
+		{"YAML com campo desconhecido", "application/yaml", []byte("name: Ada\nadmin: true\n"), 0, http.StatusBadRequest, "Campo desconhecido: admin"},
+		{"YAML com dois documentos", "application/yaml", []byte("name: Ada\n---\nname: Bob\n"), 0, http.StatusBadRequest, "O corpo deve conter um único valor"},
+		{"YAML com erro de sintaxe", "application/yaml", []byte("name: [Ada\n"), 0, http.StatusBadRequest, "Sintaxe inválida na linha 1 do corpo"},
+		{"XML com dois elementos", "application/xml", []byte(`<p><name>Ada</name></p><p/>`), 0, http.StatusBadRequest, "O corpo deve conter um único valor"},
+		{"XML com erro de sintaxe", "application/xml", []byte("<p>\n<name>Ada</p>"), 0, http.StatusBadRequest, "Sintaxe inválida na linha 2 do corpo"},
+		{"MessagePack com campo desconhecido", "application/msgpack", unknownMsgpack, 0, http.StatusBadRequest, "Campo desconhecido: extra"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := decode(tt.contentType, tt.body, tt.limit)
+			if tt.status == 0 {
+				if err != nil {
+					t.Fatalf("Erro inesperado: %v", err)
+				}
+				return
+			}
+			var p *problem.Problem
+			if !errors.As(err, &p) {
+				t.Fatalf("Esperava um problema, obteve %v", err)
+			}
+			if p.Status != tt.status || p.Detail != tt.detail {
+				t.Errorf("Esperava %d %q, obteve %d %q", tt.status, tt.detail, p.Status, p.Detail)
+			}
+		})
+	}
+}
+
+func TestDecode_UnsupportedMediaType(t *testing.T) {
+	if err := decode("text/plain", []byte("Ada"), 0); !errors.Is(err, codec.ErrUnsupportedMediaType) {
+		t.Errorf("Esperava ErrUnsupportedMediaType, obteve %v", err)
+	}
+}
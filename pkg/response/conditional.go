@@ -0,0 +1,71 @@
+package response
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Validators são os validadores HTTP de uma representação
+type Validators struct {
+	ETag         string    // entidade completa, com aspas e o prefixo W/ quando fraca
+	LastModified time.Time // zero omite o cabeçalho
+}
+
+// Set escreve ETag e Last-Modified na resposta
+func (v Validators) Set(w http.ResponseWriter) {
+	if v.ETag != "" {
+		w.Header().Set("ETag", v.ETag)
+	}
+	if !v.LastModified.IsZero() {
+		w.Header().Set("Last-Modified", v.LastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// NotModified escreve os validadores e, se a requisição GET/HEAD condicional
+// ainda corresponder à representação atual, responde 304 e retorna true
+func NotModified(w http.ResponseWriter, r *http.Request, v Validators) bool {
+	v.Set(w)
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if !isNotModified(r, v) {
+		return false
+	}
+
+	// 304 não tem corpo (RFC 9110, seção 15.4.5)
+	h := w.Header()
+	h.Del("Content-Type")
+	h.Del("Content-Length")
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// isNotModified avalia If-None-Match e, na sua ausência, If-Modified-Since
+func isNotModified(r *http.Request, v Validators) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return v.ETag != "" && etagMatches(inm, v.ETag)
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || v.LastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// Last-Modified tem precisão de segundos
+	return !v.LastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches aplica a comparação fraca usada por If-None-Match
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNotModified(t *testing.T) {
+	modified := time.Date(2024, 5, 10, 12, 0, 0, 500, time.UTC)
+	v := Validators{ETag: `W/"1-2"`, LastModified: modified}
+
+	tests := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		want    bool
+	}{
+		{"sem condições", http.MethodGet, nil, false},
+		{"ETag igual", http.MethodGet, map[string]string{"If-None-Match": `W/"1-2"`}, true},
+		{"ETag forte equivale na comparação fraca", http.MethodGet, map[string]string{"If-None-Match": `"1-2"`}, true},
+		{"ETag em lista", http.MethodGet, map[string]string{"If-None-Match": `"x", W/"1-2"`}, true},
+		{"curinga", http.MethodGet, map[string]string{"If-None-Match": "*"}, true},
+		{"ETag diferente", http.MethodGet, map[string]string{"If-None-Match": `W/"1-1"`}, false},
+		{"HEAD", http.MethodHead, map[string]string{"If-None-Match": `W/"1-2"`}, true},
+		{"não se aplica a PUT", http.MethodPut, map[string]string{"If-None-Match": `W/"1-2"`}, false},
+		{"não modificado desde", http.MethodGet, map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, true},
+		{"modificado depois", http.MethodGet, map[string]string{"If-Modified-Since": modified.Add(-time.Second).Format(http.TimeFormat)}, false},
+		{"If-None-Match tem precedência", http.MethodGet, map[string]string{
+			"If-None-Match":     `W/"1-1"`,
+			"If-Modified-Since": modified.Format(http.TimeFormat),
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", nil)
+			for k, val := range tt.headers {
+				req.Header.Set(k, val)
+			}
+			rec := httptest.NewRecorder()
+			rec.Header().Set("Content-Type", "application/json")
+
+			if got := NotModified(rec, req, v); got != tt.want {
+				t.Fatalf("Esperava %v, obteve %v", tt.want, got)
+			}
+			if rec.Header().Get("ETag") != `W/"1-2"` {
+				t.Errorf("Esperava ETag na resposta, obteve %q", rec.Header().Get("ETag"))
+			}
+			if tt.want && (rec.Code != http.StatusNotModified || rec.Header().Get("Content-Type") != "") {
+				t.Errorf("Esperava 304 sem Content-Type, obteve %d %q", rec.Code, rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -2,43 +2,69 @@ package response
 
 import (
 	"encoding/json"
-	"go-api-rest/internal/dto"
+	"go-api-rest/pkg/codec"
+	"go-api-rest/pkg/problem"
 	"net/http"
+	"strings"
+	"sync/atomic"
 )
 
-// JSON envia uma resposta JSON
+var registry atomic.Pointer[codec.Registry]
+
+func init() {
+	registry.Store(codec.Default())
+}
+
+// UseRegistry troca os formatos usados na negociação de conteúdo
+func UseRegistry(reg *codec.Registry) {
+	registry.Store(reg)
+}
+
+// Registry retorna os formatos usados na negociação de conteúdo
+func Registry() *codec.Registry {
+	return registry.Load()
+}
+
+// JSON envia uma resposta JSON, sem negociação; usada por endpoints operacionais
 func JSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(statusCode)
 	if data != nil {
 		json.NewEncoder(w).Encode(data)
 	}
 }
 
-// Success envia uma resposta de sucesso
-func Success(w http.ResponseWriter, statusCode int, data interface{}) {
-	JSON(w, statusCode, data)
+// Write envia a resposta no formato escolhido pelo Accept da requisição,
+// ou 406 quando nenhum formato aceitável representa o valor
+func Write(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}) {
+	w.Header().Add("Vary", "Accept")
+
+	enc, err := Registry().Negotiate(r.Header.Get("Accept"), data)
+	if err != nil {
+		NotAcceptable(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", enc.ContentType())
+	w.WriteHeader(statusCode)
+	if data != nil {
+		enc.Encode(w, data)
+	}
 }
 
-// Error envia uma resposta de erro
-func Error(w http.ResponseWriter, statusCode int, message string) {
-	JSON(w, statusCode, dto.ErrorResponse{
-		Error:   http.StatusText(statusCode),
-		Message: message,
-	})
+// Success envia uma resposta de sucesso
+func Success(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}) {
+	Write(w, r, statusCode, data)
 }
 
-// ValidationError envia uma resposta de erro de validação
-func ValidationError(w http.ResponseWriter, errors map[string]string) {
-	JSON(w, http.StatusBadRequest, dto.ErrorResponse{
-		Error:   "Erro de validação",
-		Message: "Os dados fornecidos são inválidos",
-		Details: errors,
-	})
+// NotAcceptable informa os formatos de resposta disponíveis
+func NotAcceptable(w http.ResponseWriter, r *http.Request) {
+	problem.Write(w, r, problem.NotAcceptable.Localized("response.available_formats", strings.Join(Registry().EncoderMediaTypes(), ", ")))
 }
 
 // Created envia uma resposta de recurso criado
-func Created(w http.ResponseWriter, data interface{}) {
-	JSON(w, http.StatusCreated, data)
+func Created(w http.ResponseWriter, r *http.Request, data interface{}) {
+	Write(w, r, http.StatusCreated, data)
 }
 
 // NoContent envia uma resposta sem conteúdo
@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Regras reutilizáveis da API, registradas no init com o nome da tag
+var rules = map[string]validator.Func{
+	"trimmed":    trimmed,
+	"personname": personName,
+	"nocontrol":  noControl,
+	"httpurl":    httpURL,
+	"daterange":  dateRange,
+}
+
+// dateLayout é o formato das datas em campos string e nos parâmetros de daterange
+const dateLayout = "2006-01-02"
+
+// trimmed rejeita espaços no início ou no fim do texto
+func trimmed(fl validator.FieldLevel) bool {
+	s, ok := stringValue(fl)
+	return ok && s == strings.TrimSpace(s)
+}
+
+// personName aceita letras Unicode (com acentos combinados), espaços,
+// apóstrofos, hífens e pontos, como em "Jean-Luc O'Neill Jr."
+func personName(fl validator.FieldLevel) bool {
+	s, ok := stringValue(fl)
+	if !ok {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r), unicode.Is(unicode.Mn, r):
+		case r == ' ', r == '\'', r == '’', r == '-', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// noControl rejeita caracteres de controle, exceto quebras de linha e tabulação
+func noControl(fl validator.FieldLevel) bool {
+	s, ok := stringValue(fl)
+	if !ok {
+		return false
+	}
+	for _, r := range s {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// httpURL aceita apenas URLs absolutas http ou https com host
+func httpURL(fl validator.FieldLevel) bool {
+	s, ok := stringValue(fl)
+	if !ok {
+		return false
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+// dateRange valida datas (time.Time ou string AAAA-MM-DD) dentro de um
+// intervalo fechado, ex: daterange=1900-01-01~now. Um limite vazio deixa o
+// intervalo aberto daquele lado.
+func dateRange(fl validator.FieldLevel) bool {
+	var date time.Time
+	switch v := fl.Field().Interface().(type) {
+	case time.Time:
+		date = v
+	case string:
+		parsed, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return false
+		}
+		date = parsed
+	default:
+		return false
+	}
+
+	minParam, maxParam, found := strings.Cut(fl.Param(), "~")
+	if !found {
+		return false
+	}
+	if minParam != "" {
+		min, ok := parseDateParam(minParam)
+		if !ok || date.Before(min) {
+			return false
+		}
+	}
+	if maxParam != "" {
+		max, ok := parseDateParam(maxParam)
+		if !ok || date.After(max) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDateParam interpreta um limite de daterange; "now" é o instante atual
+func parseDateParam(param string) (time.Time, bool) {
+	if param == "now" {
+		return time.Now(), true
+	}
+	date, err := time.Parse(dateLayout, param)
+	return date, err == nil
+}
+
+func stringValue(fl validator.FieldLevel) (string, bool) {
+	if fl.Field().Kind() != reflect.String {
+		return "", false
+	}
+	return fl.Field().String(), true
+}
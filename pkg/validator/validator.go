@@ -1,44 +1,169 @@
 package validator
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"go-api-rest/pkg/i18n"
+	"go-api-rest/pkg/reqctx"
+	"reflect"
 	"strings"
 
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/pt_BR"
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	esTranslations "github.com/go-playground/validator/v10/translations/es"
+	ptBRTranslations "github.com/go-playground/validator/v10/translations/pt_BR"
 )
 
 var validate *validator.Validate
 
+// StructLevel é o acesso à struct inteira em regras entre campos
+type StructLevel = validator.StructLevel
+
+// translators associa cada idioma do catálogo ao tradutor do validator
+var translators = make(map[string]ut.Translator)
+
+// catalogTags usam as mensagens validation.<tag> do catálogo da API em vez das
+// traduções padrão do validator; a função monta os argumentos após o campo
+var catalogTags = map[string]func(validator.FieldError) []interface{}{
+	"required":   noArgs,
+	"min":        paramArg,
+	"max":        paramArg,
+	"trimmed":    noArgs,
+	"personname": noArgs,
+	"nocontrol":  noArgs,
+	"httpurl":    noArgs,
+	"daterange":  rangeArg,
+	"atleastone": paramArg,
+}
+
 func init() {
 	validate = validator.New()
+	validate.RegisterTagNameFunc(jsonTagName)
+	for tag, fn := range rules {
+		if err := validate.RegisterValidation(tag, fn); err != nil {
+			panic("validator: regra " + tag + ": " + err.Error())
+		}
+	}
+
+	uni := ut.New(pt_BR.New(), pt_BR.New(), en.New(), es.New())
+	defaults := map[string]struct {
+		name     string
+		register func(*validator.Validate, ut.Translator) error
+	}{
+		i18n.PortugueseBR: {"pt_BR", ptBRTranslations.RegisterDefaultTranslations},
+		i18n.English:      {"en", enTranslations.RegisterDefaultTranslations},
+		i18n.Spanish:      {"es", esTranslations.RegisterDefaultTranslations},
+	}
+
+	for _, locale := range i18n.Locales() {
+		d := defaults[locale]
+		trans, _ := uni.GetTranslator(d.name)
+		if err := d.register(validate, trans); err != nil {
+			panic("validator: traduções de " + locale + ": " + err.Error())
+		}
+		for tag, args := range catalogTags {
+			registerCatalogTranslation(locale, tag, args, trans)
+		}
+		translators[locale] = trans
+	}
+}
+
+// RegisterStructRule registra uma validação entre campos para os tipos
+// informados; os erros devem ser reportados com sl.ReportError usando o nome
+// JSON do campo e uma tag com mensagem no catálogo
+func RegisterStructRule(fn func(StructLevel), types ...interface{}) {
+	validate.RegisterStructValidation(fn, types...)
+}
+
+// registerCatalogTranslation sobrescreve a tradução de tag com a chave
+// validation.<tag> do catálogo
+func registerCatalogTranslation(locale, tag string, args func(validator.FieldError) []interface{}, trans ut.Translator) {
+	validate.RegisterTranslation(tag, trans,
+		func(ut.Translator) error { return nil },
+		func(_ ut.Translator, fe validator.FieldError) string {
+			return i18n.Translate(locale, "validation."+tag, append([]interface{}{fe.Field()}, args(fe)...)...)
+		})
 }
 
-// ValidateStruct valida uma struct usando as tags de validação
-func ValidateStruct(s interface{}) map[string]string {
+func noArgs(validator.FieldError) []interface{} { return nil }
+
+func paramArg(fe validator.FieldError) []interface{} { return []interface{}{fe.Param()} }
+
+// rangeArg exibe o parâmetro min~max como intervalo, ex: [1900-01-01, now]
+func rangeArg(fe validator.FieldError) []interface{} {
+	min, max, _ := strings.Cut(fe.Param(), "~")
+	return []interface{}{"[" + min + ", " + max + "]"}
+}
+
+// jsonTagName usa o nome JSON do campo nas mensagens e caminhos de erro
+func jsonTagName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// ValidateStruct valida uma struct usando as tags de validação, com mensagens
+// no idioma padrão. As chaves do mapa são caminhos JSON (ex: "address.city",
+// "tags[0]"); o erro só é retornado quando s não é uma struct validável.
+func ValidateStruct(s interface{}) (map[string]string, error) {
+	return validateStruct(s, i18n.DefaultLocale)
+}
+
+// ValidateStructContext valida uma struct com mensagens no idioma negociado
+// na requisição
+func ValidateStructContext(ctx context.Context, s interface{}) (map[string]string, error) {
+	return validateStruct(s, reqctx.Locale(ctx))
+}
+
+func validateStruct(s interface{}, locale string) (map[string]string, error) {
 	err := validate.Struct(s)
 	if err == nil {
-		return nil
+		return nil, nil
 	}
 
-	errors := make(map[string]string)
-	for _, err := range err.(validator.ValidationErrors) {
-		field := strings.ToLower(err.Field())
-		errors[field] = getErrorMessage(err)
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil, fmt.Errorf("validar %T: %w", s, err)
 	}
 
-	return errors
+	trans, ok := translators[locale]
+	if !ok {
+		locale, trans = i18n.DefaultLocale, translators[i18n.DefaultLocale]
+	}
+
+	fields := make(map[string]string, len(validationErrors))
+	for _, err := range validationErrors {
+		fields[fieldPath(err)] = getErrorMessage(err, locale, trans)
+	}
+
+	return fields, nil
+}
+
+// getErrorMessage retorna a mensagem traduzida; tags sem tradução recebem
+// uma mensagem genérica
+func getErrorMessage(err validator.FieldError, locale string, trans ut.Translator) string {
+	message := err.Translate(trans)
+	if message == "" || message == err.Error() {
+		return i18n.Translate(locale, "validation.invalid", err.Field())
+	}
+	return message
 }
 
-// getErrorMessage retorna uma mensagem de erro amigável
-func getErrorMessage(err validator.FieldError) string {
-	switch err.Tag() {
-	case "required":
-		return fmt.Sprintf("O campo %s é obrigatório", strings.ToLower(err.Field()))
-	case "min":
-		return fmt.Sprintf("O campo %s deve ter no mínimo %s caracteres", strings.ToLower(err.Field()), err.Param())
-	case "max":
-		return fmt.Sprintf("O campo %s deve ter no máximo %s caracteres", strings.ToLower(err.Field()), err.Param())
-	default:
-		return fmt.Sprintf("O campo %s é inválido", strings.ToLower(err.Field()))
+// fieldPath remove o nome da struct raiz do namespace do erro
+func fieldPath(err validator.FieldError) string {
+	_, path, found := strings.Cut(err.Namespace(), ".")
+	if !found {
+		return err.Field()
 	}
+	return path
 }
@@ -0,0 +1,129 @@
+package validator
+
+import (
+	"context"
+	"go-api-rest/pkg/i18n"
+	"go-api-rest/pkg/reqctx"
+	"testing"
+	"time"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type profile struct {
+	Name     string    `json:"name" validate:"required,trimmed,personname,min=3"`
+	Bio      string    `json:"bio" validate:"nocontrol"`
+	Site     string    `json:"site,omitempty" validate:"omitempty,httpurl"`
+	Birth    string    `json:"birth" validate:"omitempty,daterange=1900-01-01~now"`
+	Death    time.Time `json:"death" validate:"omitempty,daterange=1900-01-01~"`
+	Address  address   `json:"address"`
+	Tags     []string  `json:"tags" validate:"dive,min=2"`
+	Internal string    `json:"-" validate:"omitempty,min=100"`
+}
+
+func validProfile() profile {
+	return profile{Name: "Zoë O'Neill-Ávila Jr.", Bio: "Linha 1\nLinha 2", Address: address{City: "Recife"}}
+}
+
+func TestValidateStruct_Rules(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*profile)
+		field  string
+	}{
+		{"válido", func(*profile) {}, ""},
+		{"espaço no início", func(p *profile) { p.Name = " Ada" }, "name"},
+		{"dígitos no nome", func(p *profile) { p.Name = "Ada 2" }, "name"},
+		{"caractere de controle", func(p *profile) { p.Bio = "texto\x00" }, "bio"},
+		{"URL sem http", func(p *profile) { p.Site = "ftp://example.com" }, "site"},
+		{"URL relativa", func(p *profile) { p.Site = "/perfil" }, "site"},
+		{"URL válida", func(p *profile) { p.Site = "https://example.com/ada" }, ""},
+		{"data no futuro", func(p *profile) { p.Birth = time.Now().AddDate(1, 0, 0).Format("2006-01-02") }, "birth"},
+		{"data antes do mínimo", func(p *profile) { p.Birth = "1850-12-10" }, "birth"},
+		{"data malformada", func(p *profile) { p.Birth = "10/12/1950" }, "birth"},
+		{"time.Time no intervalo aberto", func(p *profile) { p.Death = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC) }, ""},
+		{"time.Time antes do mínimo", func(p *profile) { p.Death = time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC) }, "death"},
+		{"campo aninhado", func(p *profile) { p.Address.City = "" }, "address.city"},
+		{"item de lista", func(p *profile) { p.Tags = []string{"go", "x"} }, "tags[1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := validProfile()
+			tt.modify(&p)
+			fields, err := ValidateStruct(p)
+			if err != nil {
+				t.Fatalf("Erro inesperado: %v", err)
+			}
+			if tt.field == "" {
+				if fields != nil {
+					t.Errorf("Esperava struct válida, obteve %v", fields)
+				}
+				return
+			}
+			if _, ok := fields[tt.field]; !ok || len(fields) != 1 {
+				t.Errorf("Esperava erro apenas em %s, obteve %v", tt.field, fields)
+			}
+		})
+	}
+}
+
+type period struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func TestRegisterStructRule(t *testing.T) {
+	RegisterStructRule(func(sl StructLevel) {
+		p := sl.Current().Interface().(period)
+		if p.End < p.Start {
+			sl.ReportError(p.End, "end", "End", "gtefield", "start")
+		}
+	}, period{})
+
+	fields, err := ValidateStruct(period{Start: "2024-02-01", End: "2024-01-01"})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if _, ok := fields["end"]; !ok {
+		t.Errorf("Esperava erro em end, obteve %v", fields)
+	}
+}
+
+func TestValidateStruct_InvalidInput(t *testing.T) {
+	if _, err := ValidateStruct(nil); err == nil {
+		t.Error("Esperava erro ao validar nil em vez de panic")
+	}
+	if _, err := ValidateStruct("texto"); err == nil {
+		t.Error("Esperava erro ao validar um valor que não é struct")
+	}
+}
+
+func TestValidateStructContext_Locale(t *testing.T) {
+	p := validProfile()
+	p.Name = ""
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{i18n.PortugueseBR, "O campo name é obrigatório"},
+		{i18n.English, "The name field is required"},
+		{i18n.Spanish, "El campo name es obligatorio"},
+		{"", "O campo name é obrigatório"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			fields, err := ValidateStructContext(reqctx.WithLocale(context.Background(), tt.locale), p)
+			if err != nil {
+				t.Fatalf("Erro inesperado: %v", err)
+			}
+			if fields["name"] != tt.want {
+				t.Errorf("Esperava %q, obteve %q", tt.want, fields["name"])
+			}
+		})
+	}
+}